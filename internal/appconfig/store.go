@@ -12,12 +12,16 @@ import (
 type Config struct {
 	DefaultRoom string `json:"defaultRoom,omitempty"`
 	Format      string `json:"format,omitempty"`
+
+	// Rooms holds per-room volume limits, keyed by room name.
+	Rooms map[string]RoomConfig `json:"rooms,omitempty"`
 }
 
 func (c Config) Normalize() Config {
 	out := Config{
 		DefaultRoom: strings.TrimSpace(c.DefaultRoom),
 		Format:      strings.ToLower(strings.TrimSpace(c.Format)),
+		Rooms:       normalizeRooms(c.Rooms),
 	}
 	if out.Format == "" {
 		out.Format = "plain"
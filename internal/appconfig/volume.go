@@ -0,0 +1,247 @@
+package appconfig
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RoomConfig holds per-room volume safety limits. Zero values mean "no limit".
+type RoomConfig struct {
+	MaxVolume  int          `json:"maxVolume,omitempty"`
+	MinVolume  int          `json:"minVolume,omitempty"`
+	QuietHours []QuietHours `json:"quietHours,omitempty"`
+}
+
+func (r RoomConfig) isZero() bool {
+	return r.MaxVolume == 0 && r.MinVolume == 0 && len(r.QuietHours) == 0
+}
+
+// QuietHours caps the volume between Start and End (local "HH:MM"; windows
+// may wrap past midnight, e.g. 19:30-07:00).
+type QuietHours struct {
+	Start     string `json:"start"`
+	End       string `json:"end"`
+	MaxVolume int    `json:"maxVolume"`
+}
+
+func (q QuietHours) String() string {
+	return fmt.Sprintf("%s-%s=%d", q.Start, q.End, q.MaxVolume)
+}
+
+// Contains reports whether t (local time of day) falls inside the window.
+func (q QuietHours) Contains(t time.Time) bool {
+	start, err1 := parseClock(q.Start)
+	end, err2 := parseClock(q.End)
+	if err1 != nil || err2 != nil || start == end {
+		return false
+	}
+	m := t.Hour()*60 + t.Minute()
+	if start < end {
+		return m >= start && m < end
+	}
+	return m >= start || m < end
+}
+
+// ParseQuietHours parses a comma-separated list of "HH:MM-HH:MM=<max>"
+// windows, e.g. "19:30-07:00=15,12:30-14:00=20".
+func ParseQuietHours(s string) ([]QuietHours, error) {
+	var out []QuietHours
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		span, maxStr, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid quiet hours %q (expected HH:MM-HH:MM=<max>)", part)
+		}
+		startStr, endStr, ok := strings.Cut(span, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid quiet hours %q (expected HH:MM-HH:MM=<max>)", part)
+		}
+		start, err := parseClock(startStr)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseClock(endStr)
+		if err != nil {
+			return nil, err
+		}
+		if start == end {
+			return nil, fmt.Errorf("invalid quiet hours %q (start equals end)", part)
+		}
+		maxVol, err := strconv.Atoi(strings.TrimSpace(maxStr))
+		if err != nil || maxVol < 0 || maxVol > 100 {
+			return nil, fmt.Errorf("invalid quiet hours volume %q (expected 0-100)", maxStr)
+		}
+		out = append(out, QuietHours{Start: formatClock(start), End: formatClock(end), MaxVolume: maxVol})
+	}
+	if len(out) == 0 {
+		return nil, errors.New("no quiet hours given")
+	}
+	return out, nil
+}
+
+// FormatQuietHours is the inverse of ParseQuietHours.
+func FormatQuietHours(qs []QuietHours) string {
+	parts := make([]string, 0, len(qs))
+	for _, q := range qs {
+		parts = append(parts, q.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (expected HH:MM)", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClock(m int) string {
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
+
+// Room returns the settings for room (matched case-insensitively).
+func (c Config) Room(room string) (RoomConfig, bool) {
+	room = strings.TrimSpace(room)
+	if rc, ok := c.Rooms[room]; ok {
+		return rc, true
+	}
+	for name, rc := range c.Rooms {
+		if strings.EqualFold(name, room) {
+			return rc, true
+		}
+	}
+	return RoomConfig{}, false
+}
+
+// RenameRoom moves the settings stored for room from (matched
+// case-insensitively) to to, and points DefaultRoom at to when it named from.
+// It reports whether anything changed.
+func (c Config) RenameRoom(from, to string) (Config, bool) {
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if from == to {
+		return c, false
+	}
+	changed := false
+	if strings.EqualFold(strings.TrimSpace(c.DefaultRoom), from) {
+		c.DefaultRoom = to
+		changed = true
+	}
+	if rc, ok := c.Room(from); ok {
+		rooms := make(map[string]RoomConfig, len(c.Rooms))
+		for name, v := range c.Rooms {
+			if !strings.EqualFold(strings.TrimSpace(name), from) {
+				rooms[name] = v
+			}
+		}
+		rooms[to] = rc
+		c.Rooms = rooms
+		changed = true
+	}
+	return c, changed
+}
+
+// VolumeLimit is the volume range allowed right now, with a short reason for
+// each bound that is narrower than 0-100.
+type VolumeLimit struct {
+	Min       int    `json:"min"`
+	Max       int    `json:"max"`
+	MinReason string `json:"minReason,omitempty"`
+	MaxReason string `json:"maxReason,omitempty"`
+}
+
+// VolumeLimit returns the strictest limit across rooms at time now. A volume
+// change that affects a whole group passes all of its rooms.
+func (c Config) VolumeLimit(now time.Time, rooms ...string) VolumeLimit {
+	l := VolumeLimit{Min: 0, Max: 100}
+	for _, room := range rooms {
+		rc, ok := c.Room(room)
+		if !ok {
+			continue
+		}
+		if rc.MaxVolume > 0 && rc.MaxVolume < l.Max {
+			l.Max, l.MaxReason = rc.MaxVolume, room+" maxVolume"
+		}
+		for _, q := range rc.QuietHours {
+			if q.Contains(now) && q.MaxVolume < l.Max {
+				l.Max, l.MaxReason = q.MaxVolume, fmt.Sprintf("%s quiet hours %s-%s", room, q.Start, q.End)
+			}
+		}
+		if rc.MinVolume > l.Min {
+			l.Min, l.MinReason = rc.MinVolume, room+" minVolume"
+		}
+	}
+	if l.Min > l.Max {
+		l.Min, l.MinReason = l.Max, ""
+	}
+	return l
+}
+
+// Clamp returns v limited to the range, and the reason when it had to change.
+func (l VolumeLimit) Clamp(v int) (int, string) {
+	switch {
+	case v > l.Max:
+		return l.Max, l.MaxReason
+	case v < l.Min:
+		return l.Min, l.MinReason
+	default:
+		return v, ""
+	}
+}
+
+func normalizeRooms(rooms map[string]RoomConfig) map[string]RoomConfig {
+	if len(rooms) == 0 {
+		return nil
+	}
+	out := map[string]RoomConfig{}
+	for name, rc := range rooms {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		rc.MaxVolume = ClampPercent(rc.MaxVolume)
+		rc.MinVolume = ClampPercent(rc.MinVolume)
+		if rc.MaxVolume > 0 && rc.MinVolume > rc.MaxVolume {
+			rc.MinVolume = rc.MaxVolume
+		}
+		quiet := rc.QuietHours[:0:0]
+		for _, q := range rc.QuietHours {
+			parsed, err := ParseQuietHours(q.String())
+			if err != nil {
+				continue
+			}
+			quiet = append(quiet, parsed...)
+		}
+		sort.SliceStable(quiet, func(i, j int) bool { return quiet[i].Start < quiet[j].Start })
+		rc.QuietHours = quiet
+		if len(rc.QuietHours) == 0 {
+			rc.QuietHours = nil
+		}
+		if rc.isZero() {
+			continue
+		}
+		out[name] = rc
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// ClampPercent limits v to the 0-100 volume range.
+func ClampPercent(v int) int {
+	if v < 0 {
+		return 0
+	}
+	if v > 100 {
+		return 100
+	}
+	return v
+}
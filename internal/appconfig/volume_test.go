@@ -0,0 +1,115 @@
+package appconfig
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseQuietHours(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseQuietHours("19:30-7:00=15, 12:30-14:00=20")
+	if err != nil {
+		t.Fatalf("ParseQuietHours: %v", err)
+	}
+	if len(got) != 2 || got[0] != (QuietHours{Start: "19:30", End: "07:00", MaxVolume: 15}) || got[1].MaxVolume != 20 {
+		t.Fatalf("unexpected windows: %+v", got)
+	}
+	if s := FormatQuietHours(got); s != "19:30-07:00=15,12:30-14:00=20" {
+		t.Fatalf("FormatQuietHours: %q", s)
+	}
+
+	for _, bad := range []string{"", "19:30-07:00", "19:30=15", "25:00-07:00=15", "07:00-07:00=10", "19:30-07:00=101"} {
+		if _, err := ParseQuietHours(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestQuietHoursContainsWrapsMidnight(t *testing.T) {
+	t.Parallel()
+
+	q := QuietHours{Start: "19:30", End: "07:00", MaxVolume: 15}
+	at := func(h, m int) time.Time { return time.Date(2025, 1, 1, h, m, 0, 0, time.Local) }
+	for _, tc := range []struct {
+		t    time.Time
+		want bool
+	}{
+		{at(19, 29), false},
+		{at(19, 30), true},
+		{at(23, 59), true},
+		{at(3, 0), true},
+		{at(7, 0), false},
+		{at(12, 0), false},
+	} {
+		if got := q.Contains(tc.t); got != tc.want {
+			t.Fatalf("Contains(%s) = %v, want %v", tc.t.Format("15:04"), got, tc.want)
+		}
+	}
+}
+
+func TestVolumeLimitStrictestAcrossRooms(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{Rooms: map[string]RoomConfig{
+		"Kids Room": {MaxVolume: 40, QuietHours: []QuietHours{{Start: "19:30", End: "07:00", MaxVolume: 15}}},
+		"Kitchen":   {MaxVolume: 70, MinVolume: 10},
+	}}
+	day := time.Date(2025, 1, 1, 12, 0, 0, 0, time.Local)
+	night := time.Date(2025, 1, 1, 21, 0, 0, 0, time.Local)
+
+	l := cfg.VolumeLimit(day, "kids room", "Kitchen", "Office")
+	if l.Min != 10 || l.Max != 40 || l.MaxReason != "kids room maxVolume" || l.MinReason != "Kitchen minVolume" {
+		t.Fatalf("day limit: %+v", l)
+	}
+	if v, reason := l.Clamp(100); v != 40 || reason == "" {
+		t.Fatalf("Clamp(100) = %d, %q", v, reason)
+	}
+	if v, reason := l.Clamp(5); v != 10 || reason != "Kitchen minVolume" {
+		t.Fatalf("Clamp(5) = %d, %q", v, reason)
+	}
+	if v, reason := l.Clamp(25); v != 25 || reason != "" {
+		t.Fatalf("Clamp(25) = %d, %q", v, reason)
+	}
+
+	l = cfg.VolumeLimit(night, "Kids Room")
+	if l.Max != 15 || l.MaxReason != "Kids Room quiet hours 19:30-07:00" {
+		t.Fatalf("night limit: %+v", l)
+	}
+
+	l = cfg.VolumeLimit(night, "Office")
+	if l.Min != 0 || l.Max != 100 || l.MinReason != "" || l.MaxReason != "" {
+		t.Fatalf("unconfigured room: %+v", l)
+	}
+}
+
+func TestFileStore_SaveLoadRooms(t *testing.T) {
+	t.Parallel()
+
+	s, err := NewFileStore(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	cfg := Config{Rooms: map[string]RoomConfig{
+		" Kids Room ": {MaxVolume: 140, MinVolume: 50, QuietHours: []QuietHours{{Start: "7:00", End: "6:00", MaxVolume: 10}, {Start: "x", End: "y", MaxVolume: 5}}},
+		"Empty":       {},
+	}}
+	if err := s.Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	got, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	rc, ok := got.Rooms["Kids Room"]
+	if len(got.Rooms) != 1 || !ok {
+		t.Fatalf("rooms: %+v", got.Rooms)
+	}
+	if rc.MaxVolume != 100 || rc.MinVolume != 50 {
+		t.Fatalf("limits: %+v", rc)
+	}
+	if len(rc.QuietHours) != 1 || rc.QuietHours[0] != (QuietHours{Start: "07:00", End: "06:00", MaxVolume: 10}) {
+		t.Fatalf("quiet hours: %+v", rc.QuietHours)
+	}
+}
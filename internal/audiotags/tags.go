@@ -0,0 +1,302 @@
+package audiotags
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+type Tags struct {
+	Title       string `json:"title"`
+	Artist      string `json:"artist,omitempty"`
+	Album       string `json:"album,omitempty"`
+	TrackNumber int    `json:"trackNumber,omitempty"`
+}
+
+func (t Tags) empty() bool {
+	return t.Title == "" && t.Artist == "" && t.Album == ""
+}
+
+// Read returns the tags of the file at path (ID3v2, ID3v1 or FLAC Vorbis comments).
+// Missing fields are filled in from the file name ("01 - Artist - Title.mp3") and
+// the parent directory (album).
+func Read(path string) (Tags, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Tags{}, err
+	}
+	defer f.Close()
+
+	var tags Tags
+	head := make([]byte, 10)
+	if _, err := io.ReadFull(f, head); err == nil {
+		switch {
+		case bytes.HasPrefix(head, []byte("ID3")):
+			tags, _ = readID3v2(f, head)
+		case bytes.HasPrefix(head, []byte("fLaC")):
+			tags, _ = readFLAC(f)
+		}
+	}
+	if tags.empty() {
+		if v1, err := readID3v1(f); err == nil {
+			tags = v1
+		}
+	}
+	return mergeFileName(tags, path), nil
+}
+
+var leadingTrackNumber = regexp.MustCompile(`^(\d{1,3})[\s._-]+`)
+
+// FromFileName derives tags from a file name alone.
+func FromFileName(path string) Tags {
+	return mergeFileName(Tags{}, path)
+}
+
+func mergeFileName(t Tags, path string) Tags {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	name := strings.TrimSpace(strings.ReplaceAll(base, "_", " "))
+	if m := leadingTrackNumber.FindStringSubmatch(name); m != nil {
+		if t.TrackNumber == 0 {
+			t.TrackNumber, _ = strconv.Atoi(m[1])
+		}
+		name = strings.TrimSpace(name[len(m[0]):])
+	}
+	artist, title := "", name
+	if parts := strings.SplitN(name, " - ", 2); len(parts) == 2 {
+		artist, title = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	}
+	if t.Title == "" {
+		t.Title = title
+		if t.Artist == "" {
+			t.Artist = artist
+		}
+	}
+	if t.Album == "" {
+		if dir := filepath.Base(filepath.Dir(path)); dir != "." && dir != string(filepath.Separator) {
+			t.Album = dir
+		}
+	}
+	return t
+}
+
+func syncsafe(b []byte) int {
+	return int(b[0]&0x7f)<<21 | int(b[1]&0x7f)<<14 | int(b[2]&0x7f)<<7 | int(b[3]&0x7f)
+}
+
+func readID3v2(r io.Reader, header []byte) (Tags, error) {
+	version := header[3]
+	size := syncsafe(header[6:10])
+	if size <= 0 || size > 16<<20 {
+		return Tags{}, errors.New("invalid id3v2 size")
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return Tags{}, err
+	}
+	if header[5]&0x40 != 0 && version >= 3 && len(body) >= 4 {
+		// Skip the extended header.
+		ext := int(binary.BigEndian.Uint32(body[:4]))
+		if version == 4 {
+			ext = syncsafe(body[:4])
+		}
+		if ext < len(body) {
+			body = body[ext:]
+		}
+	}
+
+	idLen, hdrLen := 4, 10
+	if version == 2 {
+		idLen, hdrLen = 3, 6
+	}
+	var t Tags
+	for len(body) >= hdrLen && body[0] != 0 {
+		id := string(body[:idLen])
+		var n int
+		switch version {
+		case 2:
+			n = int(body[3])<<16 | int(body[4])<<8 | int(body[5])
+		case 4:
+			n = syncsafe(body[4:8])
+		default:
+			n = int(binary.BigEndian.Uint32(body[4:8]))
+		}
+		if n <= 0 || hdrLen+n > len(body) {
+			break
+		}
+		data := body[hdrLen : hdrLen+n]
+		body = body[hdrLen+n:]
+
+		switch id {
+		case "TIT2", "TT2":
+			t.Title = decodeID3Text(data)
+		case "TPE1", "TP1":
+			t.Artist = decodeID3Text(data)
+		case "TALB", "TAL":
+			t.Album = decodeID3Text(data)
+		case "TRCK", "TRK":
+			t.TrackNumber = parseTrackNumber(decodeID3Text(data))
+		}
+	}
+	return t, nil
+}
+
+func decodeID3Text(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	enc, data := data[0], data[1:]
+	var s string
+	switch enc {
+	case 1, 2: // UTF-16 with BOM, UTF-16BE
+		bigEndian := enc == 2
+		if len(data) >= 2 {
+			switch {
+			case data[0] == 0xff && data[1] == 0xfe:
+				bigEndian, data = false, data[2:]
+			case data[0] == 0xfe && data[1] == 0xff:
+				bigEndian, data = true, data[2:]
+			}
+		}
+		u := make([]uint16, 0, len(data)/2)
+		for i := 0; i+1 < len(data); i += 2 {
+			if bigEndian {
+				u = append(u, uint16(data[i])<<8|uint16(data[i+1]))
+			} else {
+				u = append(u, uint16(data[i+1])<<8|uint16(data[i]))
+			}
+		}
+		s = string(utf16.Decode(u))
+	case 3: // UTF-8
+		s = string(data)
+	default: // ISO-8859-1
+		s = latin1(data)
+	}
+	// Multiple values are NUL-separated; keep the first.
+	if i := strings.IndexByte(s, 0); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+func latin1(b []byte) string {
+	r := make([]rune, len(b))
+	for i, c := range b {
+		r[i] = rune(c)
+	}
+	return string(r)
+}
+
+func parseTrackNumber(s string) int {
+	s, _, _ = strings.Cut(strings.TrimSpace(s), "/")
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func readID3v1(f *os.File) (Tags, error) {
+	st, err := f.Stat()
+	if err != nil || st.Size() < 128 {
+		return Tags{}, errors.New("no id3v1 tag")
+	}
+	buf := make([]byte, 128)
+	if _, err := f.ReadAt(buf, st.Size()-128); err != nil {
+		return Tags{}, err
+	}
+	if !bytes.HasPrefix(buf, []byte("TAG")) {
+		return Tags{}, errors.New("no id3v1 tag")
+	}
+	field := func(b []byte) string {
+		if i := bytes.IndexByte(b, 0); i >= 0 {
+			b = b[:i]
+		}
+		return strings.TrimSpace(latin1(b))
+	}
+	t := Tags{Title: field(buf[3:33]), Artist: field(buf[33:63]), Album: field(buf[63:93])}
+	if buf[125] == 0 && buf[126] != 0 {
+		t.TrackNumber = int(buf[126])
+	}
+	return t, nil
+}
+
+// readFLAC walks the metadata blocks after the "fLaC" marker to the Vorbis comment.
+func readFLAC(f *os.File) (Tags, error) {
+	if _, err := f.Seek(4, io.SeekStart); err != nil {
+		return Tags{}, err
+	}
+	hdr := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(f, hdr); err != nil {
+			return Tags{}, err
+		}
+		last := hdr[0]&0x80 != 0
+		typ := hdr[0] & 0x7f
+		n := int(hdr[1])<<16 | int(hdr[2])<<8 | int(hdr[3])
+		if typ == 4 {
+			block := make([]byte, n)
+			if _, err := io.ReadFull(f, block); err != nil {
+				return Tags{}, err
+			}
+			return parseVorbisComment(block), nil
+		}
+		if last {
+			return Tags{}, errors.New("no vorbis comment")
+		}
+		if _, err := f.Seek(int64(n), io.SeekCurrent); err != nil {
+			return Tags{}, err
+		}
+	}
+}
+
+func parseVorbisComment(b []byte) Tags {
+	var t Tags
+	next := func() (string, bool) {
+		if len(b) < 4 {
+			return "", false
+		}
+		n := int(binary.LittleEndian.Uint32(b[:4]))
+		if n < 0 || 4+n > len(b) {
+			return "", false
+		}
+		s := string(b[4 : 4+n])
+		b = b[4+n:]
+		return s, true
+	}
+	if _, ok := next(); !ok { // vendor string
+		return t
+	}
+	if len(b) < 4 {
+		return t
+	}
+	count := int(binary.LittleEndian.Uint32(b[:4]))
+	b = b[4:]
+	for i := 0; i < count; i++ {
+		c, ok := next()
+		if !ok {
+			break
+		}
+		k, v, ok := strings.Cut(c, "=")
+		if !ok {
+			continue
+		}
+		v = strings.TrimSpace(v)
+		switch strings.ToUpper(k) {
+		case "TITLE":
+			t.Title = v
+		case "ARTIST":
+			if t.Artist == "" {
+				t.Artist = v
+			}
+		case "ALBUM":
+			t.Album = v
+		case "TRACKNUMBER":
+			t.TrackNumber = parseTrackNumber(v)
+		}
+	}
+	return t
+}
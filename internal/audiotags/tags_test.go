@@ -0,0 +1,108 @@
+package audiotags
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func id3Frame(id string, text []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString(id)
+	_ = binary.Write(&b, binary.BigEndian, uint32(len(text)))
+	b.Write([]byte{0, 0})
+	b.Write(text)
+	return b.Bytes()
+}
+
+func writeID3v23(t *testing.T, path string) {
+	t.Helper()
+	var frames bytes.Buffer
+	frames.Write(id3Frame("TIT2", append([]byte{3}, "Blue in Green"...)))
+	frames.Write(id3Frame("TPE1", []byte{1, 0xff, 0xfe, 'M', 0, 'i', 0, 'l', 0, 'e', 0, 's', 0}))
+	frames.Write(id3Frame("TALB", append([]byte{0}, "Kind of Blue"...)))
+	frames.Write(id3Frame("TRCK", append([]byte{0}, "3/5"...)))
+	frames.Write(make([]byte, 16)) // padding
+
+	size := frames.Len()
+	header := []byte{'I', 'D', '3', 3, 0, 0,
+		byte(size >> 21 & 0x7f), byte(size >> 14 & 0x7f), byte(size >> 7 & 0x7f), byte(size & 0x7f)}
+	data := append(header, frames.Bytes()...)
+	data = append(data, []byte("audio-data")...)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadID3v2(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "track.mp3")
+	writeID3v23(t, path)
+
+	tags, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	want := Tags{Title: "Blue in Green", Artist: "Miles", Album: "Kind of Blue", TrackNumber: 3}
+	if tags != want {
+		t.Fatalf("got %+v, want %+v", tags, want)
+	}
+}
+
+func TestReadFLACVorbisComment(t *testing.T) {
+	t.Parallel()
+
+	var vc bytes.Buffer
+	str := func(s string) {
+		_ = binary.Write(&vc, binary.LittleEndian, uint32(len(s)))
+		vc.WriteString(s)
+	}
+	str("reference libFLAC")
+	_ = binary.Write(&vc, binary.LittleEndian, uint32(3))
+	str("TITLE=So What")
+	str("artist=Miles Davis")
+	str("TRACKNUMBER=1")
+
+	var data bytes.Buffer
+	data.WriteString("fLaC")
+	data.Write([]byte{0, 0, 0, 4}) // STREAMINFO (truncated for the test)
+	data.Write([]byte{1, 2, 3, 4})
+	n := vc.Len()
+	data.Write([]byte{0x80 | 4, byte(n >> 16), byte(n >> 8), byte(n)})
+	data.Write(vc.Bytes())
+
+	path := filepath.Join(t.TempDir(), "Kind of Blue", "01.flac")
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	want := Tags{Title: "So What", Artist: "Miles Davis", Album: "Kind of Blue", TrackNumber: 1}
+	if tags != want {
+		t.Fatalf("got %+v, want %+v", tags, want)
+	}
+}
+
+func TestFromFileName(t *testing.T) {
+	t.Parallel()
+
+	got := FromFileName("/music/Live at the Plugged Nickel/07 - Miles Davis - Stella by Starlight.m4a")
+	want := Tags{Title: "Stella by Starlight", Artist: "Miles Davis", Album: "Live at the Plugged Nickel", TrackNumber: 7}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	got = FromFileName("doorbell_chime.wav")
+	if got.Title != "doorbell chime" || got.Artist != "" || got.Album != "" {
+		t.Fatalf("unexpected tags: %+v", got)
+	}
+}
@@ -0,0 +1,390 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type alarmClient interface {
+	GetTopology(ctx context.Context) (sonos.Topology, error)
+	ListAlarms(ctx context.Context) ([]sonos.Alarm, error)
+	CreateAlarm(ctx context.Context, alarm sonos.Alarm) (string, error)
+	UpdateAlarm(ctx context.Context, alarm sonos.Alarm) error
+	DestroyAlarm(ctx context.Context, id string) error
+	ListFavorites(ctx context.Context, start, count int) (sonos.FavoritesPage, error)
+}
+
+// Alarms are stored household-wide, so any reachable speaker will do.
+var newAlarmClient = func(ctx context.Context, flags *rootFlags) (alarmClient, error) {
+	return anySpeakerClient(ctx, flags)
+}
+
+type alarmOutput struct {
+	sonos.Alarm
+	RoomName string `json:"roomName,omitempty"`
+	Program  string `json:"program"`
+}
+
+func newAlarmCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alarm",
+		Short: "Manage Sonos alarms",
+		Long:  "Lists, creates, edits and deletes household alarms via the AlarmClock service. Rooms are given by name and programs by Sonos Favorite title.",
+	}
+	cmd.AddCommand(newAlarmListCmd(flags))
+	cmd.AddCommand(newAlarmAddCmd(flags))
+	cmd.AddCommand(newAlarmEditCmd(flags))
+	cmd.AddCommand(newAlarmDeleteCmd(flags))
+	cmd.AddCommand(newAlarmEnableCmd(flags, "enable", true))
+	cmd.AddCommand(newAlarmEnableCmd(flags, "disable", false))
+	return cmd
+}
+
+func newAlarmListCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:          "list",
+		Short:        "List alarms",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := newAlarmClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			alarms, err := c.ListAlarms(ctx)
+			if err != nil {
+				return err
+			}
+			// Room names are best-effort; an alarm may point at a room that is offline.
+			top, _ := c.GetTopology(ctx)
+			out := make([]alarmOutput, 0, len(alarms))
+			for _, a := range alarms {
+				out = append(out, alarmOutput{
+					Alarm:    a,
+					RoomName: roomNameForUUID(top, a.RoomUUID),
+					Program:  a.ProgramTitle(),
+				})
+			}
+
+			if isJSON(flags) {
+				return writeJSON(cmd, out)
+			}
+			if isTSV(flags) {
+				for _, a := range out {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\t%s\t%s\t%v\t%d\t%s\t%s\n",
+						a.ID, a.StartTime, a.Duration, a.Recurrence, a.RoomName, a.Enabled, a.Volume, a.PlayMode, a.Program)
+				}
+				return nil
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+			_, _ = fmt.Fprintf(w, "ID\tTIME\tDURATION\tRECURRENCE\tROOM\tENABLED\tVOLUME\tPROGRAM\n")
+			for _, a := range out {
+				room := a.RoomName
+				if room == "" {
+					room = a.RoomUUID
+				}
+				if a.IncludeLinkedZones {
+					room += " (+group)"
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+					a.ID, a.StartTime, a.Duration, a.Recurrence, room, onOff(a.Enabled), a.Volume, a.Program)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// alarmFlags holds the flags shared by `alarm add` and `alarm edit`.
+type alarmFlags struct {
+	at            string
+	room          string
+	recurrence    string
+	duration      string
+	volume        int
+	favorite      string
+	uri           string
+	playMode      string
+	includeLinked bool
+	disabled      bool
+	force         bool
+}
+
+func (f *alarmFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.at, "time", "", "Start time, 24h local time (HH:MM or HH:MM:SS)")
+	cmd.Flags().StringVar(&f.room, "room", "", "Room name (defaults to --name)")
+	cmd.Flags().StringVar(&f.recurrence, "recurrence", "daily", "once|daily|weekdays|weekends or day names (e.g. mon,wed,fri)")
+	cmd.Flags().StringVar(&f.duration, "duration", "1h", "How long the alarm plays (e.g. 30m, 1h)")
+	cmd.Flags().IntVar(&f.volume, "volume", 20, "Alarm volume (0-100)")
+	cmd.Flags().StringVar(&f.favorite, "favorite", "", "Sonos Favorite title to play (default: Sonos chime)")
+	cmd.Flags().StringVar(&f.uri, "uri", "", "Raw program URI (advanced; prefer --favorite)")
+	cmd.Flags().StringVar(&f.playMode, "play-mode", "", "Play mode (NORMAL, SHUFFLE_NOREPEAT, REPEAT_ALL, ...)")
+	cmd.Flags().BoolVar(&f.includeLinked, "include-linked", false, "Also play on rooms grouped with the alarm room")
+	cmd.Flags().BoolVar(&f.disabled, "disabled", false, "Create or leave the alarm disabled")
+	cmd.Flags().BoolVar(&f.force, "force", false, "Ignore configured volume limits")
+}
+
+// apply copies the flags onto alarm. With onlyChanged, untouched flags keep the existing values.
+func (f *alarmFlags) apply(ctx context.Context, cmd *cobra.Command, flags *rootFlags, c alarmClient, alarm *sonos.Alarm, onlyChanged bool) error {
+	changed := func(name string) bool { return !onlyChanged || cmd.Flags().Changed(name) }
+
+	if changed("time") {
+		start, err := sonos.NormalizeAlarmTime(f.at)
+		if err != nil {
+			return err
+		}
+		alarm.StartTime = start
+	}
+	if changed("recurrence") {
+		rec, err := sonos.ParseRecurrence(f.recurrence)
+		if err != nil {
+			return err
+		}
+		alarm.Recurrence = rec
+	}
+	if changed("duration") {
+		d, err := parseDurationArg(f.duration)
+		if err != nil {
+			return err
+		}
+		if d <= 0 || d >= 24*time.Hour {
+			return errors.New("--duration must be between 1s and 24h")
+		}
+		alarm.Duration = sonos.FormatHHMMSS(d)
+	}
+	if changed("volume") {
+		if f.volume < 0 || f.volume > 100 {
+			return errors.New("--volume must be between 0 and 100")
+		}
+		alarm.Volume = f.volume
+	}
+	if changed("play-mode") && strings.TrimSpace(f.playMode) != "" {
+		mode, err := sonos.ParsePlayMode(f.playMode)
+		if err != nil {
+			return err
+		}
+		alarm.PlayMode = mode
+	}
+	if changed("include-linked") {
+		alarm.IncludeLinkedZones = f.includeLinked
+	}
+	if changed("disabled") {
+		alarm.Enabled = !f.disabled
+	}
+
+	room := strings.TrimSpace(f.room)
+	if room == "" && !onlyChanged {
+		room = flags.Name
+	}
+	if room != "" || (!onlyChanged && flags.IP != "") {
+		top, err := c.GetTopology(ctx)
+		if err != nil {
+			return err
+		}
+		ip := ""
+		if room == "" {
+			ip = flags.IP
+		}
+		mem, err := resolveMember(top, room, ip)
+		if err != nil {
+			return err
+		}
+		if mem.UUID == "" {
+			return errors.New("room has no UUID in topology: " + mem.Name)
+		}
+		alarm.RoomUUID = mem.UUID
+	}
+	if changed("volume") || room != "" {
+		if err := f.limitVolume(ctx, cmd, c, alarm); err != nil {
+			return err
+		}
+	}
+
+	if strings.TrimSpace(f.favorite) != "" && strings.TrimSpace(f.uri) != "" {
+		return errors.New("use either --favorite or --uri")
+	}
+	if fav := strings.TrimSpace(f.favorite); fav != "" {
+		it, err := findFavoriteByTitle(ctx, c, fav)
+		if err != nil {
+			return err
+		}
+		uri, meta, err := sonos.AlarmProgramFromFavorite(it.Item)
+		if err != nil {
+			return err
+		}
+		alarm.ProgramURI = uri
+		alarm.ProgramMetaData = meta
+	} else if uri := strings.TrimSpace(f.uri); uri != "" {
+		alarm.ProgramURI = uri
+		alarm.ProgramMetaData = ""
+	}
+	return nil
+}
+
+// limitVolume holds the alarm volume to the room's configured limits, with
+// quiet hours checked at the alarm's start time rather than now.
+func (f *alarmFlags) limitVolume(ctx context.Context, cmd *cobra.Command, c alarmClient, alarm *sonos.Alarm) error {
+	limiter, err := newVolumeLimiter(f.force)
+	if err != nil || !limiter.enabled() {
+		return err
+	}
+	top, err := c.GetTopology(ctx)
+	if err != nil {
+		return fmt.Errorf("volume limits: look up room: %w (use --force to skip limits)", err)
+	}
+	room := roomNameForUUID(top, alarm.RoomUUID)
+	if room == "" {
+		return nil
+	}
+	if start, err := time.Parse("15:04:05", alarm.StartTime); err == nil {
+		y, m, d := limiter.now.Date()
+		limiter.now = time.Date(y, m, d, start.Hour(), start.Minute(), start.Second(), 0, limiter.now.Location())
+	}
+	alarm.Volume, _ = clampVolume(cmd, limiter.limit(room), "", alarm.Volume)
+	return nil
+}
+
+func newAlarmAddCmd(flags *rootFlags) *cobra.Command {
+	af := &alarmFlags{}
+	cmd := &cobra.Command{
+		Use:          "add --time HH:MM [--room <name>]",
+		Short:        "Create an alarm",
+		Example:      "  sonos alarm add --room \"Bedroom\" --time 06:45 --recurrence weekdays --favorite \"BBC Radio 6 Music\" --volume 15",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(af.room) == "" {
+				if err := validateTarget(flags); err != nil {
+					return errors.New("provide --room (or --name/--ip)")
+				}
+			}
+			ctx := cmd.Context()
+			c, err := newAlarmClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			alarm := sonos.Alarm{PlayMode: sonos.PlayModeShuffleNoRepeat}
+			if err := af.apply(ctx, cmd, flags, c, &alarm, false); err != nil {
+				return err
+			}
+			id, err := c.CreateAlarm(ctx, alarm)
+			if err != nil {
+				return err
+			}
+			alarm.ID = id
+			writePlainLine(cmd, flags, "Created alarm "+id)
+			return writeOK(cmd, flags, "alarm.add", map[string]any{"alarm": alarm})
+		},
+	}
+	af.register(cmd)
+	_ = cmd.MarkFlagRequired("time")
+	return cmd
+}
+
+func newAlarmEditCmd(flags *rootFlags) *cobra.Command {
+	af := &alarmFlags{}
+	cmd := &cobra.Command{
+		Use:          "edit <id>",
+		Short:        "Change an existing alarm",
+		Long:         "Updates only the fields given as flags; everything else keeps its current value.",
+		Example:      "  sonos alarm edit 14 --time 07:15 --volume 12",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := newAlarmClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			alarm, err := findAlarm(ctx, c, args[0])
+			if err != nil {
+				return err
+			}
+			if err := af.apply(ctx, cmd, flags, c, &alarm, true); err != nil {
+				return err
+			}
+			if err := c.UpdateAlarm(ctx, alarm); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "alarm.edit", map[string]any{"alarm": alarm})
+		},
+	}
+	af.register(cmd)
+	return cmd
+}
+
+func newAlarmDeleteCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:          "delete <id>",
+		Short:        "Delete an alarm",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := newAlarmClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			id := strings.TrimSpace(args[0])
+			if err := c.DestroyAlarm(ctx, id); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "alarm.delete", map[string]any{"id": id})
+		},
+	}
+}
+
+func newAlarmEnableCmd(flags *rootFlags, use string, enabled bool) *cobra.Command {
+	return &cobra.Command{
+		Use:          use + " <id>",
+		Short:        strings.ToUpper(use[:1]) + use[1:] + " an alarm",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := newAlarmClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			alarm, err := findAlarm(ctx, c, args[0])
+			if err != nil {
+				return err
+			}
+			alarm.Enabled = enabled
+			if err := c.UpdateAlarm(ctx, alarm); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "alarm."+use, map[string]any{"id": alarm.ID})
+		},
+	}
+}
+
+func findAlarm(ctx context.Context, c alarmClient, id string) (sonos.Alarm, error) {
+	id = strings.TrimSpace(id)
+	alarms, err := c.ListAlarms(ctx)
+	if err != nil {
+		return sonos.Alarm{}, err
+	}
+	for _, a := range alarms {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return sonos.Alarm{}, errors.New("alarm not found: " + id)
+}
+
+func roomNameForUUID(top sonos.Topology, uuid string) string {
+	for _, g := range top.Groups {
+		for _, m := range g.Members {
+			if m.UUID == uuid {
+				return m.Name
+			}
+		}
+	}
+	return ""
+}
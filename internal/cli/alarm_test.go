@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steipete/sonoscli/internal/appconfig"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakeAlarmClient struct {
+	top       sonos.Topology
+	alarms    []sonos.Alarm
+	favorites []sonos.FavoriteItem
+	created   []sonos.Alarm
+	updated   []sonos.Alarm
+	destroyed []string
+}
+
+func (f *fakeAlarmClient) GetTopology(ctx context.Context) (sonos.Topology, error) {
+	return f.top, nil
+}
+
+func (f *fakeAlarmClient) ListAlarms(ctx context.Context) ([]sonos.Alarm, error) {
+	return f.alarms, nil
+}
+
+func (f *fakeAlarmClient) CreateAlarm(ctx context.Context, alarm sonos.Alarm) (string, error) {
+	f.created = append(f.created, alarm)
+	return "42", nil
+}
+
+func (f *fakeAlarmClient) UpdateAlarm(ctx context.Context, alarm sonos.Alarm) error {
+	f.updated = append(f.updated, alarm)
+	return nil
+}
+
+func (f *fakeAlarmClient) DestroyAlarm(ctx context.Context, id string) error {
+	f.destroyed = append(f.destroyed, id)
+	return nil
+}
+
+func (f *fakeAlarmClient) ListFavorites(ctx context.Context, start, count int) (sonos.FavoritesPage, error) {
+	return sonos.FavoritesPage{Items: f.favorites, NumberReturned: len(f.favorites), TotalMatches: len(f.favorites)}, nil
+}
+
+func newFakeAlarmClient() *fakeAlarmClient {
+	bedroom := sonos.Member{Name: "Bedroom", IP: "192.168.1.20", UUID: "RINCON_BED1400"}
+	return &fakeAlarmClient{
+		top: sonos.Topology{
+			Groups: []sonos.Group{{Coordinator: bedroom, Members: []sonos.Member{bedroom}}},
+			ByName: map[string]sonos.Member{"Bedroom": bedroom},
+			ByIP:   map[string]sonos.Member{bedroom.IP: bedroom},
+		},
+		alarms: []sonos.Alarm{{
+			ID:         "7",
+			StartTime:  "06:30:00",
+			Duration:   "01:00:00",
+			Recurrence: sonos.RecurrenceWeekdays,
+			Enabled:    true,
+			RoomUUID:   "RINCON_BED1400",
+			ProgramURI: sonos.AlarmChimeURI,
+			PlayMode:   sonos.PlayModeShuffleNoRepeat,
+			Volume:     15,
+		}},
+		favorites: []sonos.FavoriteItem{{
+			Position: 1,
+			Item:     sonos.DIDLItem{Title: "Morning Radio", URI: "x-sonosapi-stream:s1234?sid=254", ResMD: "<DIDL-Lite/>"},
+		}},
+	}
+}
+
+func runAlarmCmd(t *testing.T, flags *rootFlags, fake *fakeAlarmClient, args ...string) (string, error) {
+	t.Helper()
+	return runAlarmCmdWithConfig(t, flags, fake, appconfig.Config{}, args...)
+}
+
+func runAlarmCmdWithConfig(t *testing.T, flags *rootFlags, fake *fakeAlarmClient, cfg appconfig.Config, args ...string) (string, error) {
+	t.Helper()
+	orig, origLoad := newAlarmClient, loadAppConfig
+	t.Cleanup(func() {
+		newAlarmClient = orig
+		loadAppConfig = origLoad
+	})
+	loadAppConfig = func() (appconfig.Config, error) { return cfg, nil }
+	newAlarmClient = func(ctx context.Context, flags *rootFlags) (alarmClient, error) {
+		return fake, nil
+	}
+
+	cmd := newAlarmCmd(flags)
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestAlarmListPlain(t *testing.T) {
+	fake := newFakeAlarmClient()
+	out, err := runAlarmCmd(t, &rootFlags{Format: formatPlain}, fake, "list")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	for _, want := range []string{"ID", "06:30:00", "WEEKDAYS", "Bedroom", "Sonos Chime"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestAlarmAddResolvesRoomAndFavorite(t *testing.T) {
+	fake := newFakeAlarmClient()
+	_, err := runAlarmCmd(t, &rootFlags{Format: formatPlain}, fake,
+		"add", "--room", "Bed", "--time", "7:05", "--recurrence", "mon,wed", "--favorite", "morning radio", "--volume", "12", "--duration", "30m")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if len(fake.created) != 1 {
+		t.Fatalf("expected 1 create, got %d", len(fake.created))
+	}
+	a := fake.created[0]
+	if a.RoomUUID != "RINCON_BED1400" || a.StartTime != "07:05:00" || a.Duration != "00:30:00" || a.Volume != 12 {
+		t.Fatalf("unexpected alarm: %+v", a)
+	}
+	if a.Recurrence != "ON_13" {
+		t.Fatalf("unexpected recurrence: %q", a.Recurrence)
+	}
+	if a.ProgramURI != "x-sonosapi-stream:s1234?sid=254" || a.ProgramMetaData != "<DIDL-Lite/>" {
+		t.Fatalf("unexpected program: %q %q", a.ProgramURI, a.ProgramMetaData)
+	}
+	if !a.Enabled {
+		t.Fatalf("expected enabled alarm")
+	}
+}
+
+func TestAlarmEditKeepsUnchangedFields(t *testing.T) {
+	fake := newFakeAlarmClient()
+	if _, err := runAlarmCmd(t, &rootFlags{Format: formatPlain}, fake, "edit", "7", "--volume", "25"); err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	if len(fake.updated) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(fake.updated))
+	}
+	a := fake.updated[0]
+	if a.Volume != 25 || a.StartTime != "06:30:00" || a.Recurrence != sonos.RecurrenceWeekdays || a.RoomUUID != "RINCON_BED1400" {
+		t.Fatalf("unexpected alarm: %+v", a)
+	}
+}
+
+func TestAlarmVolumeHeldToLimitsAtAlarmTime(t *testing.T) {
+	cfg := appconfig.Config{Rooms: map[string]appconfig.RoomConfig{"Bedroom": {
+		MaxVolume:  30,
+		QuietHours: []appconfig.QuietHours{{Start: "22:00", End: "07:00", MaxVolume: 10}},
+	}}}
+
+	fake := newFakeAlarmClient()
+	out, err := runAlarmCmdWithConfig(t, &rootFlags{Format: formatPlain}, fake, cfg, "add", "--room", "Bedroom", "--time", "06:45", "--volume", "40")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if len(fake.created) != 1 || fake.created[0].Volume != 10 {
+		t.Fatalf("expected quiet-hours volume 10: %+v", fake.created)
+	}
+	if !strings.Contains(out, "use --force to override") {
+		t.Fatalf("expected limit note:\n%s", out)
+	}
+
+	if _, err := runAlarmCmdWithConfig(t, &rootFlags{Format: formatPlain}, fake, cfg, "edit", "7", "--time", "09:00", "--volume", "50"); err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	if len(fake.updated) != 1 || fake.updated[0].Volume != 30 {
+		t.Fatalf("expected maxVolume 30: %+v", fake.updated)
+	}
+
+	if _, err := runAlarmCmdWithConfig(t, &rootFlags{Format: formatPlain}, fake, cfg, "edit", "7", "--volume", "50", "--force"); err != nil {
+		t.Fatalf("edit --force: %v", err)
+	}
+	if len(fake.updated) != 2 || fake.updated[1].Volume != 50 {
+		t.Fatalf("expected --force to keep 50: %+v", fake.updated)
+	}
+}
+
+func TestAlarmDisableAndDelete(t *testing.T) {
+	fake := newFakeAlarmClient()
+	if _, err := runAlarmCmd(t, &rootFlags{Format: formatPlain}, fake, "disable", "7"); err != nil {
+		t.Fatalf("disable: %v", err)
+	}
+	if len(fake.updated) != 1 || fake.updated[0].Enabled {
+		t.Fatalf("unexpected updates: %+v", fake.updated)
+	}
+	if _, err := runAlarmCmd(t, &rootFlags{Format: formatPlain}, fake, "enable", "99"); err == nil {
+		t.Fatalf("expected error for unknown alarm")
+	}
+	if _, err := runAlarmCmd(t, &rootFlags{Format: formatPlain}, fake, "delete", "7"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if len(fake.destroyed) != 1 || fake.destroyed[0] != "7" {
+		t.Fatalf("unexpected deletes: %v", fake.destroyed)
+	}
+}
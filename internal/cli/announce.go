@@ -0,0 +1,292 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type announceClient interface {
+	TakeSnapshot(ctx context.Context, group sonos.Group) (sonos.Snapshot, error)
+	SubscribeAVTransport(ctx context.Context, callbackURL string, requestedTimeout time.Duration) (sonos.Subscription, error)
+	Unsubscribe(ctx context.Context, sub sonos.Subscription) error
+	SetAVTransportURI(ctx context.Context, uri, meta string) error
+	Play(ctx context.Context) error
+	SetVolume(ctx context.Context, volume int) error
+}
+
+var newAnnounceClient = func(ip string, timeout time.Duration) announceClient {
+	return newSonosClient(ip, timeout)
+}
+
+// announceTarget is one group that plays the clip, plus the state to return to.
+type announceTarget struct {
+	group    sonos.Group
+	client   announceClient
+	snapshot sonos.Snapshot
+	sub      sonos.Subscription
+	playAt   time.Time // events that arrived earlier describe the previous source
+	started  bool
+	done     bool
+}
+
+func newAnnounceCmd(flags *rootFlags) *cobra.Command {
+	var file string
+	var rooms []string
+	var volume int
+	var maxWait time.Duration
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "announce --file <clip>",
+		Short: "Play an audio clip, then resume what was playing",
+		Long: "Snapshots each target group, serves the clip from a built-in HTTP server, plays it, waits until it has finished " +
+			"(via AVTransport events), then restores the previous source, volume and play state.\n\n" +
+			"Rooms that are grouped with others announce on their whole group. --volume is held to each room's configured volume limits unless --force is given. Requires that Sonos speakers can reach your machine (firewall may prompt).",
+		Example:      "  sonos announce --name \"Kitchen\" --file doorbell.mp3 --volume 40\n  sonos announce --room Kitchen --room Office --file dinner.mp3",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(file) == "" {
+				return errors.New("--file is required")
+			}
+			if cmd.Flags().Changed("volume") && (volume < 0 || volume > 100) {
+				return errors.New("--volume must be between 0 and 100")
+			}
+			if len(rooms) == 0 {
+				if err := validateTarget(flags); err != nil {
+					return errors.New("provide --room (or --name/--ip)")
+				}
+			}
+			clip, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer clip.Close()
+			clipInfo, err := clip.Stat()
+			if err != nil {
+				return err
+			}
+
+			limiter, err := newVolumeLimiter(force)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			tg, err := newTopologyGetter(ctx, flags.Timeout)
+			if err != nil {
+				return err
+			}
+			top, err := tg.GetTopology(ctx)
+			if err != nil {
+				return err
+			}
+			groups, err := announceGroups(top, rooms, flags)
+			if err != nil {
+				return err
+			}
+
+			listenIP, err := listenIPForRemote(groups[0].Coordinator.IP)
+			if err != nil {
+				return err
+			}
+			ln, err := net.Listen("tcp", net.JoinHostPort(listenIP, "0"))
+			if err != nil {
+				return err
+			}
+			defer ln.Close()
+			base := fmt.Sprintf("http://%s:%d", listenIP, ln.Addr().(*net.TCPAddr).Port)
+			clipName := filepath.Base(file)
+			clipURL := base + "/clip/" + url.PathEscape(clipName)
+
+			type transportEvent struct {
+				sid     string
+				state   string
+				initial bool // SEQ 0: the state at subscribe time
+				at      time.Time
+			}
+			events := make(chan transportEvent, 64)
+			mux := http.NewServeMux()
+			mux.HandleFunc("/clip/", func(w http.ResponseWriter, r *http.Request) {
+				// ServeContent handles Range requests and derives Content-Type from the extension.
+				http.ServeContent(w, r, clipName, clipInfo.ModTime(), io.NewSectionReader(clip, 0, clipInfo.Size()))
+			})
+			mux.HandleFunc("/notify", func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				vars, err := sonos.ParseEvent(body)
+				if err != nil || vars["transport_state"] == "" {
+					return
+				}
+				select {
+				case events <- transportEvent{
+					sid:     strings.TrimSpace(r.Header.Get("SID")),
+					state:   vars["transport_state"],
+					initial: strings.TrimSpace(r.Header.Get("SEQ")) == "0",
+					at:      time.Now(),
+				}:
+				default:
+				}
+			})
+			srv := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+			go func() { _ = srv.Serve(ln) }()
+			defer func() { _ = srv.Shutdown(context.Background()) }()
+
+			// Snapshot everything first so a failure part-way can still put every group back.
+			targets := make([]*announceTarget, 0, len(groups))
+			for _, g := range groups {
+				c := newAnnounceClient(g.Coordinator.IP, flags.Timeout)
+				snap, err := c.TakeSnapshot(ctx, g)
+				if err != nil {
+					return fmt.Errorf("snapshot %s: %w", g.Coordinator.Name, err)
+				}
+				targets = append(targets, &announceTarget{group: g, client: c, snapshot: snap})
+			}
+			defer func() {
+				rctx, cancel := context.WithTimeout(context.Background(), 10*flags.Timeout)
+				defer cancel()
+				for _, t := range targets {
+					if t.sub.SID != "" {
+						_ = t.client.Unsubscribe(rctx, t.sub)
+					}
+					if err := restoreSnapshot(rctx, t.snapshot, flags.Timeout); err != nil {
+						_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "restore %s: %v\n", t.group.Coordinator.Name, err)
+					}
+				}
+			}()
+
+			bySID := map[string]*announceTarget{}
+			for _, t := range targets {
+				sub, err := t.client.SubscribeAVTransport(ctx, base+"/notify", 0)
+				if err != nil {
+					return err
+				}
+				t.sub = sub
+				bySID[sub.SID] = t
+			}
+
+			for _, t := range targets {
+				if err := t.client.SetAVTransportURI(ctx, clipURL, ""); err != nil {
+					return err
+				}
+				if cmd.Flags().Changed("volume") {
+					for _, m := range t.snapshot.Members {
+						v, _ := clampVolume(cmd, limiter.limit(m.Name), m.Name, volume)
+						if err := newAnnounceClient(m.IP, flags.Timeout).SetVolume(ctx, v); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			var wg sync.WaitGroup
+			errs := make(chan error, len(targets))
+			for _, t := range targets {
+				t.playAt = time.Now()
+				wg.Add(1)
+				go func(t *announceTarget) {
+					defer wg.Done()
+					if err := t.client.Play(ctx); err != nil {
+						errs <- err
+					}
+				}(t)
+			}
+			wg.Wait()
+			close(errs)
+			if err := <-errs; err != nil {
+				return err
+			}
+
+			timer := time.NewTimer(maxWait)
+			defer timer.Stop()
+			remaining := len(targets)
+			for remaining > 0 {
+				select {
+				case <-ctx.Done():
+					return errors.New("announce interrupted; restoring previous playback")
+				case <-timer.C:
+					_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "announce: timed out waiting for the clip to finish; restoring")
+					remaining = 0
+				case ev := <-events:
+					t := bySID[ev.sid]
+					// The initial event and anything sent before Play (e.g. the
+					// STOPPED caused by loading the clip) describe the previous source.
+					if t == nil || t.done || ev.initial || ev.at.Before(t.playAt) {
+						continue
+					}
+					switch ev.state {
+					case "PLAYING":
+						t.started = true
+					case "STOPPED", "PAUSED_PLAYBACK":
+						if t.started {
+							t.done = true
+							remaining--
+						}
+					}
+				}
+			}
+
+			names := make([]string, 0, len(targets))
+			for _, t := range targets {
+				names = append(names, t.group.Coordinator.Name)
+			}
+			return writeOK(cmd, flags, "announce", map[string]any{"file": clipName, "groups": names})
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Audio file to play (mp3, m4a, wav, flac, ...)")
+	cmd.Flags().StringArrayVar(&rooms, "room", nil, "Room to announce on (repeatable; defaults to --name/--ip)")
+	cmd.Flags().IntVar(&volume, "volume", 0, "Announcement volume for every member (0-100; default: keep current)")
+	cmd.Flags().BoolVar(&force, "force", false, "Ignore configured volume limits")
+	cmd.Flags().DurationVar(&maxWait, "max-wait", 2*time.Minute, "Restore after this long even if the clip has not finished")
+	return cmd
+}
+
+// announceGroups resolves rooms to the distinct groups they belong to.
+func announceGroups(top sonos.Topology, rooms []string, flags *rootFlags) ([]sonos.Group, error) {
+	var members []sonos.Member
+	if len(rooms) == 0 {
+		mem, err := resolveMember(top, flags.Name, flags.IP)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, mem)
+	}
+	for _, r := range rooms {
+		mem, err := resolveMember(top, r, "")
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, mem)
+	}
+
+	seen := map[string]bool{}
+	var groups []sonos.Group
+	for _, m := range members {
+		g, ok := top.GroupForIP(m.IP)
+		if !ok {
+			return nil, errors.New("group not found for: " + m.Name)
+		}
+		if seen[g.Coordinator.UUID] {
+			continue
+		}
+		seen[g.Coordinator.UUID] = true
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
@@ -0,0 +1,275 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/appconfig"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakeAnnounceClient struct {
+	ip string
+
+	mu          sync.Mutex
+	callbackURL string
+	uri         string
+	volumes     []int
+	clipBody    string
+	unsubs      int
+	seq         int
+
+	// initialState, when set, is sent as the SEQ 0 event on subscribe, like a
+	// speaker reporting what it is currently doing.
+	initialState string
+	finished     bool
+	// cancel, when set, is called from Play instead of reporting the clip.
+	cancel context.CancelFunc
+}
+
+// notify sends an AVTransport LastChange event to the subscriber.
+func (f *fakeAnnounceClient) notify(state string) {
+	f.mu.Lock()
+	callback, seq := f.callbackURL, f.seq
+	f.seq++
+	f.mu.Unlock()
+
+	lastChange := `&lt;Event xmlns=&quot;urn:schemas-upnp-org:metadata-1-0/AVT/&quot;&gt;&lt;InstanceID val=&quot;0&quot;&gt;&lt;TransportState val=&quot;` + state + `&quot;/&gt;&lt;/InstanceID&gt;&lt;/Event&gt;`
+	body := `<e:propertyset xmlns:e="urn:schemas-upnp-org:event-1-0"><e:property><LastChange>` + lastChange + `</LastChange></e:property></e:propertyset>`
+	req, _ := http.NewRequest("NOTIFY", callback, strings.NewReader(body))
+	req.Header.Set("SID", "uuid:sub-"+f.ip)
+	req.Header.Set("SEQ", fmt.Sprint(seq))
+	if resp, err := http.DefaultClient.Do(req); err == nil {
+		_ = resp.Body.Close()
+	}
+}
+
+func (f *fakeAnnounceClient) TakeSnapshot(ctx context.Context, group sonos.Group) (sonos.Snapshot, error) {
+	snap := sonos.Snapshot{CoordinatorUUID: group.Coordinator.UUID, CoordinatorIP: group.Coordinator.IP, TransportState: "PLAYING"}
+	for _, m := range group.Members {
+		snap.Members = append(snap.Members, sonos.SnapshotMember{UUID: m.UUID, Name: m.Name, IP: m.IP, Volume: 10})
+	}
+	return snap, nil
+}
+
+func (f *fakeAnnounceClient) SubscribeAVTransport(ctx context.Context, callbackURL string, requestedTimeout time.Duration) (sonos.Subscription, error) {
+	f.mu.Lock()
+	f.callbackURL = callbackURL
+	initial := f.initialState
+	if initial == "" {
+		f.seq = 1 // no initial event; later events are never SEQ 0
+	}
+	f.mu.Unlock()
+	if initial != "" {
+		f.notify(initial)
+	}
+	return sonos.Subscription{SID: "uuid:sub-" + f.ip}, nil
+}
+
+func (f *fakeAnnounceClient) Unsubscribe(ctx context.Context, sub sonos.Subscription) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.unsubs++
+	return nil
+}
+
+func (f *fakeAnnounceClient) SetAVTransportURI(ctx context.Context, uri, meta string) error {
+	f.mu.Lock()
+	f.uri = uri
+	initial := f.initialState
+	f.mu.Unlock()
+	if initial != "" {
+		// Loading a new URI stops the current source.
+		f.notify("STOPPED")
+	}
+	return nil
+}
+
+func (f *fakeAnnounceClient) SetVolume(ctx context.Context, volume int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.volumes = append(f.volumes, volume)
+	return nil
+}
+
+// Play fetches the clip like a speaker would and reports PLAYING, then STOPPED.
+func (f *fakeAnnounceClient) Play(ctx context.Context) error {
+	f.mu.Lock()
+	uri, cancel := f.uri, f.cancel
+	f.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		return nil
+	}
+
+	resp, err := http.Get(uri)
+	if err != nil {
+		return err
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	f.mu.Lock()
+	f.clipBody = string(body)
+	f.mu.Unlock()
+
+	go func() {
+		f.notify("PLAYING")
+		time.Sleep(50 * time.Millisecond)
+		f.mu.Lock()
+		f.finished = true
+		f.mu.Unlock()
+		f.notify("STOPPED")
+	}()
+	return nil
+}
+
+func TestAnnounceGroupsDedupesGroupedRooms(t *testing.T) {
+	kitchen := sonos.Member{Name: "Kitchen", IP: "192.168.1.10", UUID: "RINCON_K1400", IsVisible: true}
+	dining := sonos.Member{Name: "Dining", IP: "192.168.1.11", UUID: "RINCON_D1400", IsVisible: true}
+	office := sonos.Member{Name: "Office", IP: "192.168.1.12", UUID: "RINCON_O1400", IsVisible: true}
+	top := sonos.Topology{
+		Groups: []sonos.Group{
+			{Coordinator: kitchen, Members: []sonos.Member{kitchen, dining}},
+			{Coordinator: office, Members: []sonos.Member{office}},
+		},
+		ByName: map[string]sonos.Member{"Kitchen": kitchen, "Dining": dining, "Office": office},
+	}
+	groups, err := announceGroups(top, []string{"Dining", "Kitchen", "Office"}, &rootFlags{})
+	if err != nil {
+		t.Fatalf("announceGroups: %v", err)
+	}
+	if len(groups) != 2 || groups[0].Coordinator.Name != "Kitchen" || groups[1].Coordinator.Name != "Office" {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+}
+
+func runAnnounceCmd(t *testing.T, ctx context.Context, fake *fakeAnnounceClient, args ...string) ([]sonos.Snapshot, error) {
+	t.Helper()
+	return runAnnounceCmdWithConfig(t, ctx, fake, appconfig.Config{}, args...)
+}
+
+func runAnnounceCmdWithConfig(t *testing.T, ctx context.Context, fake *fakeAnnounceClient, cfg appconfig.Config, args ...string) ([]sonos.Snapshot, error) {
+	t.Helper()
+	clipPath := filepath.Join(t.TempDir(), "doorbell.mp3")
+	if err := os.WriteFile(clipPath, []byte("ID3-fake-mp3"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	coord := sonos.Member{Name: "Kitchen", IP: "127.0.0.1", UUID: "RINCON_K1400", IsVisible: true}
+	top := sonos.Topology{
+		Groups: []sonos.Group{{Coordinator: coord, Members: []sonos.Member{coord}}},
+		ByName: map[string]sonos.Member{"Kitchen": coord},
+		ByIP:   map[string]sonos.Member{coord.IP: coord},
+	}
+	fake.ip = coord.IP
+
+	origTG, origClient, origRestore, origLoad := newTopologyGetter, newAnnounceClient, restoreSnapshot, loadAppConfig
+	t.Cleanup(func() {
+		newTopologyGetter, newAnnounceClient, restoreSnapshot, loadAppConfig = origTG, origClient, origRestore, origLoad
+	})
+	loadAppConfig = func() (appconfig.Config, error) { return cfg, nil }
+	newTopologyGetter = func(ctx context.Context, timeout time.Duration) (topologyGetter, error) {
+		return &fakeSceneTopologyGetter{top: top}, nil
+	}
+	newAnnounceClient = func(ip string, timeout time.Duration) announceClient { return fake }
+	var restored []sonos.Snapshot
+	restoreSnapshot = func(ctx context.Context, snap sonos.Snapshot, timeout time.Duration) error {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		if fake.cancel == nil && !fake.finished {
+			t.Errorf("restored before the clip finished")
+		}
+		restored = append(restored, snap)
+		return nil
+	}
+
+	flags := &rootFlags{Name: "Kitchen", Timeout: time.Second, Format: formatPlain}
+	cmd := newAnnounceCmd(flags)
+	cmd.SetOut(newDiscardWriter())
+	cmd.SetErr(newDiscardWriter())
+	cmd.SetArgs(append([]string{"--file", clipPath}, args...))
+	err := cmd.ExecuteContext(ctx)
+	return restored, err
+}
+
+func TestAnnounceCmdPlaysClipAndRestores(t *testing.T) {
+	fake := &fakeAnnounceClient{}
+	restored, err := runAnnounceCmd(t, context.Background(), fake, "--volume", "40", "--max-wait", "5s")
+	if err != nil {
+		t.Fatalf("announce: %v", err)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if !strings.HasSuffix(fake.uri, "/clip/doorbell.mp3") {
+		t.Fatalf("unexpected clip uri: %q", fake.uri)
+	}
+	if fake.clipBody != "ID3-fake-mp3" {
+		t.Fatalf("unexpected clip body: %q", fake.clipBody)
+	}
+	if len(fake.volumes) != 1 || fake.volumes[0] != 40 {
+		t.Fatalf("unexpected volumes: %v", fake.volumes)
+	}
+	if fake.unsubs != 1 {
+		t.Fatalf("expected unsubscribe, got %d", fake.unsubs)
+	}
+	if len(restored) != 1 || restored[0].CoordinatorUUID != "RINCON_K1400" {
+		t.Fatalf("unexpected restore: %+v", restored)
+	}
+}
+
+func TestAnnounceCmdHoldsVolumeToLimits(t *testing.T) {
+	cfg := appconfig.Config{Rooms: map[string]appconfig.RoomConfig{"Kitchen": {MaxVolume: 25}}}
+
+	fake := &fakeAnnounceClient{}
+	if _, err := runAnnounceCmdWithConfig(t, context.Background(), fake, cfg, "--volume", "60", "--max-wait", "5s"); err != nil {
+		t.Fatalf("announce: %v", err)
+	}
+	if len(fake.volumes) != 1 || fake.volumes[0] != 25 {
+		t.Fatalf("expected volume capped to 25, got %v", fake.volumes)
+	}
+
+	fake = &fakeAnnounceClient{}
+	if _, err := runAnnounceCmdWithConfig(t, context.Background(), fake, cfg, "--volume", "60", "--force", "--max-wait", "5s"); err != nil {
+		t.Fatalf("announce --force: %v", err)
+	}
+	if len(fake.volumes) != 1 || fake.volumes[0] != 60 {
+		t.Fatalf("expected --force to keep 60, got %v", fake.volumes)
+	}
+}
+
+func TestAnnounceCmdIgnoresInitialPlayingEvent(t *testing.T) {
+	// The speaker was already playing: the subscribe event says PLAYING and
+	// loading the clip reports STOPPED, both before Play.
+	fake := &fakeAnnounceClient{initialState: "PLAYING"}
+	restored, err := runAnnounceCmd(t, context.Background(), fake, "--max-wait", "5s")
+	if err != nil {
+		t.Fatalf("announce: %v", err)
+	}
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if !fake.finished || len(restored) != 1 {
+		t.Fatalf("finished=%v restored=%d", fake.finished, len(restored))
+	}
+}
+
+func TestAnnounceCmdInterruptRestoresAndFails(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fake := &fakeAnnounceClient{cancel: cancel}
+	restored, err := runAnnounceCmd(t, ctx, fake, "--max-wait", "5s")
+	if err == nil || !strings.Contains(err.Error(), "interrupted") {
+		t.Fatalf("expected interrupted error, got %v", err)
+	}
+	if len(restored) != 1 {
+		t.Fatalf("expected restore after interrupt, got %d", len(restored))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -16,7 +17,10 @@ func newConfigCmd(flags *rootFlags) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "config",
 		Short: "Manage local CLI defaults",
-		Long:  "Stores small, local defaults under your user config directory (e.g. ~/.config/sonoscli/config.json).",
+		Long: "Stores small, local defaults under your user config directory (e.g. ~/.config/sonoscli/config.json).\n\n" +
+			"Per-room volume limits use the keys maxVolume.<Room>, minVolume.<Room> and quietHours.<Room> " +
+			"(comma-separated HH:MM-HH:MM=<max> windows, e.g. 19:30-07:00=15).",
+		Example: "  sonos config set defaultRoom Kitchen\n  sonos config set \"maxVolume.Kids Room\" 40\n  sonos config set \"quietHours.Kids Room\" 19:30-07:00=15",
 	}
 	cmd.AddCommand(newConfigGetCmd(flags))
 	cmd.AddCommand(newConfigSetCmd(flags))
@@ -142,6 +146,17 @@ func printConfigPlain(cmd *cobra.Command, cfg appconfig.Config) {
 		"defaultRoom": cfg.DefaultRoom,
 		"format":      cfg.Format,
 	}
+	for room, rc := range cfg.Rooms {
+		if rc.MaxVolume > 0 {
+			entries["maxVolume."+room] = strconv.Itoa(rc.MaxVolume)
+		}
+		if rc.MinVolume > 0 {
+			entries["minVolume."+room] = strconv.Itoa(rc.MinVolume)
+		}
+		if len(rc.QuietHours) > 0 {
+			entries["quietHours."+room] = appconfig.FormatQuietHours(rc.QuietHours)
+		}
+	}
 	keys := make([]string, 0, len(entries))
 	for k := range entries {
 		keys = append(keys, k)
@@ -158,9 +173,20 @@ func getConfigKey(cfg appconfig.Config, key string) (string, bool) {
 		return cfg.DefaultRoom, true
 	case "format":
 		return cfg.Format, true
-	default:
+	}
+	field, room, ok := roomConfigKey(key)
+	if !ok {
 		return "", false
 	}
+	rc, _ := cfg.Room(room)
+	switch field {
+	case "maxVolume":
+		return strconv.Itoa(rc.MaxVolume), true
+	case "minVolume":
+		return strconv.Itoa(rc.MinVolume), true
+	default:
+		return appconfig.FormatQuietHours(rc.QuietHours), true
+	}
 }
 
 func setConfigKey(cfg appconfig.Config, key, value string) (appconfig.Config, error) {
@@ -177,9 +203,31 @@ func setConfigKey(cfg appconfig.Config, key, value string) (appconfig.Config, er
 			return appconfig.Config{}, errors.New("invalid format (expected plain|json|tsv): " + value)
 		}
 		return cfg, nil
-	default:
+	}
+	field, room, ok := roomConfigKey(key)
+	if !ok {
 		return appconfig.Config{}, errors.New("unknown key: " + key)
 	}
+	rc, _ := cfg.Room(room)
+	switch field {
+	case "maxVolume", "minVolume":
+		v, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || v < 0 || v > 100 {
+			return appconfig.Config{}, fmt.Errorf("invalid %s (expected 0-100): %s", field, value)
+		}
+		if field == "maxVolume" {
+			rc.MaxVolume = v
+		} else {
+			rc.MinVolume = v
+		}
+	default:
+		q, err := appconfig.ParseQuietHours(value)
+		if err != nil {
+			return appconfig.Config{}, err
+		}
+		rc.QuietHours = q
+	}
+	return withRoomConfig(cfg, room, rc), nil
 }
 
 func unsetConfigKey(cfg appconfig.Config, key string) (appconfig.Config, error) {
@@ -190,7 +238,49 @@ func unsetConfigKey(cfg appconfig.Config, key string) (appconfig.Config, error)
 	case "format":
 		cfg.Format = ""
 		return cfg, nil
-	default:
+	}
+	field, room, ok := roomConfigKey(key)
+	if !ok {
 		return appconfig.Config{}, errors.New("unknown key: " + key)
 	}
+	rc, _ := cfg.Room(room)
+	switch field {
+	case "maxVolume":
+		rc.MaxVolume = 0
+	case "minVolume":
+		rc.MinVolume = 0
+	default:
+		rc.QuietHours = nil
+	}
+	return withRoomConfig(cfg, room, rc), nil
+}
+
+// roomConfigKey splits per-room keys like "maxVolume.Kids Room".
+func roomConfigKey(key string) (field, room string, ok bool) {
+	field, room, ok = strings.Cut(key, ".")
+	room = strings.TrimSpace(room)
+	if !ok || room == "" {
+		return "", "", false
+	}
+	switch field {
+	case "maxVolume", "minVolume", "quietHours":
+		return field, room, true
+	default:
+		return "", "", false
+	}
+}
+
+// withRoomConfig returns cfg with a copied Rooms map holding rc for room
+// (an existing entry with different case is replaced).
+func withRoomConfig(cfg appconfig.Config, room string, rc appconfig.RoomConfig) appconfig.Config {
+	rooms := make(map[string]appconfig.RoomConfig, len(cfg.Rooms)+1)
+	for k, v := range cfg.Rooms {
+		if strings.EqualFold(k, room) {
+			room = k
+		}
+		rooms[k] = v
+	}
+	rooms[room] = rc
+	cfg.Rooms = rooms
+	return cfg
 }
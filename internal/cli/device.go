@@ -0,0 +1,464 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type deviceClient interface {
+	GetLEDState(ctx context.Context) (bool, error)
+	SetLEDState(ctx context.Context, on bool) error
+	GetButtonLockState(ctx context.Context) (bool, error)
+	SetButtonLockState(ctx context.Context, locked bool) error
+	GetDeviceInfo(ctx context.Context) (sonos.DeviceInfo, error)
+	GetDeviceModel(ctx context.Context) (sonos.DeviceInfo, error)
+	GetZoneAttributes(ctx context.Context) (sonos.ZoneAttributes, error)
+	SetZoneAttributes(ctx context.Context, attrs sonos.ZoneAttributes) error
+}
+
+var newDeviceClient = func(ip string, timeout time.Duration) deviceClient {
+	return sonos.NewClient(ip, timeout)
+}
+
+func newDeviceCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "device",
+		Short: "Per-device info and settings (model, room name, status light, button lock)",
+		Long:  "Reads and changes DeviceProperties settings of individual speakers. Commands apply to every device of a room (stereo pairs, subs and surrounds included).",
+	}
+	cmd.AddCommand(newDeviceInfoCmd(flags))
+	cmd.AddCommand(newDeviceRenameCmd(flags))
+	cmd.AddCommand(newDeviceSwitchCmd(flags, deviceSwitch{
+		use:   "led",
+		short: "Show or turn the status light on/off",
+		key:   "led",
+		label: "LED",
+		on:    "on",
+		off:   "off",
+		shown: [2]string{"off", "on"},
+		get:   func(ctx context.Context, c deviceClient) (bool, error) { return c.GetLEDState(ctx) },
+		set:   func(ctx context.Context, c deviceClient, v bool) error { return c.SetLEDState(ctx, v) },
+	}))
+	cmd.AddCommand(newDeviceSwitchCmd(flags, deviceSwitch{
+		use:   "buttons",
+		short: "Show or lock/unlock the physical buttons",
+		key:   "buttonsLocked",
+		label: "buttons",
+		on:    "lock",
+		off:   "unlock",
+		shown: [2]string{"unlocked", "locked"},
+		get:   func(ctx context.Context, c deviceClient) (bool, error) { return c.GetButtonLockState(ctx) },
+		set:   func(ctx context.Context, c deviceClient, v bool) error { return c.SetButtonLockState(ctx, v) },
+	}))
+	return cmd
+}
+
+// deviceSwitch describes an on/off DeviceProperties setting.
+type deviceSwitch struct {
+	use, short string
+	key        string    // JSON key
+	label      string    // plain output label
+	on, off    string    // arguments for true/false
+	shown      [2]string // plain output for false/true
+	get        func(ctx context.Context, c deviceClient) (bool, error)
+	set        func(ctx context.Context, c deviceClient, v bool) error
+}
+
+func (s deviceSwitch) word(v bool) string {
+	if v {
+		return s.shown[1]
+	}
+	return s.shown[0]
+}
+
+type deviceSwitchState struct {
+	member sonos.Member
+	on     bool
+}
+
+func newDeviceSwitchCmd(flags *rootFlags, s deviceSwitch) *cobra.Command {
+	var rooms []string
+
+	cmd := &cobra.Command{
+		Use:   s.use + " [" + s.on + "|" + s.off + "]",
+		Short: s.short,
+		Long: "Without an argument, shows the current state of every device in the target rooms; with " + s.on + " or " + s.off + ", changes it. " +
+			"Rooms come from --room (repeatable) or --name/--ip.",
+		Example: fmt.Sprintf("  sonos device %[1]s --name Nursery\n  sonos device %[1]s %[2]s --room Nursery --room \"Kids Room\"", s.use, s.off),
+		Args:    cobra.MaximumNArgs(1),
+		ValidArgs: []string{
+			s.on, s.off,
+		},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var want *bool
+			if len(args) == 1 {
+				switch strings.ToLower(strings.TrimSpace(args[0])) {
+				case s.on:
+					v := true
+					want = &v
+				case s.off:
+					v := false
+					want = &v
+				default:
+					return fmt.Errorf("expected %s|%s: %s", s.on, s.off, args[0])
+				}
+			}
+
+			ctx := cmd.Context()
+			devices, err := deviceTargets(ctx, flags, rooms)
+			if err != nil {
+				return err
+			}
+
+			var states []deviceSwitchState
+			var errs []error
+			for _, m := range devices {
+				c := newDeviceClient(m.IP, flags.Timeout)
+				st := deviceSwitchState{member: m}
+				if want != nil {
+					if err := s.set(ctx, c, *want); err != nil {
+						errs = append(errs, fmt.Errorf("%s (%s): %w", m.Name, m.IP, err))
+						continue
+					}
+					st.on = *want
+				} else {
+					v, err := s.get(ctx, c)
+					if err != nil {
+						errs = append(errs, fmt.Errorf("%s (%s): %w", m.Name, m.IP, err))
+						continue
+					}
+					st.on = v
+				}
+				states = append(states, st)
+			}
+
+			if isJSON(flags) {
+				out := make([]map[string]any, 0, len(states))
+				for _, st := range states {
+					out = append(out, map[string]any{"room": st.member.Name, "ip": st.member.IP, "uuid": st.member.UUID, s.key: st.on})
+				}
+				if want != nil {
+					if err := writeOK(cmd, flags, "device."+s.use, map[string]any{"ok": len(errs) == 0, s.key: *want, "devices": out}); err != nil {
+						return err
+					}
+				} else if err := writeJSON(cmd, map[string]any{"devices": out}); err != nil {
+					return err
+				}
+			} else {
+				for _, st := range states {
+					if isTSV(flags) {
+						_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\n", st.member.Name, st.member.IP, s.word(st.on))
+						continue
+					}
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s (%s): %s %s\n", st.member.Name, st.member.IP, s.label, s.word(st.on))
+				}
+			}
+			return errors.Join(errs...)
+		},
+	}
+	cmd.Flags().StringArrayVar(&rooms, "room", nil, "Room to target (repeatable; defaults to --name/--ip)")
+	return cmd
+}
+
+func newDeviceInfoCmd(flags *rootFlags) *cobra.Command {
+	var rooms []string
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Show model, serial, versions and MAC address",
+		Long: "Shows model name and number, serial number, software and hardware version, MAC address, series ID and S1/S2 of every device in the target rooms " +
+			"(device_description.xml plus DeviceProperties GetZoneInfo/GetZoneAttributes). --all lists every device of the household as a table.",
+		Example:      "  sonos device info --name Office\n  sonos device info --all\n  sonos device info --all --format json",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			var devices []sonos.Member
+			if all {
+				if len(rooms) > 0 {
+					return errors.New("use either --all or --room")
+				}
+				tg, err := newTopologyGetter(ctx, flags.Timeout)
+				if err != nil {
+					return err
+				}
+				top, err := tg.GetTopology(ctx)
+				if err != nil {
+					return err
+				}
+				for _, m := range top.ByIP {
+					devices = append(devices, m)
+				}
+				sort.Slice(devices, func(i, j int) bool {
+					a, b := devices[i], devices[j]
+					if a.Name != b.Name {
+						return a.Name < b.Name
+					}
+					if a.IsVisible != b.IsVisible {
+						return a.IsVisible
+					}
+					return a.IP < b.IP
+				})
+			} else {
+				var err error
+				if devices, err = deviceTargets(ctx, flags, rooms); err != nil {
+					return err
+				}
+			}
+
+			infos := make([]sonos.DeviceInfo, 0, len(devices))
+			var errs []error
+			for _, m := range devices {
+				info, err := newDeviceClient(m.IP, flags.Timeout).GetDeviceInfo(ctx)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s (%s): %w", m.Name, m.IP, err))
+					continue
+				}
+				infos = append(infos, info)
+			}
+
+			if err := writeDeviceInfo(cmd, flags, infos, all); err != nil {
+				return err
+			}
+			return errors.Join(errs...)
+		},
+	}
+	cmd.Flags().StringArrayVar(&rooms, "room", nil, "Room to show (repeatable; defaults to --name/--ip)")
+	cmd.Flags().BoolVar(&all, "all", false, "List every device of the household")
+	return cmd
+}
+
+func newDeviceRenameCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename <new-name>",
+		Short: "Rename a room",
+		Long: "Renames the --name/--ip room via DeviceProperties SetZoneAttributes, keeping its icon and configuration. " +
+			"Saved scenes and config entries (volume limits, defaultRoom) that refer to the old name are updated and the shell completion cache is cleared.",
+		Example:      "  sonos device rename --name Office Studio",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			newName := strings.TrimSpace(args[0])
+			if newName == "" {
+				return errors.New("new room name is required")
+			}
+
+			ctx := cmd.Context()
+			tg, err := newTopologyGetter(ctx, flags.Timeout)
+			if err != nil {
+				return err
+			}
+			top, err := tg.GetTopology(ctx)
+			if err != nil {
+				return err
+			}
+			mem, err := resolveMember(top, flags.Name, flags.IP)
+			if err != nil {
+				return err
+			}
+			for name := range top.ByName {
+				if strings.EqualFold(name, newName) && name != mem.Name {
+					return fmt.Errorf("a room named %q already exists", name)
+				}
+			}
+
+			c := newDeviceClient(mem.IP, flags.Timeout)
+			attrs, err := c.GetZoneAttributes(ctx)
+			if err != nil {
+				return err
+			}
+			oldName := attrs.Name
+			if oldName == "" {
+				oldName = mem.Name
+			}
+			if oldName != newName {
+				attrs.Name = newName
+				if err := c.SetZoneAttributes(ctx, attrs); err != nil {
+					return err
+				}
+			}
+
+			// The room is renamed at this point; scene, config and cache
+			// updates are follow-ups and only reported.
+			var updated []string
+			if store, err := newSceneStore(); err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: scenes not updated: %v\n", err)
+			} else if updated, err = store.RenameRoom(oldName, newName); err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: scenes not updated: %v\n", err)
+			}
+			configUpdated, err := renameConfigRoom(oldName, newName)
+			if err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: config not updated: %v\n", err)
+			}
+			if err := invalidateNameCompletions(); err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: completion cache not cleared: %v\n", err)
+			}
+
+			if isJSON(flags) {
+				if updated == nil {
+					updated = []string{}
+				}
+				return writeOK(cmd, flags, "device.rename", map[string]any{"ip": mem.IP, "from": oldName, "to": newName, "scenes": updated, "config": configUpdated})
+			}
+			line := fmt.Sprintf("Renamed room %q to %q", oldName, newName)
+			var notes []string
+			if len(updated) > 0 {
+				notes = append(notes, "updated scenes: "+strings.Join(updated, ", "))
+			}
+			if configUpdated {
+				notes = append(notes, "updated config")
+			}
+			if len(notes) > 0 {
+				line += " (" + strings.Join(notes, "; ") + ")"
+			}
+			writePlainLine(cmd, flags, line)
+			return nil
+		},
+	}
+}
+
+func writeDeviceInfo(cmd *cobra.Command, flags *rootFlags, infos []sonos.DeviceInfo, table bool) error {
+	if isJSON(flags) {
+		return writeJSON(cmd, infos)
+	}
+	out := cmd.OutOrStdout()
+	if isTSV(flags) {
+		for _, d := range infos {
+			_, _ = fmt.Fprintf(out, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				d.Room, d.IP, d.ModelName, d.ModelNumber, d.SerialNumber, d.SoftwareVersion, d.HardwareVersion, d.MACAddress, d.SeriesID, d.Generation)
+		}
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+	if table {
+		_, _ = fmt.Fprintf(w, "ROOM\tMODEL\tNUMBER\tIP\tMAC\tSERIAL\tVERSION\tGEN\n")
+		for _, d := range infos {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				d.Room, d.ModelName, d.ModelNumber, d.IP, d.MACAddress, d.SerialNumber, d.SoftwareVersion, valueOrDash(d.Generation))
+		}
+		return w.Flush()
+	}
+	for i, d := range infos {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		version := d.SoftwareVersion
+		if d.DisplayVersion != "" {
+			version = fmt.Sprintf("%s (%s)", d.DisplayVersion, d.SoftwareVersion)
+		}
+		rows := [][2]string{
+			{"Room", d.Room},
+			{"Model", fmt.Sprintf("%s (%s)", d.ModelName, d.ModelNumber)},
+			{"IP", d.IP},
+			{"UDN", d.UDN},
+			{"Serial", d.SerialNumber},
+			{"Software", version},
+			{"Hardware", d.HardwareVersion},
+			{"MAC", d.MACAddress},
+			{"Series ID", valueOrDash(d.SeriesID)},
+			{"Generation", valueOrDash(d.Generation)},
+		}
+		for _, r := range rows {
+			_, _ = fmt.Fprintf(w, "%s:\t%s\n", r[0], r[1])
+		}
+	}
+	return w.Flush()
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// deviceTargets resolves rooms (or --name/--ip) to every device that belongs
+// to them: the visible speaker plus bonded pair partners, subs and surrounds,
+// which share the room name.
+func deviceTargets(ctx context.Context, flags *rootFlags, rooms []string) ([]sonos.Member, error) {
+	if len(rooms) == 0 {
+		if err := validateTarget(flags); err != nil {
+			return nil, errors.New("provide --room (or --name/--ip)")
+		}
+	}
+	tg, err := newTopologyGetter(ctx, flags.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	top, err := tg.GetTopology(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var named []sonos.Member
+	if len(rooms) == 0 {
+		mem, err := resolveMember(top, flags.Name, flags.IP)
+		if err != nil {
+			return nil, err
+		}
+		named = append(named, mem)
+	}
+	for _, r := range rooms {
+		mem, err := resolveMember(top, r, "")
+		if err != nil {
+			return nil, err
+		}
+		named = append(named, mem)
+	}
+
+	seen := map[string]bool{}
+	var out []sonos.Member
+	for _, n := range named {
+		var devs []sonos.Member
+		for _, m := range top.ByIP {
+			if m.IP == n.IP || (m.Name != "" && m.Name == n.Name) {
+				devs = append(devs, m)
+			}
+		}
+		sort.Slice(devs, func(i, j int) bool {
+			if devs[i].IsVisible != devs[j].IsVisible {
+				return devs[i].IsVisible
+			}
+			return devs[i].IP < devs[j].IP
+		})
+		for _, d := range devs {
+			if seen[d.IP] {
+				continue
+			}
+			seen[d.IP] = true
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+// renameConfigRoom moves per-room settings and defaultRoom in the CLI config
+// to the room's new name.
+func renameConfigRoom(oldName, newName string) (bool, error) {
+	store, err := newConfigStore()
+	if err != nil {
+		return false, err
+	}
+	cfg, err := store.Load()
+	if err != nil {
+		return false, err
+	}
+	cfg, changed := cfg.RenameRoom(oldName, newName)
+	if !changed {
+		return false, nil
+	}
+	return true, store.Save(cfg)
+}
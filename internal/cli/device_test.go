@@ -0,0 +1,296 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/appconfig"
+	"github.com/steipete/sonoscli/internal/scenes"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakeDeviceClient struct {
+	led     bool
+	locked  bool
+	setErr  error
+	setLEDs int
+	info    sonos.DeviceInfo
+	infoErr error
+	attrs   sonos.ZoneAttributes
+	setAttr []sonos.ZoneAttributes
+}
+
+func (f *fakeDeviceClient) GetLEDState(ctx context.Context) (bool, error) { return f.led, nil }
+func (f *fakeDeviceClient) SetLEDState(ctx context.Context, on bool) error {
+	if f.setErr != nil {
+		return f.setErr
+	}
+	f.led = on
+	f.setLEDs++
+	return nil
+}
+func (f *fakeDeviceClient) GetButtonLockState(ctx context.Context) (bool, error) {
+	return f.locked, nil
+}
+func (f *fakeDeviceClient) SetButtonLockState(ctx context.Context, locked bool) error {
+	if f.setErr != nil {
+		return f.setErr
+	}
+	f.locked = locked
+	return nil
+}
+func (f *fakeDeviceClient) GetDeviceInfo(ctx context.Context) (sonos.DeviceInfo, error) {
+	return f.info, f.infoErr
+}
+func (f *fakeDeviceClient) GetDeviceModel(ctx context.Context) (sonos.DeviceInfo, error) {
+	return f.info, f.infoErr
+}
+func (f *fakeDeviceClient) GetZoneAttributes(ctx context.Context) (sonos.ZoneAttributes, error) {
+	return f.attrs, nil
+}
+func (f *fakeDeviceClient) SetZoneAttributes(ctx context.Context, attrs sonos.ZoneAttributes) error {
+	f.setAttr = append(f.setAttr, attrs)
+	f.attrs = attrs
+	return nil
+}
+
+// deviceTestTopology has a Nursery stereo pair (one visible, one bonded) and a
+// single Kitchen speaker.
+func deviceTestTopology() sonos.Topology {
+	n := sonos.Member{Name: "Nursery", IP: "192.168.1.30", UUID: "RINCON_N1400", IsVisible: true, IsCoordinator: true}
+	nr := sonos.Member{Name: "Nursery", IP: "192.168.1.31", UUID: "RINCON_NR1400"}
+	k := sonos.Member{Name: "Kitchen", IP: "192.168.1.11", UUID: "RINCON_K1400", IsVisible: true, IsCoordinator: true}
+	return sonos.Topology{
+		Groups: []sonos.Group{
+			{ID: "RINCON_N1400:1", Coordinator: n, Members: []sonos.Member{n, nr}},
+			{ID: "RINCON_K1400:1", Coordinator: k, Members: []sonos.Member{k}},
+		},
+		ByName: map[string]sonos.Member{"Nursery": n, "Kitchen": k},
+		ByIP:   map[string]sonos.Member{n.IP: n, nr.IP: nr, k.IP: k},
+	}
+}
+
+func runDeviceCmd(t *testing.T, flags *rootFlags, clients map[string]*fakeDeviceClient, args ...string) (string, error) {
+	t.Helper()
+	origTG, origClient := newTopologyGetter, newDeviceClient
+	t.Cleanup(func() {
+		newTopologyGetter = origTG
+		newDeviceClient = origClient
+	})
+	newTopologyGetter = func(ctx context.Context, timeout time.Duration) (topologyGetter, error) {
+		return &fakeTopologyGetter{top: deviceTestTopology()}, nil
+	}
+	newDeviceClient = func(ip string, timeout time.Duration) deviceClient {
+		c, ok := clients[ip]
+		if !ok {
+			t.Fatalf("unexpected device %s", ip)
+		}
+		return c
+	}
+
+	cmd := newDeviceCmd(flags)
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(newDiscardWriter())
+	cmd.SetArgs(args)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestDeviceLEDOffAppliesToAllDevicesOfRooms(t *testing.T) {
+	clients := map[string]*fakeDeviceClient{
+		"192.168.1.30": {led: true},
+		"192.168.1.31": {led: true},
+		"192.168.1.11": {led: true},
+	}
+	out, err := runDeviceCmd(t, &rootFlags{Timeout: time.Second, Format: formatPlain}, clients, "led", "off", "--room", "Nursery", "--room", "Kitchen")
+	if err != nil {
+		t.Fatalf("led off: %v", err)
+	}
+	for ip, c := range clients {
+		if c.led || c.setLEDs != 1 {
+			t.Fatalf("%s: led=%v sets=%d", ip, c.led, c.setLEDs)
+		}
+	}
+	want := "Nursery (192.168.1.30): LED off\nNursery (192.168.1.31): LED off\nKitchen (192.168.1.11): LED off\n"
+	if out != want {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+}
+
+func TestDeviceButtonsShowsStateJSON(t *testing.T) {
+	clients := map[string]*fakeDeviceClient{
+		"192.168.1.30": {locked: true},
+		"192.168.1.31": {locked: true},
+	}
+	out, err := runDeviceCmd(t, &rootFlags{Name: "Nursery", Timeout: time.Second, Format: formatJSON}, clients, "buttons")
+	if err != nil {
+		t.Fatalf("buttons: %v", err)
+	}
+	if strings.Count(out, `"buttonsLocked": true`) != 2 || !strings.Contains(out, `"ip": "192.168.1.31"`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
+
+func TestDeviceButtonsReportsPerDeviceErrors(t *testing.T) {
+	clients := map[string]*fakeDeviceClient{
+		"192.168.1.30": {},
+		"192.168.1.31": {setErr: errors.New("boom")},
+	}
+	out, err := runDeviceCmd(t, &rootFlags{Name: "Nursery", Timeout: time.Second, Format: formatPlain}, clients, "buttons", "lock")
+	if err == nil || !strings.Contains(err.Error(), "Nursery (192.168.1.31): boom") {
+		t.Fatalf("expected per-device error, got %v", err)
+	}
+	if !clients["192.168.1.30"].locked || out != "Nursery (192.168.1.30): buttons locked\n" {
+		t.Fatalf("locked=%v output %q", clients["192.168.1.30"].locked, out)
+	}
+}
+
+func TestDeviceLEDRejectsInvalidState(t *testing.T) {
+	if _, err := runDeviceCmd(t, &rootFlags{Name: "Nursery", Timeout: time.Second}, nil, "led", "dim"); err == nil {
+		t.Fatalf("expected error")
+	}
+	if _, err := runDeviceCmd(t, &rootFlags{Timeout: time.Second}, nil, "led"); err == nil {
+		t.Fatalf("expected error without target")
+	}
+}
+
+func TestDeviceInfoAllListsHousehold(t *testing.T) {
+	clients := map[string]*fakeDeviceClient{
+		"192.168.1.30": {info: sonos.DeviceInfo{Room: "Nursery", IP: "192.168.1.30", ModelName: "Sonos One", ModelNumber: "S18", MACAddress: "48:A6:B8:00:00:30", SerialNumber: "S-30", SoftwareVersion: "79.1-56030", Generation: "S2"}},
+		"192.168.1.31": {info: sonos.DeviceInfo{Room: "Nursery", IP: "192.168.1.31", ModelName: "Sonos One", ModelNumber: "S18", MACAddress: "48:A6:B8:00:00:31", SerialNumber: "S-31", SoftwareVersion: "79.1-56030", Generation: "S2"}},
+		"192.168.1.11": {info: sonos.DeviceInfo{Room: "Kitchen", IP: "192.168.1.11", ModelName: "Sonos Play:5", ModelNumber: "S6", MACAddress: "00:0E:58:00:00:11", SerialNumber: "S-11", SoftwareVersion: "57.19-41110"}},
+	}
+	out, err := runDeviceCmd(t, &rootFlags{Timeout: time.Second, Format: formatPlain}, clients, "info", "--all")
+	if err != nil {
+		t.Fatalf("info --all: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "ROOM") {
+		t.Fatalf("unexpected table:\n%s", out)
+	}
+	if !strings.HasPrefix(lines[1], "Kitchen") || !strings.HasSuffix(lines[1], " -") {
+		t.Fatalf("kitchen row: %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "192.168.1.30") || !strings.Contains(lines[3], "192.168.1.31") || !strings.HasSuffix(lines[3], "S2") {
+		t.Fatalf("nursery rows:\n%s", out)
+	}
+}
+
+func TestDeviceInfoRoomJSONReportsFailures(t *testing.T) {
+	clients := map[string]*fakeDeviceClient{
+		"192.168.1.30": {info: sonos.DeviceInfo{Room: "Nursery", IP: "192.168.1.30", ModelName: "Sonos One", SerialNumber: "S-30"}},
+		"192.168.1.31": {infoErr: errors.New("timeout")},
+	}
+	out, err := runDeviceCmd(t, &rootFlags{Name: "Nursery", Timeout: time.Second, Format: formatJSON}, clients, "info")
+	if err == nil || !strings.Contains(err.Error(), "Nursery (192.168.1.31): timeout") {
+		t.Fatalf("expected per-device error, got %v", err)
+	}
+	if !strings.Contains(out, `"serialNumber": "S-30"`) || strings.Contains(out, "192.168.1.31") {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
+
+func TestDeviceRenameKeepsAttributesAndUpdatesScenes(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv("SONOSCLI_COMPLETION_CACHE_DIR", cacheDir)
+	if err := storeNameCompletions(time.Now(), []string{"Kitchen", "Nursery"}); err != nil {
+		t.Fatalf("store cache: %v", err)
+	}
+
+	store := &fakeSceneStore{scenes: map[string]scenes.Scene{
+		"Bedtime": {Name: "Bedtime", Devices: []scenes.SceneDevice{{UUID: "RINCON_N1400", Name: "Nursery", Volume: 10}}},
+		"Dinner":  {Name: "Dinner", Devices: []scenes.SceneDevice{{UUID: "RINCON_K1400", Name: "Kitchen", Volume: 30}}},
+	}}
+	origStore := newSceneStore
+	t.Cleanup(func() { newSceneStore = origStore })
+	newSceneStore = func() (scenes.Store, error) { return store, nil }
+	useTempConfigStore(t, appconfig.Config{})
+
+	c := &fakeDeviceClient{attrs: sonos.ZoneAttributes{Name: "Nursery", Icon: "x-rincon-roomicon:nursery", Configuration: "1"}}
+	out, err := runDeviceCmd(t, &rootFlags{Name: "Nursery", Timeout: time.Second, Format: formatPlain},
+		map[string]*fakeDeviceClient{"192.168.1.30": c}, "rename", "Kids Room")
+	if err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	want := []sonos.ZoneAttributes{{Name: "Kids Room", Icon: "x-rincon-roomicon:nursery", Configuration: "1"}}
+	if !reflect.DeepEqual(c.setAttr, want) {
+		t.Fatalf("SetZoneAttributes: %+v", c.setAttr)
+	}
+	if out != "Renamed room \"Nursery\" to \"Kids Room\" (updated scenes: Bedtime)\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if store.scenes["Bedtime"].Devices[0].Name != "Kids Room" || store.scenes["Dinner"].Devices[0].Name != "Kitchen" {
+		t.Fatalf("scenes: %+v", store.scenes)
+	}
+	if _, ok := cachedNameCompletions(time.Now()); ok {
+		t.Fatalf("expected completion cache to be cleared")
+	}
+}
+
+func TestDeviceRenameUpdatesConfigRooms(t *testing.T) {
+	origStore := newSceneStore
+	t.Cleanup(func() { newSceneStore = origStore })
+	newSceneStore = func() (scenes.Store, error) { return &fakeSceneStore{scenes: map[string]scenes.Scene{}}, nil }
+	store := useTempConfigStore(t, appconfig.Config{
+		DefaultRoom: "nursery",
+		Rooms: map[string]appconfig.RoomConfig{
+			"Nursery": {MaxVolume: 30},
+			"Kitchen": {MinVolume: 10},
+		},
+	})
+
+	c := &fakeDeviceClient{attrs: sonos.ZoneAttributes{Name: "Nursery"}}
+	out, err := runDeviceCmd(t, &rootFlags{Name: "Nursery", Timeout: time.Second, Format: formatPlain},
+		map[string]*fakeDeviceClient{"192.168.1.30": c}, "rename", "Kids Room")
+	if err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if out != "Renamed room \"Nursery\" to \"Kids Room\" (updated config)\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	cfg, err := store.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.DefaultRoom != "Kids Room" {
+		t.Fatalf("defaultRoom: %q", cfg.DefaultRoom)
+	}
+	want := map[string]appconfig.RoomConfig{"Kids Room": {MaxVolume: 30}, "Kitchen": {MinVolume: 10}}
+	if !reflect.DeepEqual(cfg.Rooms, want) {
+		t.Fatalf("rooms: %+v", cfg.Rooms)
+	}
+}
+
+func useTempConfigStore(t *testing.T, cfg appconfig.Config) appconfig.Store {
+	t.Helper()
+	store, err := appconfig.NewFileStore(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	if err := store.Save(cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	orig := newConfigStore
+	t.Cleanup(func() { newConfigStore = orig })
+	newConfigStore = func() (appconfig.Store, error) { return store, nil }
+	return store
+}
+
+func TestDeviceRenameRejectsExistingRoom(t *testing.T) {
+	c := &fakeDeviceClient{attrs: sonos.ZoneAttributes{Name: "Nursery"}}
+	_, err := runDeviceCmd(t, &rootFlags{Name: "Nursery", Timeout: time.Second}, map[string]*fakeDeviceClient{"192.168.1.30": c}, "rename", "kitchen")
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected error, got %v", err)
+	}
+	if len(c.setAttr) != 0 {
+		t.Fatalf("unexpected SetZoneAttributes: %+v", c.setAttr)
+	}
+}
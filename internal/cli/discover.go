@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steipete/sonoscli/internal/sonos"
@@ -51,6 +52,10 @@ func newDiscoverCmd(flags *rootFlags) *cobra.Command {
 			}
 
 			for _, d := range devices {
+				if all && d.Bond != nil {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\t%s\n", d.Name, d.IP, d.UDN, bondLabel(*d.Bond))
+					continue
+				}
 				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\n", d.Name, d.IP, d.UDN)
 			}
 			return nil
@@ -59,3 +64,12 @@ func newDiscoverCmd(flags *rootFlags) *cobra.Command {
 	cmd.Flags().BoolVar(&all, "all", false, "Include invisible/bonded devices (advanced)")
 	return cmd
 }
+
+// bondLabel describes a device's part in a bond, e.g. "stereo pair (RF)".
+func bondLabel(b sonos.DeviceBond) string {
+	kind := strings.ReplaceAll(b.Type, "_", " ")
+	if len(b.Channels) == 0 {
+		return kind
+	}
+	return fmt.Sprintf("%s (%s)", kind, strings.Join(b.Channels, ","))
+}
@@ -150,3 +150,35 @@ func TestDiscoverNoDevicesJSONOutputsEmptyArray(t *testing.T) {
 		t.Fatalf("unexpected output: %q", out.String())
 	}
 }
+
+func TestDiscoverAllShowsBonds(t *testing.T) {
+	flags := &rootFlags{Timeout: 5 * time.Second, Format: formatPlain}
+	cmd := newDiscoverCmd(flags)
+	cmd.SetArgs([]string{"--all"})
+
+	orig := discoverFunc
+	t.Cleanup(func() { discoverFunc = orig })
+	discoverFunc = func(ctx context.Context, opts sonos.DiscoverOptions) ([]sonos.Device, error) {
+		return []sonos.Device{
+			{Name: "Shelf", IP: "192.168.1.40", UDN: "RINCON_L1400", Bond: &sonos.DeviceBond{Type: sonos.BondStereoPair, Room: "Shelf", Channels: []string{"LF"}, Primary: true}},
+			{Name: "Shelf", IP: "192.168.1.41", UDN: "RINCON_R1400", Bond: &sonos.DeviceBond{Type: sonos.BondStereoPair, Room: "Shelf", Channels: []string{"RF"}}},
+			{Name: "Office", IP: "192.168.1.20", UDN: "RINCON_OFF1400"},
+		}, nil
+	}
+
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(newDiscardWriter())
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "Office\t192.168.1.20\tRINCON_OFF1400\n" +
+		"Shelf\t192.168.1.40\tRINCON_L1400\tstereo pair (LF)\n" +
+		"Shelf\t192.168.1.41\tRINCON_R1400\tstereo pair (RF)\n"
+	if out.String() != want {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+}
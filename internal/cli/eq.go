@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type eqClient interface {
+	GetEQSettings(ctx context.Context) (sonos.EQSettings, error)
+	SetBass(ctx context.Context, level int) error
+	SetTreble(ctx context.Context, level int) error
+	SetLoudness(ctx context.Context, on bool) error
+	SetBalance(ctx context.Context, balance int) error
+}
+
+var newEQClient = func(ip string, timeout time.Duration) eqClient {
+	return sonos.NewClient(ip, timeout)
+}
+
+func newEQCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "eq",
+		Short: "Get or set bass, treble, loudness and balance",
+		Long: "Controls the RenderingControl tone settings of a speaker. EQ is per speaker, not per group; " +
+			"use --group to read or change every visible member of the target's group.",
+	}
+	cmd.AddCommand(newEQGetCmd(flags))
+	cmd.AddCommand(newEQSetCmd(flags))
+	return cmd
+}
+
+type eqRoom struct {
+	Room string `json:"room"`
+	IP   string `json:"ip"`
+	sonos.EQSettings
+}
+
+func newEQGetCmd(flags *rootFlags) *cobra.Command {
+	var group bool
+
+	cmd := &cobra.Command{
+		Use:          "get",
+		Short:        "Show EQ settings",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			members, err := eqTargets(cmd.Context(), flags, group)
+			if err != nil {
+				return err
+			}
+			rooms := make([]eqRoom, 0, len(members))
+			for _, m := range members {
+				s, err := newEQClient(m.IP, flags.Timeout).GetEQSettings(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("%s (%s): %w", m.Name, m.IP, err)
+				}
+				rooms = append(rooms, eqRoom{Room: m.Name, IP: m.IP, EQSettings: s})
+			}
+
+			if isJSON(flags) {
+				return writeJSON(cmd, map[string]any{"rooms": rooms})
+			}
+			if isTSV(flags) {
+				for _, r := range rooms {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%d\t%d\t%s\t%d\n", r.Room, r.Bass, r.Treble, onOff(r.Loudness), r.Balance)
+				}
+				return nil
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "ROOM\tBASS\tTREBLE\tLOUDNESS\tBALANCE")
+			for _, r := range rooms {
+				_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\n", r.Room, r.Bass, r.Treble, onOff(r.Loudness), formatBalance(r.Balance))
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVar(&group, "group", false, "Show every visible member of the target's group")
+	return cmd
+}
+
+func newEQSetCmd(flags *rootFlags) *cobra.Command {
+	var group bool
+
+	cmd := &cobra.Command{
+		Use:   "set <key=value>...",
+		Short: "Change EQ settings",
+		Long: "Sets one or more of bass=<-10..10>, treble=<-10..10>, loudness=<on|off> and balance=<-100..100> " +
+			"(negative is left, 0 centered, positive right; implemented with the LF/RF channel volumes).",
+		Example:      "  sonos eq set --name Kitchen bass=3 treble=-2\n  sonos eq set --name Kitchen --group loudness=on balance=0",
+		SilenceUsage: true,
+		Args:         cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			changes, err := parseEQChanges(args)
+			if err != nil {
+				return err
+			}
+			members, err := eqTargets(cmd.Context(), flags, group)
+			if err != nil {
+				return err
+			}
+
+			var errs []error
+			rooms := make([]string, 0, len(members))
+			for _, m := range members {
+				if err := changes.apply(cmd.Context(), newEQClient(m.IP, flags.Timeout)); err != nil {
+					errs = append(errs, fmt.Errorf("%s (%s): %w", m.Name, m.IP, err))
+					continue
+				}
+				rooms = append(rooms, m.Name)
+			}
+			if len(errs) > 0 {
+				return errors.Join(errs...)
+			}
+			return writeOK(cmd, flags, "eq.set", map[string]any{"rooms": rooms, "settings": changes})
+		},
+	}
+
+	cmd.Flags().BoolVar(&group, "group", false, "Apply to every visible member of the target's group")
+	return cmd
+}
+
+// eqChanges holds the settings given to `eq set`; nil means unchanged.
+type eqChanges struct {
+	Bass     *int  `json:"bass,omitempty"`
+	Treble   *int  `json:"treble,omitempty"`
+	Loudness *bool `json:"loudness,omitempty"`
+	Balance  *int  `json:"balance,omitempty"`
+}
+
+func parseEQChanges(args []string) (eqChanges, error) {
+	var ch eqChanges
+	for _, arg := range args {
+		key, val, ok := strings.Cut(arg, "=")
+		if !ok {
+			return eqChanges{}, fmt.Errorf("expected key=value: %q", arg)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		val = strings.TrimSpace(val)
+		switch key {
+		case "bass", "treble":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < sonos.MinToneLevel || n > sonos.MaxToneLevel {
+				return eqChanges{}, fmt.Errorf("%s must be between %d and %d: %q", key, sonos.MinToneLevel, sonos.MaxToneLevel, val)
+			}
+			if key == "bass" {
+				ch.Bass = &n
+			} else {
+				ch.Treble = &n
+			}
+		case "loudness":
+			on, err := parseOnOff(val)
+			if err != nil {
+				return eqChanges{}, err
+			}
+			ch.Loudness = &on
+		case "balance":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < sonos.MinBalance || n > sonos.MaxBalance {
+				return eqChanges{}, fmt.Errorf("balance must be between %d and %d: %q", sonos.MinBalance, sonos.MaxBalance, val)
+			}
+			ch.Balance = &n
+		default:
+			return eqChanges{}, fmt.Errorf("unknown EQ setting %q (use bass, treble, loudness, balance)", key)
+		}
+	}
+	return ch, nil
+}
+
+func (ch eqChanges) apply(ctx context.Context, c eqClient) error {
+	if ch.Bass != nil {
+		if err := c.SetBass(ctx, *ch.Bass); err != nil {
+			return err
+		}
+	}
+	if ch.Treble != nil {
+		if err := c.SetTreble(ctx, *ch.Treble); err != nil {
+			return err
+		}
+	}
+	if ch.Loudness != nil {
+		if err := c.SetLoudness(ctx, *ch.Loudness); err != nil {
+			return err
+		}
+	}
+	if ch.Balance != nil {
+		if err := c.SetBalance(ctx, *ch.Balance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eqTargets resolves the target speaker, or all visible members of its group
+// (sorted by name) with group set.
+func eqTargets(ctx context.Context, flags *rootFlags, group bool) ([]sonos.Member, error) {
+	if err := validateTarget(flags); err != nil {
+		return nil, err
+	}
+	tg, err := newTopologyGetter(ctx, flags.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	top, err := tg.GetTopology(ctx)
+	if err != nil {
+		return nil, err
+	}
+	target, err := resolveMember(top, flags.Name, flags.IP)
+	if err != nil {
+		return nil, err
+	}
+	if !group {
+		return []sonos.Member{target}, nil
+	}
+	g, ok := top.GroupForIP(target.IP)
+	if !ok {
+		return nil, errors.New("speaker not found in any group")
+	}
+	var members []sonos.Member
+	for _, m := range g.Members {
+		if m.IsVisible {
+			members = append(members, m)
+		}
+	}
+	sort.SliceStable(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+	return members, nil
+}
+
+// formatBalance renders -20 as "L20", 30 as "R30" and 0 as "center".
+func formatBalance(b int) string {
+	switch {
+	case b < 0:
+		return "L" + strconv.Itoa(-b)
+	case b > 0:
+		return "R" + strconv.Itoa(b)
+	default:
+		return "center"
+	}
+}
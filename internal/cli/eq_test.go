@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakeEQClient struct {
+	settings sonos.EQSettings
+	calls    []string
+}
+
+func (f *fakeEQClient) GetEQSettings(ctx context.Context) (sonos.EQSettings, error) {
+	return f.settings, nil
+}
+
+func (f *fakeEQClient) SetBass(ctx context.Context, level int) error {
+	f.calls = append(f.calls, "bass="+strconv.Itoa(level))
+	return nil
+}
+
+func (f *fakeEQClient) SetTreble(ctx context.Context, level int) error {
+	f.calls = append(f.calls, "treble="+strconv.Itoa(level))
+	return nil
+}
+
+func (f *fakeEQClient) SetLoudness(ctx context.Context, on bool) error {
+	f.calls = append(f.calls, "loudness="+onOff(on))
+	return nil
+}
+
+func (f *fakeEQClient) SetBalance(ctx context.Context, balance int) error {
+	f.calls = append(f.calls, "balance="+strconv.Itoa(balance))
+	return nil
+}
+
+func eqTestTopology() sonos.Topology {
+	lr := sonos.Member{Name: "Living Room", IP: "192.168.1.10", UUID: "RINCON_LR1400", IsVisible: true, IsCoordinator: true}
+	k := sonos.Member{Name: "Kitchen", IP: "192.168.1.11", UUID: "RINCON_K1400", IsVisible: true}
+	return sonos.Topology{
+		Groups: []sonos.Group{{ID: "RINCON_LR1400:1", Coordinator: lr, Members: []sonos.Member{lr, k}}},
+		ByName: map[string]sonos.Member{"Living Room": lr, "Kitchen": k},
+		ByIP:   map[string]sonos.Member{lr.IP: lr, k.IP: k},
+	}
+}
+
+func runEQCmd(t *testing.T, flags *rootFlags, clients map[string]*fakeEQClient, args ...string) (string, error) {
+	t.Helper()
+	origTG, origEQ := newTopologyGetter, newEQClient
+	t.Cleanup(func() {
+		newTopologyGetter = origTG
+		newEQClient = origEQ
+	})
+	newTopologyGetter = func(ctx context.Context, timeout time.Duration) (topologyGetter, error) {
+		return &fakeTopologyGetter{top: eqTestTopology()}, nil
+	}
+	newEQClient = func(ip string, timeout time.Duration) eqClient {
+		c, ok := clients[ip]
+		if !ok {
+			t.Fatalf("unexpected speaker ip: %s", ip)
+		}
+		return c
+	}
+
+	cmd := newEQCmd(flags)
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(newDiscardWriter())
+	cmd.SetArgs(args)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestEQGetGroupPlainAndTSV(t *testing.T) {
+	clients := map[string]*fakeEQClient{
+		"192.168.1.10": {settings: sonos.EQSettings{Bass: 3, Treble: -2, Loudness: true, Balance: -20}},
+		"192.168.1.11": {settings: sonos.EQSettings{}},
+	}
+	out, err := runEQCmd(t, &rootFlags{Name: "Kitchen", Timeout: time.Second}, clients, "get", "--group")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "Kitchen") || !strings.Contains(lines[2], "L20") || !strings.Contains(lines[1], "center") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	out, err = runEQCmd(t, &rootFlags{Name: "Living Room", Timeout: time.Second, Format: formatTSV}, clients, "get")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "Living Room\t3\t-2\ton\t-20\n" {
+		t.Fatalf("unexpected tsv: %q", out)
+	}
+}
+
+func TestEQSetParsesAndAppliesToGroup(t *testing.T) {
+	clients := map[string]*fakeEQClient{"192.168.1.10": {}, "192.168.1.11": {}}
+	out, err := runEQCmd(t, &rootFlags{Name: "Kitchen", Timeout: time.Second, Format: formatJSON}, clients,
+		"set", "--group", "bass=3", "treble=-2", "loudness=on", "balance=-20")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "bass=3 treble=-2 loudness=on balance=-20"
+	for ip, c := range clients {
+		if strings.Join(c.calls, " ") != want {
+			t.Fatalf("%s: unexpected calls %v", ip, c.calls)
+		}
+	}
+	if !strings.Contains(out, `"action": "eq.set"`) || !strings.Contains(out, `"balance": -20`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+
+	for _, bad := range []string{"bass=11", "loudness=maybe", "balance=-101", "volume=3", "bass"} {
+		clients := map[string]*fakeEQClient{"192.168.1.11": {}}
+		if _, err := runEQCmd(t, &rootFlags{Name: "Kitchen", Timeout: time.Second}, clients, "set", bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
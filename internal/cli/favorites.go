@@ -24,11 +24,14 @@ var newFavoritesClient = func(ctx context.Context, flags *rootFlags) (favoritesC
 func newFavoritesCmd(flags *rootFlags) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "favorites",
-		Short: "Browse and play Sonos Favorites",
-		Long:  "Lists and plays Sonos Favorites (ContentDirectory FV:2).",
+		Short: "Browse, play and edit Sonos Favorites",
+		Long:  "Lists, plays and edits Sonos Favorites (ContentDirectory FV:2).",
 	}
 	cmd.AddCommand(newFavoritesListCmd(flags))
 	cmd.AddCommand(newFavoritesOpenCmd(flags))
+	cmd.AddCommand(newFavoritesAddCmd(flags))
+	cmd.AddCommand(newFavoritesRemoveCmd(flags))
+	cmd.AddCommand(newFavoritesRenameCmd(flags))
 	return cmd
 }
 
@@ -124,32 +127,43 @@ func newFavoritesOpenCmd(flags *rootFlags) *cobra.Command {
 				return writeOK(cmd, flags, "favorites.open", map[string]any{"favorite": page.Items[0]})
 			}
 
-			// Search pages until we find a matching title.
-			const pageSize = 100
-			start := 0
-			for {
-				page, err := c.ListFavorites(cmd.Context(), start, pageSize)
-				if err != nil {
-					return err
-				}
-				for _, it := range page.Items {
-					if strings.EqualFold(it.Item.Title, title) {
-						if err := c.PlayFavorite(cmd.Context(), it.Item); err != nil {
-							return err
-						}
-						return writeOK(cmd, flags, "favorites.open", map[string]any{"favorite": it})
-					}
-				}
-				start += page.NumberReturned
-				if page.NumberReturned == 0 || start >= page.TotalMatches {
-					break
-				}
+			it, err := findFavoriteByTitle(cmd.Context(), c, title)
+			if err != nil {
+				return err
 			}
-
-			return errors.New("favorite not found: " + title)
+			if err := c.PlayFavorite(cmd.Context(), it.Item); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "favorites.open", map[string]any{"favorite": it})
 		},
 	}
 
 	cmd.Flags().IntVar(&index, "index", 0, "1-based favorite index from `sonos favorites list`")
 	return cmd
 }
+
+type favoritesLister interface {
+	ListFavorites(ctx context.Context, start, count int) (sonos.FavoritesPage, error)
+}
+
+// findFavoriteByTitle pages through FV:2 looking for a case-insensitive exact title match.
+func findFavoriteByTitle(ctx context.Context, c favoritesLister, title string) (sonos.FavoriteItem, error) {
+	const pageSize = 100
+	start := 0
+	for {
+		page, err := c.ListFavorites(ctx, start, pageSize)
+		if err != nil {
+			return sonos.FavoriteItem{}, err
+		}
+		for _, it := range page.Items {
+			if strings.EqualFold(it.Item.Title, title) {
+				return it, nil
+			}
+		}
+		start += page.NumberReturned
+		if page.NumberReturned == 0 || start >= page.TotalMatches {
+			break
+		}
+	}
+	return sonos.FavoriteItem{}, errors.New("favorite not found: " + title)
+}
@@ -0,0 +1,312 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type favoritesEditClient interface {
+	ListFavorites(ctx context.Context, start, count int) (sonos.FavoritesPage, error)
+	CreateFavorite(ctx context.Context, fav sonos.DIDLItem) (string, error)
+	RenameFavorite(ctx context.Context, fav sonos.DIDLItem, title string) error
+	DeleteFavorite(ctx context.Context, id string) error
+	GetMediaInfo(ctx context.Context) (sonos.MediaInfo, error)
+	GetPositionInfo(ctx context.Context) (sonos.PositionInfo, error)
+}
+
+var newFavoritesEditClient = func(ctx context.Context, flags *rootFlags) (favoritesEditClient, error) {
+	return coordinatorClient(ctx, flags)
+}
+
+func newFavoritesAddCmd(flags *rootFlags) *cobra.Command {
+	var (
+		current     bool
+		search      string
+		serviceName string
+		category    string
+		index       int
+		title       string
+		meta        string
+		metaFile    string
+		description string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add [spotify-uri|uri]",
+		Short: "Add a Sonos Favorite",
+		Long: "Adds a Sonos Favorite (ContentDirectory CreateObject on FV:2) from exactly one source:\n" +
+			"  --current             what the target room is playing (current track or station)\n" +
+			"  <spotify-uri>         a Spotify URI or share link (requires --title)\n" +
+			"  --search <query>      an SMAPI search result (see `sonos smapi search`, --index picks the result)\n" +
+			"  <uri> [--meta DIDL]   a raw URI plus optional DIDL metadata (--meta or --meta-file)",
+		Example: "  sonos favorites add --name Kitchen --current\n" +
+			"  sonos favorites add --name Kitchen --title \"Kind of Blue\" spotify:album:1weenld61qoidwYuZ1GESA\n" +
+			"  sonos favorites add --name Kitchen --search \"so what miles davis\" --index 2\n" +
+			"  sonos favorites add --name Kitchen --title \"Radio\" --meta-file radio.xml x-rincon-mp3radio://example.com/stream",
+		SilenceUsage: true,
+		Args:         cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			var uri string
+			if len(args) == 1 {
+				uri = strings.TrimSpace(args[0])
+			}
+			sources := 0
+			for _, set := range []bool{current, strings.TrimSpace(search) != "", uri != ""} {
+				if set {
+					sources++
+				}
+			}
+			if sources != 1 {
+				return errors.New("provide exactly one of --current, --search or a URI")
+			}
+			if metaFile != "" {
+				if meta != "" {
+					return errors.New("use only one of --meta or --meta-file")
+				}
+				b, err := os.ReadFile(metaFile)
+				if err != nil {
+					return err
+				}
+				meta = string(b)
+			}
+			if (meta != "") && (current || search != "") {
+				return errors.New("--meta/--meta-file only apply to a raw URI")
+			}
+
+			ctx := cmd.Context()
+			c, err := newFavoritesEditClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+
+			var fav sonos.DIDLItem
+			switch {
+			case current:
+				fav, err = currentItemFavorite(ctx, c)
+			case search != "":
+				fav, err = smapiResultFavorite(ctx, flags, serviceName, category, search, index, title)
+			default:
+				fav, err = uriFavorite(uri, title, meta)
+			}
+			if err != nil {
+				return err
+			}
+			if t := strings.TrimSpace(title); t != "" {
+				fav.Title = t
+			}
+			if d := strings.TrimSpace(description); d != "" {
+				fav.Description = d
+			}
+			if strings.TrimSpace(fav.Title) == "" {
+				return errors.New("could not determine a title; pass --title")
+			}
+
+			id, err := c.CreateFavorite(ctx, fav)
+			if err != nil {
+				return err
+			}
+			fav.ID = id
+			writePlainLine(cmd, flags, fmt.Sprintf("Added favorite %q (%s)", fav.Title, id))
+			return writeOK(cmd, flags, "favorites.add", map[string]any{"favorite": fav})
+		},
+	}
+
+	cmd.Flags().BoolVar(&current, "current", false, "Use what the target room is playing")
+	cmd.Flags().StringVar(&search, "search", "", "Use an SMAPI search result for this query")
+	cmd.Flags().StringVar(&serviceName, "service", "Spotify", "Music service for --search (as shown in `sonos smapi services`)")
+	cmd.Flags().StringVar(&category, "category", "tracks", "Search category for --search (e.g. tracks|albums|artists|playlists)")
+	cmd.Flags().IntVar(&index, "index", 1, "Which --search result to use (1-based)")
+	cmd.Flags().StringVar(&title, "title", "", "Favorite title (defaults to the item's title)")
+	cmd.Flags().StringVar(&meta, "meta", "", "DIDL-Lite metadata for a raw URI (stored as r:resMD)")
+	cmd.Flags().StringVar(&metaFile, "meta-file", "", "Read DIDL-Lite metadata for a raw URI from a file")
+	cmd.Flags().StringVar(&description, "description", "", "Favorite description (shown by the Sonos app, e.g. the service name)")
+	return cmd
+}
+
+func newFavoritesRemoveCmd(flags *rootFlags) *cobra.Command {
+	var index int
+
+	cmd := &cobra.Command{
+		Use:          "remove [title]",
+		Short:        "Remove a Sonos Favorite by title or index",
+		SilenceUsage: true,
+		Args:         cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := newFavoritesEditClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			it, err := resolveFavoriteArg(ctx, c, args, index)
+			if err != nil {
+				return err
+			}
+			if err := c.DeleteFavorite(ctx, it.Item.ID); err != nil {
+				return err
+			}
+			writePlainLine(cmd, flags, fmt.Sprintf("Removed favorite %q", it.Item.Title))
+			return writeOK(cmd, flags, "favorites.remove", map[string]any{"favorite": it})
+		},
+	}
+
+	cmd.Flags().IntVar(&index, "index", 0, "1-based favorite index from `sonos favorites list`")
+	return cmd
+}
+
+func newFavoritesRenameCmd(flags *rootFlags) *cobra.Command {
+	var index int
+
+	cmd := &cobra.Command{
+		Use:          "rename [title] <new-title>",
+		Short:        "Rename a Sonos Favorite",
+		Example:      "  sonos favorites rename --name Kitchen \"Old Title\" \"New Title\"\n  sonos favorites rename --name Kitchen --index 3 \"New Title\"",
+		SilenceUsage: true,
+		Args:         cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			newTitle := strings.TrimSpace(args[len(args)-1])
+			if newTitle == "" {
+				return errors.New("new title is required")
+			}
+			ctx := cmd.Context()
+			c, err := newFavoritesEditClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			it, err := resolveFavoriteArg(ctx, c, args[:len(args)-1], index)
+			if err != nil {
+				return err
+			}
+			if err := c.RenameFavorite(ctx, it.Item, newTitle); err != nil {
+				return err
+			}
+			writePlainLine(cmd, flags, fmt.Sprintf("Renamed favorite %q to %q", it.Item.Title, newTitle))
+			return writeOK(cmd, flags, "favorites.rename", map[string]any{"id": it.Item.ID, "from": it.Item.Title, "to": newTitle})
+		},
+	}
+
+	cmd.Flags().IntVar(&index, "index", 0, "1-based favorite index from `sonos favorites list`")
+	return cmd
+}
+
+// resolveFavoriteArg picks a favorite by --index or by the (single) title arg.
+func resolveFavoriteArg(ctx context.Context, c favoritesLister, args []string, index int) (sonos.FavoriteItem, error) {
+	var title string
+	if len(args) == 1 {
+		title = strings.TrimSpace(args[0])
+	}
+	if (index > 0) == (title != "") {
+		return sonos.FavoriteItem{}, errors.New("provide either --index or a title")
+	}
+	if index > 0 {
+		page, err := c.ListFavorites(ctx, index-1, 1)
+		if err != nil {
+			return sonos.FavoriteItem{}, err
+		}
+		if len(page.Items) == 0 {
+			return sonos.FavoriteItem{}, errors.New("favorite index out of range: " + strconv.Itoa(index))
+		}
+		return page.Items[0], nil
+	}
+	return findFavoriteByTitle(ctx, c, title)
+}
+
+// currentItemFavorite turns what the room is playing into a favorite: the
+// current track for queue playback, the transport URI (station, stream)
+// otherwise.
+func currentItemFavorite(ctx context.Context, c favoritesEditClient) (sonos.DIDLItem, error) {
+	mi, err := c.GetMediaInfo(ctx)
+	if err != nil {
+		return sonos.DIDLItem{}, err
+	}
+	uri, meta := mi.CurrentURI, mi.CurrentURIMetaData
+	switch src := sonos.ClassifySource(uri); src {
+	case sonos.SourceQueue:
+		pi, err := c.GetPositionInfo(ctx)
+		if err != nil {
+			return sonos.DIDLItem{}, err
+		}
+		uri, meta = pi.TrackURI, pi.TrackMeta
+	case sonos.SourceNone:
+		return sonos.DIDLItem{}, errors.New("nothing is playing")
+	case sonos.SourceLineIn, sonos.SourceTV, sonos.SourceSpotifyConnect, sonos.SourceAirPlay, sonos.SourceGrouped:
+		return sonos.DIDLItem{}, fmt.Errorf("cannot add the current %s source as a favorite", strings.ReplaceAll(string(src), "_", " "))
+	}
+	if strings.TrimSpace(uri) == "" {
+		return sonos.DIDLItem{}, errors.New("nothing is playing")
+	}
+	fav := sonos.DIDLItem{URI: uri, ResMD: meta}
+	if items, err := sonos.ParseDIDLItems(meta); err == nil && len(items) > 0 {
+		fav.Title = items[0].Title
+		fav.AlbumArtURI = items[0].AlbumArtURI
+	}
+	return fav, nil
+}
+
+func smapiResultFavorite(ctx context.Context, flags *rootFlags, serviceName, category, query string, index int, title string) (sonos.DIDLItem, error) {
+	if index <= 0 {
+		index = 1
+	}
+	searcher, _, _, err := newSMAPISearcher(ctx, flags, serviceName)
+	if err != nil {
+		return sonos.DIDLItem{}, err
+	}
+	res, err := searcher.Search(ctx, category, strings.TrimSpace(query), 0, 25)
+	if err != nil {
+		return sonos.DIDLItem{}, err
+	}
+	items := append([]sonos.SMAPIItem{}, res.MediaMetadata...)
+	items = append(items, res.MediaCollection...)
+	if len(items) == 0 {
+		return sonos.DIDLItem{}, errors.New("no results")
+	}
+	if index > len(items) {
+		return sonos.DIDLItem{}, fmt.Errorf("--index %d out of range (got %d results)", index, len(items))
+	}
+	selected := items[index-1]
+	if strings.TrimSpace(title) == "" {
+		title = selected.Title
+	}
+	if _, ok := sonos.ParseSpotifyRef(selected.ID); !ok {
+		return sonos.DIDLItem{}, errors.New("selected result is not a supported Spotify ref: " + selected.ID)
+	}
+	return sonos.SpotifyFavorite(selected.ID, title)
+}
+
+func uriFavorite(uri, title, meta string) (sonos.DIDLItem, error) {
+	// Spotify URIs/links get the same DIDL the Sonos app writes; Sonos URIs
+	// (x-sonos-spotify:..., x-rincon-...) are stored as given.
+	if _, ok := sonos.ParseSpotifyRef(uri); ok && meta == "" && !strings.HasPrefix(strings.ToLower(uri), "x-") {
+		if strings.TrimSpace(title) == "" {
+			return sonos.DIDLItem{}, errors.New("--title is required for Spotify URIs")
+		}
+		return sonos.SpotifyFavorite(uri, title)
+	}
+	fav := sonos.DIDLItem{URI: uri, ResMD: strings.TrimSpace(meta)}
+	if fav.ResMD != "" {
+		items, err := sonos.ParseDIDLItems(fav.ResMD)
+		if err != nil {
+			return sonos.DIDLItem{}, fmt.Errorf("invalid --meta: %w", err)
+		}
+		if len(items) > 0 {
+			fav.Title = items[0].Title
+			fav.AlbumArtURI = items[0].AlbumArtURI
+		}
+	}
+	return fav, nil
+}
@@ -105,3 +105,142 @@ func TestFavoritesOpenByIndex(t *testing.T) {
 		t.Fatalf("expected Fav 2, got %q", fake.lastItem.Title)
 	}
 }
+
+type fakeFavoritesEditClient struct {
+	fakeFavoritesClient
+
+	media    sonos.MediaInfo
+	position sonos.PositionInfo
+
+	created []sonos.DIDLItem
+	renamed map[string]string
+	deleted []string
+}
+
+func (f *fakeFavoritesEditClient) CreateFavorite(ctx context.Context, fav sonos.DIDLItem) (string, error) {
+	f.created = append(f.created, fav)
+	return "FV:2/99", nil
+}
+
+func (f *fakeFavoritesEditClient) RenameFavorite(ctx context.Context, fav sonos.DIDLItem, title string) error {
+	if f.renamed == nil {
+		f.renamed = map[string]string{}
+	}
+	f.renamed[fav.ID] = title
+	return nil
+}
+
+func (f *fakeFavoritesEditClient) DeleteFavorite(ctx context.Context, id string) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func (f *fakeFavoritesEditClient) GetMediaInfo(ctx context.Context) (sonos.MediaInfo, error) {
+	return f.media, nil
+}
+
+func (f *fakeFavoritesEditClient) GetPositionInfo(ctx context.Context) (sonos.PositionInfo, error) {
+	return f.position, nil
+}
+
+func runFavoritesEditCmd(t *testing.T, fake *fakeFavoritesEditClient, args ...string) (string, error) {
+	t.Helper()
+	orig := newFavoritesEditClient
+	t.Cleanup(func() { newFavoritesEditClient = orig })
+	newFavoritesEditClient = func(ctx context.Context, flags *rootFlags) (favoritesEditClient, error) {
+		return fake, nil
+	}
+
+	flags := &rootFlags{Name: "Kitchen", Timeout: 2 * time.Second}
+	cmd := newFavoritesCmd(flags)
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(newDiscardWriter())
+	cmd.SetArgs(args)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestFavoritesAddCurrentQueueTrack(t *testing.T) {
+	meta := `<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/">` +
+		`<item id="-1" parentID="-1"><dc:title>So What</dc:title><upnp:class>object.item.audioItem.musicTrack</upnp:class></item></DIDL-Lite>`
+	fake := &fakeFavoritesEditClient{
+		media:    sonos.MediaInfo{CurrentURI: "x-rincon-queue:RINCON_1#0"},
+		position: sonos.PositionInfo{TrackURI: "x-file-cifs://nas/so-what.flac", TrackMeta: meta},
+	}
+	out, err := runFavoritesEditCmd(t, fake, "add", "--current")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fake.created) != 1 {
+		t.Fatalf("expected one favorite, got %d", len(fake.created))
+	}
+	got := fake.created[0]
+	if got.Title != "So What" || got.URI != "x-file-cifs://nas/so-what.flac" || got.ResMD != meta {
+		t.Fatalf("unexpected favorite: %+v", got)
+	}
+	if !strings.Contains(out, `Added favorite "So What" (FV:2/99)`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
+
+func TestFavoritesAddRejectsLineIn(t *testing.T) {
+	fake := &fakeFavoritesEditClient{media: sonos.MediaInfo{CurrentURI: "x-rincon-stream:RINCON_1"}}
+	if _, err := runFavoritesEditCmd(t, fake, "add", "--current"); err == nil || !strings.Contains(err.Error(), "line in") {
+		t.Fatalf("expected line-in error, got %v", err)
+	}
+	if len(fake.created) != 0 {
+		t.Fatalf("expected no favorite, got %+v", fake.created)
+	}
+}
+
+func TestFavoritesAddSpotifyURI(t *testing.T) {
+	fake := &fakeFavoritesEditClient{}
+	if _, err := runFavoritesEditCmd(t, fake, "add", "spotify:album:1weenld61qoidwYuZ1GESA"); err == nil {
+		t.Fatalf("expected error without --title")
+	}
+	if _, err := runFavoritesEditCmd(t, fake, "add", "--title", "Kind of Blue", "spotify:album:1weenld61qoidwYuZ1GESA"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fake.created) != 1 {
+		t.Fatalf("expected one favorite, got %d", len(fake.created))
+	}
+	got := fake.created[0]
+	if got.Title != "Kind of Blue" || !strings.HasPrefix(got.URI, "x-rincon-cpcontainer:") || !strings.Contains(got.ResMD, "spotify%3aalbum%3a1weenld61qoidwYuZ1GESA") {
+		t.Fatalf("unexpected favorite: %+v", got)
+	}
+}
+
+func TestFavoritesRemoveAndRename(t *testing.T) {
+	fake := &fakeFavoritesEditClient{
+		fakeFavoritesClient: fakeFavoritesClient{page: sonos.FavoritesPage{
+			Items: []sonos.FavoriteItem{
+				{Position: 1, Item: sonos.DIDLItem{ID: "FV:2/1", Title: "Fav 1", URI: "x://1"}},
+				{Position: 2, Item: sonos.DIDLItem{ID: "FV:2/2", Title: "Fav 2", URI: "x://2"}},
+			},
+			NumberReturned: 2,
+			TotalMatches:   2,
+		}},
+	}
+	if _, err := runFavoritesEditCmd(t, fake, "remove", "fav 1"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if len(fake.deleted) != 1 || fake.deleted[0] != "FV:2/1" {
+		t.Fatalf("unexpected deletes: %v", fake.deleted)
+	}
+	out, err := runFavoritesEditCmd(t, fake, "rename", "--index", "2", "Morning")
+	if err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if fake.renamed["FV:2/2"] != "Morning" {
+		t.Fatalf("unexpected renames: %v", fake.renamed)
+	}
+	if !strings.Contains(out, `Renamed favorite "Fav 2" to "Morning"`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+	if _, err := runFavoritesEditCmd(t, fake, "rename", "--index", "1", "Fav 1", "New"); err == nil {
+		t.Fatalf("expected error for --index plus title")
+	}
+}
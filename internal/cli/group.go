@@ -102,6 +102,27 @@ func newGroupStatusCmd(flags *rootFlags) *cobra.Command {
 				return nil
 			}
 
+			// With --all, bonded rooms are shown with their physical devices,
+			// e.g. "Living Room = Arc + Sub + 2× One SL (surround)".
+			rooms := map[string][]bondedDevice{}
+			bonded := map[string]bool{}
+			if all {
+				for _, g := range top.Groups {
+					for _, m := range g.Members {
+						if !m.IsVisible {
+							continue
+						}
+						if devs := roomDevices(top, m); len(devs) > 0 {
+							lookupModels(cmd.Context(), flags.Timeout, devs)
+							rooms[m.UUID] = devs
+							for _, d := range devs {
+								bonded[d.UUID] = true
+							}
+						}
+					}
+				}
+			}
+
 			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
 			for _, g := range top.Groups {
 				coord := g.Coordinator
@@ -110,18 +131,29 @@ func newGroupStatusCmd(flags *rootFlags) *cobra.Command {
 					if !all && !m.IsVisible {
 						continue
 					}
+					if !m.IsVisible && bonded[m.UUID] {
+						continue // listed under its room
+					}
 					mark := " "
 					if m.IsCoordinator {
 						mark = "*"
 					}
-					_, _ = fmt.Fprintf(w, "  %s\t%s\t(%s)\n", mark, m.Name, m.IP)
+					devs, ok := rooms[m.UUID]
+					if !ok {
+						_, _ = fmt.Fprintf(w, "  %s\t%s\t(%s)\n", mark, m.Name, m.IP)
+						continue
+					}
+					_, _ = fmt.Fprintf(w, "  %s\t%s = %s\t(%s)\n", mark, m.Name, roomStructure(devs), m.IP)
+					for _, d := range devs {
+						_, _ = fmt.Fprintf(w, "   \t  - %s [%s]\t(%s)\n", d.Model, strings.Join(d.Channels, ","), d.IP)
+					}
 				}
 				_, _ = fmt.Fprintln(w)
 			}
 			return w.Flush()
 		},
 	}
-	cmd.Flags().BoolVar(&all, "all", false, "Include invisible/bonded devices (advanced)")
+	cmd.Flags().BoolVar(&all, "all", false, "Include invisible/bonded devices and show each room's physical structure")
 	return cmd
 }
 
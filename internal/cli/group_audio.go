@@ -51,9 +51,12 @@ func newGroupVolumeCmd(flags *rootFlags) *cobra.Command {
 		},
 	})
 
-	cmd.AddCommand(&cobra.Command{
-		Use:          "set <0-100>",
-		Short:        "Set group volume",
+	var force bool
+	setCmd := &cobra.Command{
+		Use:   "set <0-100>",
+		Short: "Set group volume",
+		Long: "Sets the group volume. The strictest configured limit of any room in the group applies unless --force is given. " +
+			"Group volume scales every member, so rooms with limits are then also held to their own limits.",
 		Args:         cobra.ExactArgs(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -64,6 +67,16 @@ func newGroupVolumeCmd(flags *rootFlags) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			limiter, err := newVolumeLimiter(force)
+			if err != nil {
+				return err
+			}
+			lim, err := limiter.targetLimit(cmd.Context(), flags, true)
+			if err != nil {
+				return err
+			}
+			requested := v
+			v, limited := clampVolume(cmd, lim, "", v)
 			c, err := newGroupAudioClient(cmd.Context(), flags)
 			if err != nil {
 				return err
@@ -71,9 +84,20 @@ func newGroupVolumeCmd(flags *rootFlags) *cobra.Command {
 			if err := c.SetGroupVolume(cmd.Context(), v); err != nil {
 				return err
 			}
-			return writeOK(cmd, flags, "group.volume.set", map[string]any{"volume": v})
+			clamp, err := limiter.memberClamp(cmd.Context(), cmd, flags)
+			if err != nil {
+				return err
+			}
+			if clamp != nil {
+				if err := clamp(cmd.Context()); err != nil {
+					return err
+				}
+			}
+			return writeOK(cmd, flags, "group.volume.set", limitedOutput(map[string]any{"volume": v}, requested, limited))
 		},
-	})
+	}
+	setCmd.Flags().BoolVar(&force, "force", false, "Ignore configured volume limits")
+	cmd.AddCommand(setCmd)
 
 	return cmd
 }
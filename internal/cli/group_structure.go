@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+// bondedDevice is one physical device of a room with a stereo pair or
+// home-theater bond.
+type bondedDevice struct {
+	UUID     string
+	IP       string
+	Model    string
+	Channels []string
+	Role     string // "", "surround" or "stereo pair"
+	order    int
+}
+
+// roomDevices lists the devices bonded to the visible member m (soundbar or
+// pair primary first, then sub, then surrounds). It returns nil when m has no
+// bond.
+func roomDevices(top sonos.Topology, m sonos.Member) []bondedDevice {
+	var devs []bondedDevice
+	index := map[string]int{}
+	for _, b := range top.Bonds() {
+		if b.Primary != m.UUID {
+			continue
+		}
+		for _, bm := range b.Members {
+			i, ok := index[bm.UUID]
+			if !ok {
+				i = len(devs)
+				index[bm.UUID] = i
+				devs = append(devs, bondedDevice{UUID: bm.UUID, IP: bm.IP})
+			}
+			d := &devs[i]
+			for _, ch := range bm.Channels {
+				if !slices.Contains(d.Channels, ch) {
+					d.Channels = append(d.Channels, ch)
+				}
+			}
+			switch {
+			case slices.Contains(d.Channels, "SW"):
+				d.Role, d.order = "", 1
+			case slices.Contains(d.Channels, "LR") || slices.Contains(d.Channels, "RR"):
+				d.Role, d.order = "surround", 2
+			case b.Type == sonos.BondStereoPair:
+				d.Role, d.order = "stereo pair", 0
+			}
+		}
+	}
+	sort.SliceStable(devs, func(i, j int) bool { return devs[i].order < devs[j].order })
+	return devs
+}
+
+// roomStructure renders devices as e.g. "Arc + Sub + 2× One SL (surround)".
+func roomStructure(devs []bondedDevice) string {
+	var parts []string
+	for i := 0; i < len(devs); {
+		j := i + 1
+		for j < len(devs) && devs[j].Model == devs[i].Model && devs[j].Role == devs[i].Role {
+			j++
+		}
+		part := devs[i].Model
+		if n := j - i; n > 1 {
+			part = fmt.Sprintf("%d× %s", n, part)
+		}
+		if devs[i].Role != "" {
+			part += " (" + devs[i].Role + ")"
+		}
+		parts = append(parts, part)
+		i = j
+	}
+	return strings.Join(parts, " + ")
+}
+
+// lookupModels fills in the model of each device from its device
+// description, asking all devices at once; devices that do not answer are
+// shown as "speaker".
+func lookupModels(ctx context.Context, timeout time.Duration, devs []bondedDevice) {
+	var wg sync.WaitGroup
+	for i := range devs {
+		devs[i].Model = "speaker"
+		if devs[i].IP == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(d *bondedDevice) {
+			defer wg.Done()
+			info, err := newDeviceClient(d.IP, timeout).GetDeviceModel(ctx)
+			if err != nil {
+				return
+			}
+			if model := deviceModel(info); model != "" {
+				d.Model = model
+			}
+		}(&devs[i])
+	}
+	wg.Wait()
+}
+
+func deviceModel(info sonos.DeviceInfo) string {
+	if info.DisplayName != "" {
+		return info.DisplayName
+	}
+	return strings.TrimSpace(strings.TrimPrefix(info.ModelName, "Sonos "))
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+func TestRoomStructure(t *testing.T) {
+	cases := []struct {
+		devs []bondedDevice
+		want string
+	}{
+		{
+			devs: []bondedDevice{{Model: "Arc"}, {Model: "Sub"}, {Model: "One SL", Role: "surround"}, {Model: "One SL", Role: "surround"}},
+			want: "Arc + Sub + 2× One SL (surround)",
+		},
+		{
+			devs: []bondedDevice{{Model: "One", Role: "stereo pair"}, {Model: "One", Role: "stereo pair"}, {Model: "Sub Mini"}},
+			want: "2× One (stereo pair) + Sub Mini",
+		},
+		{
+			devs: []bondedDevice{{Model: "Beam"}, {Model: "speaker"}},
+			want: "Beam + speaker",
+		},
+	}
+	for _, tc := range cases {
+		if got := roomStructure(tc.devs); got != tc.want {
+			t.Fatalf("roomStructure: got %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestGroupStatusAllShowsPhysicalStructure(t *testing.T) {
+	const htMap = "RINCON_ARC1400:LF,RF;RINCON_SUB1400:SW;RINCON_SL1400:LR;RINCON_SR1400:RR"
+	arc := sonos.Member{Name: "Living Room", IP: "192.168.1.50", UUID: "RINCON_ARC1400", IsVisible: true, IsCoordinator: true, HTSatChanMapSet: htMap}
+	sub := sonos.Member{Name: "Living Room", IP: "192.168.1.51", UUID: "RINCON_SUB1400", HTSatChanMapSet: htMap}
+	sl := sonos.Member{Name: "Living Room", IP: "192.168.1.52", UUID: "RINCON_SL1400", HTSatChanMapSet: htMap}
+	sr := sonos.Member{Name: "Living Room", IP: "192.168.1.53", UUID: "RINCON_SR1400", HTSatChanMapSet: htMap}
+	k := sonos.Member{Name: "Kitchen", IP: "192.168.1.11", UUID: "RINCON_K1400", IsVisible: true}
+	arc.Satellites = []sonos.Member{sub, sl, sr}
+	top := sonos.Topology{
+		Groups: []sonos.Group{{ID: "RINCON_ARC1400:3", Coordinator: arc, Members: []sonos.Member{arc, sub, sl, sr, k}}},
+		ByIP:   map[string]sonos.Member{arc.IP: arc, sub.IP: sub, sl.IP: sl, sr.IP: sr, k.IP: k},
+	}
+	models := map[string]string{"192.168.1.50": "Arc", "192.168.1.51": "Sub", "192.168.1.52": "One SL", "192.168.1.53": "One SL"}
+
+	origTG, origClient := newTopologyGetter, newDeviceClient
+	t.Cleanup(func() {
+		newTopologyGetter = origTG
+		newDeviceClient = origClient
+	})
+	newTopologyGetter = func(ctx context.Context, timeout time.Duration) (topologyGetter, error) {
+		return &fakeTopologyGetter{top: top}, nil
+	}
+	newDeviceClient = func(ip string, timeout time.Duration) deviceClient {
+		model, ok := models[ip]
+		if !ok {
+			t.Errorf("unexpected device lookup %s", ip)
+		}
+		return &fakeDeviceClient{info: sonos.DeviceInfo{IP: ip, DisplayName: model}}
+	}
+
+	cmd := newGroupStatusCmd(&rootFlags{Timeout: time.Second, Format: formatPlain})
+	cmd.SetArgs([]string{"--all"})
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(newDiscardWriter())
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("group status --all: %v", err)
+	}
+
+	s := out.String()
+	for _, want := range []string{
+		"Living Room = Arc + Sub + 2× One SL (surround)",
+		"- Sub [SW]",
+		"- One SL [RR]",
+		"(192.168.1.53)",
+		"Kitchen",
+	} {
+		if !strings.Contains(s, want) {
+			t.Fatalf("output missing %q:\n%s", want, s)
+		}
+	}
+	// Bonded devices are listed once, under their room.
+	if strings.Count(s, "192.168.1.51") != 1 {
+		t.Fatalf("sub listed more than once:\n%s", s)
+	}
+}
+
+func TestLookupModelsAsksDevicesConcurrently(t *testing.T) {
+	devs := []bondedDevice{{IP: "192.168.1.50"}, {IP: "192.168.1.51"}, {IP: "192.168.1.52"}, {}}
+
+	origClient := newDeviceClient
+	t.Cleanup(func() { newDeviceClient = origClient })
+	var started sync.WaitGroup
+	started.Add(3)
+	newDeviceClient = func(ip string, timeout time.Duration) deviceClient {
+		started.Done()
+		// Every lookup waits for the others, so serial lookups would never finish.
+		started.Wait()
+		if ip == "192.168.1.52" {
+			return &fakeDeviceClient{infoErr: errors.New("timeout")}
+		}
+		return &fakeDeviceClient{info: sonos.DeviceInfo{ModelName: "Sonos Era 100"}}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		lookupModels(context.Background(), time.Second, devs)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("lookups did not run concurrently")
+	}
+	for i, want := range []string{"Era 100", "Era 100", "speaker", "speaker"} {
+		if devs[i].Model != want {
+			t.Fatalf("device %d: got %q, want %q", i, devs[i].Model, want)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type htClient interface {
+	GetEQ(ctx context.Context, eqType string) (int, error)
+	SetEQ(ctx context.Context, eqType string, value int) error
+}
+
+var newHTClient = func(ip string, timeout time.Duration) htClient {
+	return sonos.NewClient(ip, timeout)
+}
+
+func newHTCmd(flags *rootFlags) *cobra.Command {
+	names := make([]string, 0, len(sonos.HTSettings()))
+	for _, s := range sonos.HTSettings() {
+		names = append(names, s.Name)
+	}
+	cmd := &cobra.Command{
+		Use:   "ht",
+		Short: "Home theater settings (night mode, dialog, sub, surround)",
+		Long: "Reads and changes soundbar settings via RenderingControl GetEQ/SetEQ: " + strings.Join(names, ", ") + ".\n" +
+			"Sub and surround settings need those speakers bonded to the soundbar (from the topology); " +
+			"settings a room does not have are reported as unsupported.",
+	}
+	cmd.AddCommand(newHTGetCmd(flags))
+	cmd.AddCommand(newHTSetCmd(flags))
+	return cmd
+}
+
+type htRoom struct {
+	Room        string            `json:"room"`
+	IP          string            `json:"ip"`
+	Bonded      bool              `json:"bonded"`
+	Bond        sonos.HTBond      `json:"bond"`
+	Settings    map[string]any    `json:"settings"`
+	Unsupported map[string]string `json:"unsupported,omitempty"`
+}
+
+func (r *htRoom) unsupported(name, reason string) {
+	if r.Unsupported == nil {
+		r.Unsupported = map[string]string{}
+	}
+	r.Unsupported[name] = reason
+}
+
+// precheck returns why s cannot work in this room based on the topology, or "".
+func (r *htRoom) precheck(s sonos.HTSetting) string {
+	if r.Bond.Supports(s) {
+		return ""
+	}
+	if s.Needs == "sub" {
+		return "no sub bonded"
+	}
+	return "no surrounds bonded"
+}
+
+func newHTGetCmd(flags *rootFlags) *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:          "get",
+		Short:        "Show home theater settings",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			members, err := htTargets(ctx, flags, all)
+			if err != nil {
+				return err
+			}
+			rooms := make([]htRoom, 0, len(members))
+			for _, m := range members {
+				r := newHTRoom(m)
+				c := newHTClient(m.IP, flags.Timeout)
+				for _, s := range sonos.HTSettings() {
+					if reason := r.precheck(s); reason != "" {
+						r.unsupported(s.Name, reason)
+						continue
+					}
+					v, err := c.GetEQ(ctx, s.EQType)
+					if errors.Is(err, sonos.ErrEQNotSupported) {
+						r.unsupported(s.Name, "not supported by this speaker")
+						continue
+					}
+					if err != nil {
+						return fmt.Errorf("%s (%s): %w", m.Name, m.IP, err)
+					}
+					r.Settings[s.Name] = htValue(s, v)
+				}
+				rooms = append(rooms, r)
+			}
+
+			if isJSON(flags) {
+				return writeJSON(cmd, map[string]any{"rooms": rooms})
+			}
+			if isTSV(flags) {
+				for _, r := range rooms {
+					for _, s := range sonos.HTSettings() {
+						_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\n", r.Room, s.Name, htDisplay(r, s))
+					}
+				}
+				return nil
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "ROOM\tSETTING\tVALUE")
+			for _, r := range rooms {
+				for _, s := range sonos.HTSettings() {
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.Room, s.Name, htDisplay(r, s))
+				}
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Show every room with a home theater bond (soundbar + sub/surrounds)")
+	return cmd
+}
+
+func newHTSetCmd(flags *rootFlags) *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "set <setting=value>...",
+		Short: "Change home theater settings",
+		Long: "Sets night=<on|off>, dialog=<on|off>, sub=<on|off>, sub-gain=<-15..15>, surround=<on|off>, " +
+			"surround-level=<-15..15>, music-surround-level=<-15..15> and height-level=<-10..10>. " +
+			"Settings a room does not support are reported per room and skipped; the command fails only if nothing could be applied.",
+		Example:      "  sonos ht set --name \"Living Room\" night=on dialog=on\n  sonos ht set --all sub-gain=-3",
+		SilenceUsage: true,
+		Args:         cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			changes, err := parseHTChanges(args)
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			members, err := htTargets(ctx, flags, all)
+			if err != nil {
+				return err
+			}
+
+			applied := 0
+			rooms := make([]htRoom, 0, len(members))
+			for _, m := range members {
+				r := newHTRoom(m)
+				c := newHTClient(m.IP, flags.Timeout)
+				for _, ch := range changes {
+					if reason := r.precheck(ch.setting); reason != "" {
+						r.unsupported(ch.setting.Name, reason)
+						continue
+					}
+					err := c.SetEQ(ctx, ch.setting.EQType, ch.value)
+					if errors.Is(err, sonos.ErrEQNotSupported) {
+						r.unsupported(ch.setting.Name, "not supported by this speaker")
+						continue
+					}
+					if err != nil {
+						return fmt.Errorf("%s (%s): %w", m.Name, m.IP, err)
+					}
+					r.Settings[ch.setting.Name] = htValue(ch.setting, ch.value)
+					applied++
+				}
+				rooms = append(rooms, r)
+			}
+
+			if !isJSON(flags) {
+				for _, r := range rooms {
+					var parts []string
+					for _, ch := range changes {
+						if reason, ok := r.Unsupported[ch.setting.Name]; ok {
+							parts = append(parts, fmt.Sprintf("%s unsupported (%s)", ch.setting.Name, reason))
+						} else {
+							parts = append(parts, ch.setting.Name+"="+htDisplay(r, ch.setting))
+						}
+					}
+					writePlainLine(cmd, flags, r.Room+": "+strings.Join(parts, ", "))
+				}
+			}
+			if err := writeOK(cmd, flags, "ht.set", map[string]any{"ok": applied > 0, "rooms": rooms}); err != nil {
+				return err
+			}
+			if applied == 0 {
+				return errors.New("none of the settings are supported by the target room(s)")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Apply to every room with a home theater bond")
+	return cmd
+}
+
+type htChange struct {
+	setting sonos.HTSetting
+	value   int
+}
+
+func parseHTChanges(args []string) ([]htChange, error) {
+	var out []htChange
+	for _, arg := range args {
+		key, val, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected setting=value: %q", arg)
+		}
+		s, ok := sonos.LookupHTSetting(key)
+		if !ok {
+			return nil, fmt.Errorf("unknown home theater setting %q", key)
+		}
+		var v int
+		if s.Bool {
+			on, err := parseOnOff(val)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", s.Name, err)
+			}
+			if on {
+				v = 1
+			}
+		} else {
+			n, err := strconv.Atoi(strings.TrimSpace(val))
+			if err != nil || n < s.Min || n > s.Max {
+				return nil, fmt.Errorf("%s must be between %d and %d: %q", s.Name, s.Min, s.Max, val)
+			}
+			v = n
+		}
+		out = append(out, htChange{setting: s, value: v})
+	}
+	return out, nil
+}
+
+func newHTRoom(m sonos.Member) htRoom {
+	bond, bonded := m.HomeTheater()
+	return htRoom{Room: m.Name, IP: m.IP, Bonded: bonded, Bond: bond, Settings: map[string]any{}}
+}
+
+func htValue(s sonos.HTSetting, v int) any {
+	if s.Bool {
+		return v != 0
+	}
+	return v
+}
+
+func htDisplay(r htRoom, s sonos.HTSetting) string {
+	if _, ok := r.Unsupported[s.Name]; ok {
+		return "unsupported"
+	}
+	switch v := r.Settings[s.Name].(type) {
+	case bool:
+		return onOff(v)
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return ""
+	}
+}
+
+// htTargets resolves the target speaker, or with all every visible room that
+// is the primary of a home theater bond.
+func htTargets(ctx context.Context, flags *rootFlags, all bool) ([]sonos.Member, error) {
+	if !all {
+		if err := validateTarget(flags); err != nil {
+			return nil, err
+		}
+	}
+	tg, err := newTopologyGetter(ctx, flags.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	top, err := tg.GetTopology(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !all {
+		target, err := resolveMember(top, flags.Name, flags.IP)
+		if err != nil {
+			return nil, err
+		}
+		return []sonos.Member{target}, nil
+	}
+	var members []sonos.Member
+	for _, g := range top.Groups {
+		for _, m := range g.Members {
+			if _, ok := m.HomeTheater(); ok && m.IsVisible {
+				members = append(members, m)
+			}
+		}
+	}
+	if len(members) == 0 {
+		return nil, errors.New("no rooms with a home theater bond found")
+	}
+	sort.SliceStable(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+	return members, nil
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakeHTClient struct {
+	values      map[string]int
+	unsupported map[string]bool
+	sets        []string
+}
+
+func (f *fakeHTClient) GetEQ(ctx context.Context, eqType string) (int, error) {
+	if f.unsupported[eqType] {
+		return 0, fmt.Errorf("%s: %w", eqType, sonos.ErrEQNotSupported)
+	}
+	return f.values[eqType], nil
+}
+
+func (f *fakeHTClient) SetEQ(ctx context.Context, eqType string, value int) error {
+	if f.unsupported[eqType] {
+		return fmt.Errorf("%s: %w", eqType, sonos.ErrEQNotSupported)
+	}
+	f.sets = append(f.sets, fmt.Sprintf("%s=%d", eqType, value))
+	return nil
+}
+
+func htTestTopology() sonos.Topology {
+	arc := sonos.Member{Name: "Living Room", IP: "192.168.1.30", UUID: "RINCON_ARC1400", IsVisible: true, IsCoordinator: true,
+		HTSatChanMapSet: "RINCON_ARC1400:LF,RF;RINCON_SUB1400:SW"}
+	k := sonos.Member{Name: "Kitchen", IP: "192.168.1.11", UUID: "RINCON_K1400", IsVisible: true, IsCoordinator: true}
+	return sonos.Topology{
+		Groups: []sonos.Group{
+			{ID: "RINCON_ARC1400:1", Coordinator: arc, Members: []sonos.Member{arc}},
+			{ID: "RINCON_K1400:1", Coordinator: k, Members: []sonos.Member{k}},
+		},
+		ByName: map[string]sonos.Member{"Living Room": arc, "Kitchen": k},
+		ByIP:   map[string]sonos.Member{arc.IP: arc, k.IP: k},
+	}
+}
+
+func runHTCmd(t *testing.T, flags *rootFlags, clients map[string]*fakeHTClient, args ...string) (string, error) {
+	t.Helper()
+	origTG, origHT := newTopologyGetter, newHTClient
+	t.Cleanup(func() {
+		newTopologyGetter = origTG
+		newHTClient = origHT
+	})
+	newTopologyGetter = func(ctx context.Context, timeout time.Duration) (topologyGetter, error) {
+		return &fakeTopologyGetter{top: htTestTopology()}, nil
+	}
+	newHTClient = func(ip string, timeout time.Duration) htClient {
+		c, ok := clients[ip]
+		if !ok {
+			t.Fatalf("unexpected speaker ip: %s", ip)
+		}
+		return c
+	}
+
+	cmd := newHTCmd(flags)
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(newDiscardWriter())
+	cmd.SetArgs(args)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestHTGetAllReportsUnsupportedPerRoom(t *testing.T) {
+	arc := &fakeHTClient{
+		values:      map[string]int{"NightMode": 1, "SubGain": -3},
+		unsupported: map[string]bool{"HeightChannelLevel": true},
+	}
+	out, err := runHTCmd(t, &rootFlags{Timeout: time.Second, Format: formatTSV}, map[string]*fakeHTClient{"192.168.1.30": arc}, "get", "--all")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"Living Room\tnight\ton\n",
+		"Living Room\tdialog\toff\n",
+		"Living Room\tsub-gain\t-3\n",
+		"Living Room\tsurround\tunsupported\n",
+		"Living Room\theight-level\tunsupported\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Kitchen") {
+		t.Fatalf("kitchen has no home theater bond:\n%s", out)
+	}
+
+	out, err = runHTCmd(t, &rootFlags{Name: "Living Room", Timeout: time.Second, Format: formatJSON}, map[string]*fakeHTClient{"192.168.1.30": arc}, "get")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, `"surround-level": "no surrounds bonded"`) || !strings.Contains(out, `"height-level": "not supported by this speaker"`) {
+		t.Fatalf("unexpected json: %s", out)
+	}
+}
+
+func TestHTSetSkipsUnsupported(t *testing.T) {
+	arc := &fakeHTClient{}
+	out, err := runHTCmd(t, &rootFlags{Name: "Living Room", Timeout: time.Second}, map[string]*fakeHTClient{"192.168.1.30": arc},
+		"set", "night=on", "sub-gain=4", "surround-level=2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(arc.sets, " ") != "NightMode=1 SubGain=4" {
+		t.Fatalf("unexpected sets: %v", arc.sets)
+	}
+	if !strings.Contains(out, "Living Room: night=on, sub-gain=4, surround-level unsupported (no surrounds bonded)") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	kitchen := &fakeHTClient{unsupported: map[string]bool{"NightMode": true}}
+	if _, err := runHTCmd(t, &rootFlags{Name: "Kitchen", Timeout: time.Second}, map[string]*fakeHTClient{"192.168.1.11": kitchen}, "set", "night=on", "sub=on"); err == nil {
+		t.Fatalf("expected error when nothing is supported")
+	}
+	if _, err := runHTCmd(t, &rootFlags{Name: "Kitchen", Timeout: time.Second}, nil, "set", "sub-gain=20"); err == nil {
+		t.Fatalf("expected range error")
+	}
+}
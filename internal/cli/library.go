@@ -0,0 +1,233 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type libraryClient interface {
+	BrowseLibrary(ctx context.Context, objectID string, start, count int) (sonos.LibraryPage, error)
+	SearchLibrary(ctx context.Context, category, term string, start, count int) (sonos.LibraryPage, error)
+}
+
+type libraryEnqueuer interface {
+	EnqueueLibraryItem(ctx context.Context, item sonos.LibraryItem, opts sonos.EnqueueOptions) (int, error)
+}
+
+var newLibraryClient = func(ctx context.Context, flags *rootFlags) (libraryClient, error) {
+	return anySpeakerClient(ctx, flags)
+}
+
+var newLibraryEnqueuer = func(ctx context.Context, flags *rootFlags) (libraryEnqueuer, error) {
+	return coordinatorClient(ctx, flags)
+}
+
+func newLibraryCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "library",
+		Short: "Browse and search the local music library",
+		Long:  "Browses the music library indexed from your shares (ContentDirectory A: containers) and plays or enqueues items from it.",
+	}
+	for _, c := range []struct{ category, short string }{
+		{"artists", "List artists"},
+		{"albums", "List albums"},
+		{"tracks", "List tracks"},
+		{"genres", "List genres"},
+		{"composers", "List composers"},
+		{"playlists", "List imported playlists (M3U etc. on your shares)"},
+	} {
+		cmd.AddCommand(newLibraryCategoryCmd(flags, c.category, c.short))
+	}
+	cmd.AddCommand(newLibrarySharesCmd(flags))
+	cmd.AddCommand(newLibraryBrowseCmd(flags))
+	cmd.AddCommand(newLibrarySearchCmd(flags))
+	cmd.AddCommand(newLibraryShareCmd(flags))
+	cmd.AddCommand(newLibraryReindexCmd(flags))
+	return cmd
+}
+
+// libraryListFlags are shared by all listing commands: paging plus the same
+// --open/--enqueue/--index selection as `sonos smapi search`.
+type libraryListFlags struct {
+	start     int
+	limit     int
+	doOpen    bool
+	doEnqueue bool
+	index     int
+}
+
+func (lf *libraryListFlags) register(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&lf.start, "start", 0, "Starting index (0-based)")
+	cmd.Flags().IntVar(&lf.limit, "limit", 100, "Max results to return (0 = all)")
+	cmd.Flags().BoolVar(&lf.doOpen, "open", false, "Play the selected result on Sonos (requires --name/--ip)")
+	cmd.Flags().BoolVar(&lf.doEnqueue, "enqueue", false, "Enqueue the selected result on Sonos (requires --name/--ip)")
+	cmd.Flags().IntVar(&lf.index, "index", 1, "Which result to use with --open/--enqueue (1-based)")
+}
+
+func (lf *libraryListFlags) validate(flags *rootFlags) error {
+	if lf.doOpen && lf.doEnqueue {
+		return errors.New("use only one of --open or --enqueue")
+	}
+	if (lf.doOpen || lf.doEnqueue) && flags.IP == "" && flags.Name == "" {
+		return errors.New("--open/--enqueue require --ip or --name")
+	}
+	if lf.index <= 0 {
+		lf.index = 1
+	}
+	return nil
+}
+
+func newLibraryCategoryCmd(flags *rootFlags, category, short string) *cobra.Command {
+	var lf libraryListFlags
+
+	cmd := &cobra.Command{
+		Use:          category,
+		Short:        short,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := sonos.LibraryContainerID(category)
+			if err != nil {
+				return err
+			}
+			return runLibraryList(cmd, flags, &lf, func(ctx context.Context, c libraryClient) (sonos.LibraryPage, error) {
+				return c.BrowseLibrary(ctx, id, lf.start, lf.limit)
+			})
+		},
+	}
+	lf.register(cmd)
+	return cmd
+}
+
+func newLibraryBrowseCmd(flags *rootFlags) *cobra.Command {
+	var lf libraryListFlags
+
+	cmd := &cobra.Command{
+		Use:          "browse <object-id>",
+		Short:        "List the children of a library object",
+		Long:         "Lists the children of any library object, using the IDs printed by the other library commands (e.g. `A:ARTIST/Miles Davis`, `A:ALBUM/Kind of Blue`, `S://nas/music`).",
+		Example:      "  sonos library browse \"A:ARTIST/Miles Davis\"\n  sonos library browse --name Kitchen --open --index 1 \"A:ALBUMARTIST/Miles Davis\"",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := strings.TrimSpace(args[0])
+			if id == "" {
+				return errors.New("object id is required")
+			}
+			return runLibraryList(cmd, flags, &lf, func(ctx context.Context, c libraryClient) (sonos.LibraryPage, error) {
+				return c.BrowseLibrary(ctx, id, lf.start, lf.limit)
+			})
+		},
+	}
+	lf.register(cmd)
+	return cmd
+}
+
+func newLibrarySearchCmd(flags *rootFlags) *cobra.Command {
+	var lf libraryListFlags
+	var category string
+
+	cmd := &cobra.Command{
+		Use:          "search <term>",
+		Short:        "Search the local music library",
+		Long:         "Searches one library category (Browse with `A:TRACKS:<term>`, `A:ARTIST:<term>`, ...).",
+		Example:      "  sonos library search \"blue in green\"\n  sonos library search --category albums --name Kitchen --open \"kind of blue\"",
+		SilenceUsage: true,
+		Args:         cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			term := strings.TrimSpace(strings.Join(args, " "))
+			return runLibraryList(cmd, flags, &lf, func(ctx context.Context, c libraryClient) (sonos.LibraryPage, error) {
+				return c.SearchLibrary(ctx, category, term, lf.start, lf.limit)
+			})
+		},
+	}
+	lf.register(cmd)
+	cmd.Flags().StringVar(&category, "category", "tracks", "Search category: tracks|albums|artists|albumartists|genres|composers|playlists")
+	return cmd
+}
+
+func runLibraryList(cmd *cobra.Command, flags *rootFlags, lf *libraryListFlags, fetch func(context.Context, libraryClient) (sonos.LibraryPage, error)) error {
+	if err := lf.validate(flags); err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	c, err := newLibraryClient(ctx, flags)
+	if err != nil {
+		return err
+	}
+	page, err := fetch(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	if lf.doOpen || lf.doEnqueue {
+		if len(page.Items) == 0 {
+			return errors.New("no results")
+		}
+		if lf.index > len(page.Items) {
+			return fmt.Errorf("--index %d out of range (got %d results)", lf.index, len(page.Items))
+		}
+		selected := page.Items[lf.index-1]
+		e, err := newLibraryEnqueuer(ctx, flags)
+		if err != nil {
+			return err
+		}
+		first, err := e.EnqueueLibraryItem(ctx, selected, sonos.EnqueueOptions{PlayNow: lf.doOpen})
+		if err != nil {
+			return err
+		}
+		if isJSON(flags) {
+			return writeJSON(cmd, map[string]any{
+				"result":   page,
+				"selected": selected,
+				"action": map[string]any{
+					"enqueue":  true,
+					"playNow":  lf.doOpen,
+					"position": first,
+				},
+			})
+		}
+		verb := "Enqueued"
+		if lf.doOpen {
+			verb = "Playing"
+		}
+		writePlainLine(cmd, flags, fmt.Sprintf("%s %s", verb, selected.Item.Title))
+		return nil
+	}
+
+	if isJSON(flags) {
+		return writeJSON(cmd, page)
+	}
+	if isTSV(flags) {
+		for i, it := range page.Items {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%d\t%s\t%s\t%s\t%s\n", i+1, libraryItemType(it.Item), it.Item.Title, it.Item.Artist, it.Item.ID)
+		}
+		return nil
+	}
+	if len(page.Items) == 0 {
+		writePlainLine(cmd, flags, "No results.")
+		return nil
+	}
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "INDEX\tTYPE\tTITLE\tARTIST\tID")
+	for i, it := range page.Items {
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", i+1, libraryItemType(it.Item), it.Item.Title, it.Item.Artist, it.Item.ID)
+	}
+	return w.Flush()
+}
+
+// libraryItemType shortens a UPnP class (object.container.album.musicAlbum)
+// to its last segment (musicAlbum).
+func libraryItemType(it sonos.DIDLItem) string {
+	class := it.Class
+	if i := strings.LastIndexByte(class, '.'); i >= 0 {
+		class = class[i+1:]
+	}
+	return class
+}
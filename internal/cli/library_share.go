@@ -0,0 +1,284 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type libraryShareClient interface {
+	BrowseLibrary(ctx context.Context, objectID string, start, count int) (sonos.LibraryPage, error)
+	RefreshShareIndex(ctx context.Context, albumArtistDisplayOption string) error
+	ShareIndexInProgress(ctx context.Context) (bool, error)
+	AddShare(ctx context.Context, path string) (string, error)
+	RemoveShare(ctx context.Context, id string) error
+}
+
+var newLibraryShareClient = func(ctx context.Context, flags *rootFlags) (libraryShareClient, error) {
+	return anySpeakerClient(ctx, flags)
+}
+
+// reindexPollInterval is how often `library reindex --wait` checks
+// GetShareIndexInProgress.
+var reindexPollInterval = 2 * time.Second
+
+// reindexStartGrace is how many polls may report "not indexing" right after
+// RefreshShareIndex before we assume the (quick) rescan already finished.
+const reindexStartGrace = 3
+
+func newLibraryShareCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "share",
+		Short: "List, add and remove music library shares",
+		Long:  "Manages the music library shares (ContentDirectory S:), i.e. the network folders the speakers index.",
+	}
+	cmd.AddCommand(newLibraryShareListCmd(flags))
+	cmd.AddCommand(newLibraryShareAddCmd(flags))
+	cmd.AddCommand(newLibraryShareRemoveCmd(flags))
+	return cmd
+}
+
+// newLibrarySharesCmd is `library shares`, the same command as `library share list`.
+func newLibrarySharesCmd(flags *rootFlags) *cobra.Command {
+	cmd := newLibraryShareListCmd(flags)
+	cmd.Use = "shares"
+	cmd.Short = "List music library shares (same as `library share list`)"
+	return cmd
+}
+
+func newLibraryShareListCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List music library shares",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := newLibraryShareClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			page, err := c.BrowseLibrary(ctx, "S:", 0, 0)
+			if err != nil {
+				return err
+			}
+			indexing, err := c.ShareIndexInProgress(ctx)
+			if err != nil {
+				return err
+			}
+
+			type shareOutput struct {
+				Title string `json:"title"`
+				ID    string `json:"id"`
+			}
+			shares := make([]shareOutput, 0, len(page.Items))
+			for _, it := range page.Items {
+				shares = append(shares, shareOutput{Title: it.Item.Title, ID: it.Item.ID})
+			}
+			if isJSON(flags) {
+				return writeJSON(cmd, map[string]any{"shares": shares, "indexing": indexing})
+			}
+			if isTSV(flags) {
+				for _, s := range shares {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", s.Title, s.ID)
+				}
+				return nil
+			}
+			if len(shares) == 0 {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No music library shares.")
+			} else {
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+				_, _ = fmt.Fprintln(w, "SHARE\tID")
+				for _, s := range shares {
+					_, _ = fmt.Fprintf(w, "%s\t%s\n", s.Title, s.ID)
+				}
+				if err := w.Flush(); err != nil {
+					return err
+				}
+			}
+			if indexing {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Indexing in progress.")
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+func newLibraryShareAddCmd(flags *rootFlags) *cobra.Command {
+	var reindex bool
+
+	cmd := &cobra.Command{
+		Use:   "add <//host/share>",
+		Short: "Add a music library share",
+		Long: "Adds a network share to the music library (ContentDirectory CreateObject in S:). " +
+			"Accepts //host/share, \\\\host\\share or smb://host/share; the share must be readable by the speakers without a password. " +
+			"With --reindex, starts a rescan right away.",
+		Example:      "  sonos library share add //nas/music\n  sonos library share add --reindex smb://nas/music",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := newLibraryShareClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			id, err := c.AddShare(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			if reindex {
+				if err := c.RefreshShareIndex(ctx, ""); err != nil {
+					return err
+				}
+			}
+			writePlainLine(cmd, flags, fmt.Sprintf("Added share %s.", id))
+			return writeOK(cmd, flags, "library.share.add", map[string]any{"id": id, "reindex": reindex})
+		},
+	}
+	cmd.Flags().BoolVar(&reindex, "reindex", false, "Rescan the music library after adding the share")
+	return cmd
+}
+
+func newLibraryShareRemoveCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "remove <share>",
+		Short:        "Remove a music library share",
+		Long:         "Removes a share from the music library (ContentDirectory DestroyObject). <share> is the ID (S://nas/music) or the full path (//nas/music) as shown by `library share list`.",
+		Example:      "  sonos library share remove //nas/music",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := newLibraryShareClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			page, err := c.BrowseLibrary(ctx, "S:", 0, 0)
+			if err != nil {
+				return err
+			}
+			ref := strings.TrimSpace(args[0])
+			var share *sonos.DIDLItem
+			for i := range page.Items {
+				it := page.Items[i].Item
+				if it.ID == ref || strings.EqualFold(it.Title, ref) {
+					share = &page.Items[i].Item
+					break
+				}
+			}
+			if share == nil {
+				return errors.New("share not found: " + ref)
+			}
+			if err := c.RemoveShare(ctx, share.ID); err != nil {
+				return err
+			}
+			writePlainLine(cmd, flags, fmt.Sprintf("Removed share %s.", share.Title))
+			return writeOK(cmd, flags, "library.share.remove", map[string]any{"id": share.ID, "title": share.Title})
+		},
+	}
+	return cmd
+}
+
+func newLibraryReindexCmd(flags *rootFlags) *cobra.Command {
+	var wait bool
+	var maxWait time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "reindex",
+		Short: "Rescan the music library shares",
+		Long: "Starts a rescan of all music library shares (ContentDirectory RefreshShareIndex). " +
+			"With --wait, polls GetShareIndexInProgress until indexing finishes; with --format json, each poll is printed as one JSON line " +
+			`({"event":"started"|"progress"|"finished", ...}).`,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			c, err := newLibraryShareClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			if err := c.RefreshShareIndex(ctx, ""); err != nil {
+				return err
+			}
+			if !wait {
+				writePlainLine(cmd, flags, "Music library reindex started.")
+				return writeOK(cmd, flags, "library.reindex", map[string]any{"started": true})
+			}
+
+			started := time.Now()
+			emit := func(event string, indexing bool) {
+				elapsed := time.Since(started).Round(time.Second)
+				if isJSON(flags) {
+					_ = writeJSONLine(cmd, map[string]any{
+						"event":          event,
+						"indexing":       indexing,
+						"elapsedSeconds": int(elapsed.Seconds()),
+					})
+					return
+				}
+				switch event {
+				case "started":
+					writePlainLine(cmd, flags, "Music library reindex started; waiting…")
+				case "progress":
+					writePlainLine(cmd, flags, fmt.Sprintf("Indexing… (%s)", elapsed))
+				case "finished":
+					writePlainLine(cmd, flags, fmt.Sprintf("Indexing finished after %s.", elapsed))
+				}
+			}
+			emit("started", true)
+
+			var deadline <-chan time.Time
+			if maxWait > 0 {
+				timer := time.NewTimer(maxWait)
+				defer timer.Stop()
+				deadline = timer.C
+			}
+			ticker := time.NewTicker(reindexPollInterval)
+			defer ticker.Stop()
+
+			seenIndexing := false
+			idlePolls := 0
+			for {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-deadline:
+					return fmt.Errorf("indexing still in progress after %s", maxWait)
+				case <-ticker.C:
+				}
+				indexing, err := c.ShareIndexInProgress(ctx)
+				if err != nil {
+					if errors.Is(ctx.Err(), context.Canceled) {
+						return ctx.Err()
+					}
+					return err
+				}
+				if indexing {
+					seenIndexing = true
+					emit("progress", true)
+					continue
+				}
+				idlePolls++
+				if seenIndexing || idlePolls >= reindexStartGrace {
+					emit("finished", false)
+					return nil
+				}
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until indexing has finished")
+	cmd.Flags().DurationVar(&maxWait, "max-wait", time.Hour, "Give up waiting after this long (0 = no limit)")
+	return cmd
+}
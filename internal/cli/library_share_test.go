@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakeLibraryShareClient struct {
+	shares    sonos.LibraryPage
+	polls     []bool
+	pollCalls int
+	refreshed int
+	added     []string
+	removed   []string
+}
+
+func (f *fakeLibraryShareClient) BrowseLibrary(ctx context.Context, objectID string, start, count int) (sonos.LibraryPage, error) {
+	return f.shares, nil
+}
+
+func (f *fakeLibraryShareClient) RefreshShareIndex(ctx context.Context, albumArtistDisplayOption string) error {
+	f.refreshed++
+	return nil
+}
+
+func (f *fakeLibraryShareClient) ShareIndexInProgress(ctx context.Context) (bool, error) {
+	f.pollCalls++
+	if len(f.polls) == 0 {
+		return false, nil
+	}
+	v := f.polls[0]
+	f.polls = f.polls[1:]
+	return v, nil
+}
+
+func (f *fakeLibraryShareClient) AddShare(ctx context.Context, path string) (string, error) {
+	f.added = append(f.added, path)
+	return "S:" + path, nil
+}
+
+func (f *fakeLibraryShareClient) RemoveShare(ctx context.Context, id string) error {
+	f.removed = append(f.removed, id)
+	return nil
+}
+
+func withFakeLibraryShareClient(t *testing.T, fc *fakeLibraryShareClient) {
+	t.Helper()
+	orig, origPoll := newLibraryShareClient, reindexPollInterval
+	t.Cleanup(func() { newLibraryShareClient, reindexPollInterval = orig, origPoll })
+	newLibraryShareClient = func(ctx context.Context, flags *rootFlags) (libraryShareClient, error) { return fc, nil }
+	reindexPollInterval = time.Millisecond
+}
+
+func TestLibraryShareList(t *testing.T) {
+	fc := &fakeLibraryShareClient{
+		shares: sonos.LibraryPage{Items: []sonos.LibraryItem{{Item: sonos.DIDLItem{ID: "S://nas/music", Title: "//nas/music"}}}},
+		polls:  []bool{true},
+	}
+	withFakeLibraryShareClient(t, fc)
+
+	out, err := runLibraryCmd(t, &rootFlags{Timeout: time.Second}, "share", "list")
+	if err != nil {
+		t.Fatalf("share list: %v", err)
+	}
+	if !strings.Contains(out, "S://nas/music") || !strings.Contains(out, "Indexing in progress.") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestLibrarySharesIsShareList(t *testing.T) {
+	fc := &fakeLibraryShareClient{
+		shares: sonos.LibraryPage{Items: []sonos.LibraryItem{{Item: sonos.DIDLItem{ID: "S://nas/music", Title: "//nas/music"}}}},
+	}
+	withFakeLibraryShareClient(t, fc)
+
+	list, err := runLibraryCmd(t, &rootFlags{Timeout: time.Second}, "share", "list")
+	if err != nil {
+		t.Fatalf("share list: %v", err)
+	}
+	shares, err := runLibraryCmd(t, &rootFlags{Timeout: time.Second}, "shares")
+	if err != nil {
+		t.Fatalf("shares: %v", err)
+	}
+	if shares != list {
+		t.Fatalf("outputs differ:\n%s\n%s", list, shares)
+	}
+}
+
+func TestLibraryShareAddAndRemove(t *testing.T) {
+	fc := &fakeLibraryShareClient{
+		shares: sonos.LibraryPage{Items: []sonos.LibraryItem{
+			{Item: sonos.DIDLItem{ID: "S://nas/music", Title: "//nas/music"}},
+			{Item: sonos.DIDLItem{ID: "S://nas/music-old", Title: "//nas/music-old"}},
+		}},
+	}
+	withFakeLibraryShareClient(t, fc)
+
+	out, err := runLibraryCmd(t, &rootFlags{Timeout: time.Second, Format: formatJSON}, "share", "add", "--reindex", "//nas/audio")
+	if err != nil {
+		t.Fatalf("share add: %v", err)
+	}
+	if len(fc.added) != 1 || fc.added[0] != "//nas/audio" || fc.refreshed != 1 || !strings.Contains(out, `"id": "S://nas/audio"`) {
+		t.Fatalf("added=%v refreshed=%d out=%s", fc.added, fc.refreshed, out)
+	}
+
+	if _, err := runLibraryCmd(t, &rootFlags{Timeout: time.Second}, "share", "remove", "//NAS/music"); err != nil {
+		t.Fatalf("share remove: %v", err)
+	}
+	if len(fc.removed) != 1 || fc.removed[0] != "S://nas/music" {
+		t.Fatalf("removed=%v", fc.removed)
+	}
+	// Partial paths never select a share to remove.
+	if _, err := runLibraryCmd(t, &rootFlags{Timeout: time.Second}, "share", "remove", "music"); err == nil {
+		t.Fatalf("expected error for partial path")
+	}
+	if len(fc.removed) != 1 {
+		t.Fatalf("removed=%v", fc.removed)
+	}
+}
+
+func TestLibraryReindexWaitJSONProgress(t *testing.T) {
+	fc := &fakeLibraryShareClient{polls: []bool{false, true, true, false}}
+	withFakeLibraryShareClient(t, fc)
+
+	out, err := runLibraryCmd(t, &rootFlags{Timeout: time.Second, Format: formatJSON}, "reindex", "--wait")
+	if err != nil {
+		t.Fatalf("reindex: %v", err)
+	}
+	var events []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var ev struct {
+			Event string `json:"event"`
+		}
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("line %q: %v", line, err)
+		}
+		events = append(events, ev.Event)
+	}
+	if strings.Join(events, ",") != "started,progress,progress,finished" || fc.refreshed != 1 {
+		t.Fatalf("events=%v refreshed=%d", events, fc.refreshed)
+	}
+}
+
+func TestLibraryReindexWaitQuickRescan(t *testing.T) {
+	fc := &fakeLibraryShareClient{}
+	withFakeLibraryShareClient(t, fc)
+
+	out, err := runLibraryCmd(t, &rootFlags{Timeout: time.Second}, "reindex", "--wait")
+	if err != nil {
+		t.Fatalf("reindex: %v", err)
+	}
+	if fc.pollCalls != reindexStartGrace || !strings.Contains(out, "Indexing finished") {
+		t.Fatalf("polls=%d out=%q", fc.pollCalls, out)
+	}
+}
+
+func TestLibraryReindexNoWait(t *testing.T) {
+	fc := &fakeLibraryShareClient{}
+	withFakeLibraryShareClient(t, fc)
+
+	if _, err := runLibraryCmd(t, &rootFlags{Timeout: time.Second}, "reindex"); err != nil {
+		t.Fatalf("reindex: %v", err)
+	}
+	if fc.refreshed != 1 || fc.pollCalls != 0 {
+		t.Fatalf("refreshed=%d polls=%d", fc.refreshed, fc.pollCalls)
+	}
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakeLibraryClient struct {
+	page        sonos.LibraryPage
+	browsedID   string
+	searchCat   string
+	searchTerm  string
+	limit       int
+	enqueued    []sonos.LibraryItem
+	enqueueOpts sonos.EnqueueOptions
+}
+
+func (f *fakeLibraryClient) BrowseLibrary(ctx context.Context, objectID string, start, count int) (sonos.LibraryPage, error) {
+	f.browsedID = objectID
+	f.limit = count
+	return f.page, nil
+}
+
+func (f *fakeLibraryClient) SearchLibrary(ctx context.Context, category, term string, start, count int) (sonos.LibraryPage, error) {
+	f.searchCat, f.searchTerm = category, term
+	return f.page, nil
+}
+
+func (f *fakeLibraryClient) EnqueueLibraryItem(ctx context.Context, item sonos.LibraryItem, opts sonos.EnqueueOptions) (int, error) {
+	f.enqueued = append(f.enqueued, item)
+	f.enqueueOpts = opts
+	return 3, nil
+}
+
+func withFakeLibrary(t *testing.T, fc *fakeLibraryClient) {
+	t.Helper()
+	origC, origE := newLibraryClient, newLibraryEnqueuer
+	t.Cleanup(func() { newLibraryClient, newLibraryEnqueuer = origC, origE })
+	newLibraryClient = func(ctx context.Context, flags *rootFlags) (libraryClient, error) { return fc, nil }
+	newLibraryEnqueuer = func(ctx context.Context, flags *rootFlags) (libraryEnqueuer, error) { return fc, nil }
+}
+
+func runLibraryCmd(t *testing.T, flags *rootFlags, args ...string) (string, error) {
+	t.Helper()
+	cmd := newLibraryCmd(flags)
+	cmd.SetArgs(args)
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+var testLibraryPage = sonos.LibraryPage{Items: []sonos.LibraryItem{
+	{Item: sonos.DIDLItem{ID: "A:ALBUM/Kind of Blue", Title: "Kind of Blue", Artist: "Miles Davis", Class: "object.container.album.musicAlbum", URI: "x-rincon-playlist:RINCON_A1400#A:ALBUM/Kind%20of%20Blue"}},
+	{Item: sonos.DIDLItem{ID: "A:ALBUM/Blue Train", Title: "Blue Train", Artist: "John Coltrane", Class: "object.container.album.musicAlbum"}},
+}}
+
+func TestLibraryCategoryListsContainer(t *testing.T) {
+	fc := &fakeLibraryClient{page: testLibraryPage}
+	withFakeLibrary(t, fc)
+
+	out, err := runLibraryCmd(t, &rootFlags{Timeout: time.Second}, "albums", "--limit", "0")
+	if err != nil {
+		t.Fatalf("albums: %v", err)
+	}
+	if fc.browsedID != "A:ALBUM" || fc.limit != 0 {
+		t.Fatalf("browsed %q limit %d", fc.browsedID, fc.limit)
+	}
+	if !strings.Contains(out, "musicAlbum") || !strings.Contains(out, "Kind of Blue") || !strings.Contains(out, "A:ALBUM/Blue Train") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+}
+
+func TestLibrarySearchOpen(t *testing.T) {
+	fc := &fakeLibraryClient{page: testLibraryPage}
+	withFakeLibrary(t, fc)
+
+	out, err := runLibraryCmd(t, &rootFlags{Name: "Kitchen", Timeout: time.Second}, "search", "--category", "albums", "--open", "kind", "of", "blue")
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if fc.searchCat != "albums" || fc.searchTerm != "kind of blue" {
+		t.Fatalf("search %q %q", fc.searchCat, fc.searchTerm)
+	}
+	if len(fc.enqueued) != 1 || fc.enqueued[0].Item.Title != "Kind of Blue" || !fc.enqueueOpts.PlayNow {
+		t.Fatalf("enqueued=%+v opts=%+v", fc.enqueued, fc.enqueueOpts)
+	}
+	if !strings.Contains(out, "Playing Kind of Blue") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestLibraryOpenValidation(t *testing.T) {
+	fc := &fakeLibraryClient{page: testLibraryPage}
+	withFakeLibrary(t, fc)
+
+	if _, err := runLibraryCmd(t, &rootFlags{Timeout: time.Second}, "search", "--enqueue", "blue"); err == nil {
+		t.Fatalf("expected error without target")
+	}
+	if _, err := runLibraryCmd(t, &rootFlags{Name: "Kitchen", Timeout: time.Second}, "browse", "--open", "--enqueue", "A:ALBUM"); err == nil {
+		t.Fatalf("expected error for --open with --enqueue")
+	}
+	if _, err := runLibraryCmd(t, &rootFlags{Name: "Kitchen", Timeout: time.Second}, "browse", "--enqueue", "--index", "3", "A:ALBUM"); err == nil {
+		t.Fatalf("expected index out of range")
+	}
+	if len(fc.enqueued) != 0 {
+		t.Fatalf("unexpected enqueue: %+v", fc.enqueued)
+	}
+}
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type lineInClient interface {
+	GetLineInSettings(ctx context.Context) (sonos.LineInSettings, error)
+	SetAudioInputAttributes(ctx context.Context, name, icon string) error
+	SetLineInLevel(ctx context.Context, left, right int) error
+	SetAutoplayRoomUUID(ctx context.Context, roomUUID string) error
+	SetAutoplayLinkedZones(ctx context.Context, include bool) error
+}
+
+var newLineInClient = func(ip string, timeout time.Duration) lineInClient {
+	return sonos.NewClient(ip, timeout)
+}
+
+type lineInOutput struct {
+	Room string `json:"room"`
+	IP   string `json:"ip"`
+	sonos.LineInSettings
+	AutoplayRoom string `json:"autoplayRoom,omitempty"`
+}
+
+func newLineInSettingsCmd(flags *rootFlags) *cobra.Command {
+	var level int
+	var autoplayRoom, linkedZones, sourceName string
+
+	cmd := &cobra.Command{
+		Use:   "settings",
+		Short: "Show or change line-in settings",
+		Long: "Shows the line-in settings of the speaker that has the line-in port (--name/--ip): source name (AudioIn GetAudioInputAttributes), " +
+			"level (GetLineInLevel), autoplay room and whether autoplay includes grouped rooms (DeviceProperties). " +
+			"Flags change the corresponding setting first; the updated settings are printed afterwards.",
+		Example:      "  sonos linein settings --name \"Living Room\"\n  sonos linein settings --name \"Living Room\" --level 8 --source-name Turntable\n  sonos linein settings --name \"Living Room\" --autoplay-room Kitchen --include-linked-zones on\n  sonos linein settings --name \"Living Room\" --autoplay-room off",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			changed := cmd.Flags().Changed
+			if changed("level") && (level < sonos.MinLineInLevel || level > sonos.MaxLineInLevel) {
+				return fmt.Errorf("--level must be %d-%d", sonos.MinLineInLevel, sonos.MaxLineInLevel)
+			}
+			if changed("source-name") && strings.TrimSpace(sourceName) == "" {
+				return errors.New("--source-name must not be empty")
+			}
+			var includeLinked bool
+			if changed("include-linked-zones") {
+				v, err := parseOnOff(linkedZones)
+				if err != nil {
+					return err
+				}
+				includeLinked = v
+			}
+
+			ctx := cmd.Context()
+			tg, err := newTopologyGetter(ctx, flags.Timeout)
+			if err != nil {
+				return err
+			}
+			top, err := tg.GetTopology(ctx)
+			if err != nil {
+				return err
+			}
+			mem, err := resolveMember(top, flags.Name, flags.IP)
+			if err != nil {
+				return err
+			}
+			autoplayUUID := ""
+			if changed("autoplay-room") && !isOffValue(autoplayRoom) {
+				room, err := resolveMember(top, autoplayRoom, "")
+				if err != nil {
+					return err
+				}
+				autoplayUUID = room.UUID
+			}
+
+			c := newLineInClient(mem.IP, flags.Timeout)
+			cur, err := c.GetLineInSettings(ctx)
+			if err != nil {
+				return fmt.Errorf("%s: %w", mem.Name, err)
+			}
+			updated := false
+			if changed("source-name") {
+				if err := c.SetAudioInputAttributes(ctx, strings.TrimSpace(sourceName), cur.Icon); err != nil {
+					return err
+				}
+				updated = true
+			}
+			if changed("level") {
+				if err := c.SetLineInLevel(ctx, level, level); err != nil {
+					return err
+				}
+				updated = true
+			}
+			if changed("autoplay-room") {
+				if err := c.SetAutoplayRoomUUID(ctx, autoplayUUID); err != nil {
+					return err
+				}
+				updated = true
+			}
+			if changed("include-linked-zones") {
+				if err := c.SetAutoplayLinkedZones(ctx, includeLinked); err != nil {
+					return err
+				}
+				updated = true
+			}
+			if updated {
+				if cur, err = c.GetLineInSettings(ctx); err != nil {
+					return err
+				}
+			}
+
+			out := lineInOutput{Room: mem.Name, IP: mem.IP, LineInSettings: cur}
+			if cur.AutoplayRoomUUID != "" {
+				out.AutoplayRoom = cur.AutoplayRoomUUID
+				for _, m := range top.ByIP {
+					if m.UUID == cur.AutoplayRoomUUID {
+						out.AutoplayRoom = m.Name
+						break
+					}
+				}
+			}
+			return writeLineInSettings(cmd, flags, out)
+		},
+	}
+
+	cmd.Flags().IntVar(&level, "level", 0, fmt.Sprintf("Set the line-in level (%d-%d, both channels)", sonos.MinLineInLevel, sonos.MaxLineInLevel))
+	cmd.Flags().StringVar(&autoplayRoom, "autoplay-room", "", "Room that starts playing line-in when a signal is detected (or off)")
+	cmd.Flags().StringVar(&linkedZones, "include-linked-zones", "", "Autoplay also plays on rooms grouped with the autoplay room: on|off")
+	cmd.Flags().StringVar(&sourceName, "source-name", "", "Displayed line-in source name")
+	return cmd
+}
+
+func isOffValue(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "off", "none":
+		return true
+	default:
+		return false
+	}
+}
+
+func writeLineInSettings(cmd *cobra.Command, flags *rootFlags, out lineInOutput) error {
+	if isJSON(flags) {
+		return writeJSON(cmd, out)
+	}
+	autoplay := out.AutoplayRoom
+	if autoplay == "" {
+		autoplay = "off"
+	}
+	level := strconv.Itoa(out.LeftLevel)
+	if out.LeftLevel != out.RightLevel {
+		level = fmt.Sprintf("L%d/R%d", out.LeftLevel, out.RightLevel)
+	}
+	rows := []struct{ key, label, value string }{
+		{"room", "Room", out.Room},
+		{"sourceName", "Source name", out.SourceName},
+		{"level", "Level", level},
+		{"autoplayRoom", "Autoplay room", autoplay},
+		{"includeLinkedZones", "Include linked zones", onOff(out.AutoplayIncludeLinkedZones)},
+	}
+	if isTSV(flags) {
+		for _, r := range rows {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", r.key, r.value)
+		}
+		return nil
+	}
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+	for _, r := range rows {
+		_, _ = fmt.Fprintf(w, "%s:\t%s\n", r.label, r.value)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakeLineInClient struct {
+	settings sonos.LineInSettings
+	err      error
+	calls    []string
+}
+
+func (f *fakeLineInClient) GetLineInSettings(ctx context.Context) (sonos.LineInSettings, error) {
+	return f.settings, f.err
+}
+
+func (f *fakeLineInClient) SetAudioInputAttributes(ctx context.Context, name, icon string) error {
+	f.calls = append(f.calls, "name="+name+"/"+icon)
+	f.settings.SourceName = name
+	return nil
+}
+
+func (f *fakeLineInClient) SetLineInLevel(ctx context.Context, left, right int) error {
+	f.calls = append(f.calls, "level="+strconv.Itoa(left)+"/"+strconv.Itoa(right))
+	f.settings.LeftLevel, f.settings.RightLevel = left, right
+	return nil
+}
+
+func (f *fakeLineInClient) SetAutoplayRoomUUID(ctx context.Context, roomUUID string) error {
+	f.calls = append(f.calls, "autoplay="+roomUUID)
+	f.settings.AutoplayRoomUUID = roomUUID
+	return nil
+}
+
+func (f *fakeLineInClient) SetAutoplayLinkedZones(ctx context.Context, include bool) error {
+	f.calls = append(f.calls, "linked="+onOff(include))
+	f.settings.AutoplayIncludeLinkedZones = include
+	return nil
+}
+
+func runLineInSettingsCmd(t *testing.T, flags *rootFlags, c *fakeLineInClient, args ...string) (string, error) {
+	t.Helper()
+	origTG, origClient := newTopologyGetter, newLineInClient
+	t.Cleanup(func() {
+		newTopologyGetter = origTG
+		newLineInClient = origClient
+	})
+	newTopologyGetter = func(ctx context.Context, timeout time.Duration) (topologyGetter, error) {
+		return &fakeTopologyGetter{top: eqTestTopology()}, nil
+	}
+	newLineInClient = func(ip string, timeout time.Duration) lineInClient {
+		if ip != "192.168.1.10" {
+			t.Fatalf("unexpected speaker ip: %s", ip)
+		}
+		return c
+	}
+
+	cmd := newLineInCmd(flags)
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(newDiscardWriter())
+	cmd.SetArgs(append([]string{"settings"}, args...))
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestLineInSettingsShow(t *testing.T) {
+	c := &fakeLineInClient{settings: sonos.LineInSettings{SourceName: "Turntable", LeftLevel: 7, RightLevel: 7, AutoplayRoomUUID: "RINCON_K1400"}}
+
+	out, err := runLineInSettingsCmd(t, &rootFlags{Name: "Living Room", Timeout: time.Second, Format: formatTSV}, c)
+	if err != nil {
+		t.Fatalf("settings: %v", err)
+	}
+	want := "room\tLiving Room\nsourceName\tTurntable\nlevel\t7\nautoplayRoom\tKitchen\nincludeLinkedZones\toff\n"
+	if out != want {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+	if len(c.calls) != 0 {
+		t.Fatalf("unexpected set calls: %v", c.calls)
+	}
+}
+
+func TestLineInSettingsSet(t *testing.T) {
+	c := &fakeLineInClient{settings: sonos.LineInSettings{SourceName: "Line-In", Icon: "linein", LeftLevel: 4, RightLevel: 4, AutoplayRoomUUID: "RINCON_K1400"}}
+
+	out, err := runLineInSettingsCmd(t, &rootFlags{Name: "Living Room", Timeout: time.Second, Format: formatJSON}, c,
+		"--level", "8", "--source-name", "Turntable", "--autoplay-room", "off", "--include-linked-zones", "on")
+	if err != nil {
+		t.Fatalf("settings: %v", err)
+	}
+	if want := []string{"name=Turntable/linein", "level=8/8", "autoplay=", "linked=on"}; !reflect.DeepEqual(c.calls, want) {
+		t.Fatalf("calls: %v", c.calls)
+	}
+	for _, want := range []string{`"room": "Living Room"`, `"sourceName": "Turntable"`, `"leftLevel": 8`, `"autoplayIncludeLinkedZones": true`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %s: %s", want, out)
+		}
+	}
+	if strings.Contains(out, "autoplayRoom") {
+		t.Fatalf("autoplay room should be cleared: %s", out)
+	}
+
+	c = &fakeLineInClient{}
+	if _, err := runLineInSettingsCmd(t, &rootFlags{Name: "Living Room", Timeout: time.Second}, c, "--autoplay-room", "Kitchen"); err != nil {
+		t.Fatalf("autoplay room: %v", err)
+	}
+	if want := []string{"autoplay=RINCON_K1400"}; !reflect.DeepEqual(c.calls, want) {
+		t.Fatalf("calls: %v", c.calls)
+	}
+
+	if _, err := runLineInSettingsCmd(t, &rootFlags{Name: "Living Room", Timeout: time.Second}, c, "--level", "11"); err == nil {
+		t.Fatalf("expected level range error")
+	}
+}
+
+func TestLineInSettingsNotSupported(t *testing.T) {
+	c := &fakeLineInClient{err: sonos.ErrLineInNotSupported}
+	_, err := runLineInSettingsCmd(t, &rootFlags{Name: "Living Room", Timeout: time.Second}, c, "--level", "5")
+	if err == nil || !strings.Contains(err.Error(), "Living Room: speaker has no line-in") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(c.calls) != 0 {
+		t.Fatalf("unexpected set calls: %v", c.calls)
+	}
+}
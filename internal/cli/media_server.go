@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+// audioMIMETypes is kept explicit because Go's builtin MIME table lacks most
+// audio types and /etc/mime.types is not always present.
+var audioMIMETypes = map[string]string{
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/mp4",
+	".mp4":  "audio/mp4",
+	".aac":  "audio/aac",
+	".flac": "audio/flac",
+	".wav":  "audio/wav",
+	".ogg":  "audio/ogg",
+	".oga":  "audio/ogg",
+	".aif":  "audio/aiff",
+	".aiff": "audio/aiff",
+	".wma":  "audio/x-ms-wma",
+}
+
+func audioMIMEType(name string) (string, bool) {
+	mt, ok := audioMIMETypes[strings.ToLower(filepath.Ext(name))]
+	return mt, ok
+}
+
+// collectAudioFiles returns the directory to serve and the audio files below it
+// (sorted by path). p may be a single file or a directory, which is walked
+// recursively; hidden entries and symlinks inside it are skipped.
+func collectAudioFiles(p string) (root string, files []string, err error) {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return "", nil, err
+	}
+	st, err := os.Stat(abs)
+	if err != nil {
+		return "", nil, err
+	}
+	if !st.IsDir() {
+		if _, ok := audioMIMEType(abs); !ok {
+			return "", nil, fmt.Errorf("unsupported audio file type: %s", filepath.Ext(abs))
+		}
+		return filepath.Dir(abs), []string{abs}, nil
+	}
+	err = filepath.WalkDir(abs, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != abs && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if _, ok := audioMIMEType(p); ok && !strings.HasPrefix(d.Name(), ".") {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if len(files) == 0 {
+		return "", nil, errors.New("no audio files found in " + p)
+	}
+	sort.Strings(files)
+	return abs, files, nil
+}
+
+// mediaServer serves the given audio files over HTTP, with Range support, so
+// speakers can stream them. Nothing else below root is reachable.
+type mediaServer struct {
+	base  string            // e.g. http://192.168.1.5:41234
+	files map[string]string // URL path below /media/ → absolute file path
+	srv   *http.Server
+}
+
+func startMediaServer(ln net.Listener, root string, files []string) (*mediaServer, error) {
+	s := &mediaServer{
+		base:  "http://" + ln.Addr().String(),
+		files: make(map[string]string, len(files)),
+	}
+	for _, f := range files {
+		rel, err := mediaPath(root, f)
+		if err != nil {
+			return nil, err
+		}
+		s.files["/"+rel] = f
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/media/", http.StripPrefix("/media", http.HandlerFunc(s.serveFile)))
+	s.srv = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = s.srv.Serve(ln) }()
+	return s, nil
+}
+
+func (s *mediaServer) serveFile(w http.ResponseWriter, r *http.Request) {
+	full, ok := s.files[path.Clean("/"+r.URL.Path)]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	mt, ok := audioMIMEType(full)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	f, err := os.Open(full)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil || st.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", mt)
+	// ServeContent handles Range/If-Modified-Since and keeps the Content-Type set above.
+	http.ServeContent(w, r, st.Name(), st.ModTime(), f)
+}
+
+// mediaPath returns file's slash-separated path below root.
+func mediaPath(root, file string) (string, error) {
+	rel, err := filepath.Rel(root, file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is outside of %s", file, root)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// mediaURL returns the URL under which a media server at base serves file (below root).
+func mediaURL(base, root, file string) (string, error) {
+	rel, err := mediaPath(root, file)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.Split(rel, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(p)
+	}
+	return base + "/media/" + strings.Join(parts, "/"), nil
+}
+
+func (s *mediaServer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return s.srv.Shutdown(ctx)
+}
+
+type queueLister interface {
+	ListQueue(ctx context.Context, start, count int) (sonos.QueuePage, error)
+}
+
+// queueReferences reports whether any queue entry points at base.
+func queueReferences(ctx context.Context, c queueLister, base string) (bool, error) {
+	const pageSize = 100
+	for start := 0; ; start += pageSize {
+		page, err := c.ListQueue(ctx, start, pageSize)
+		if err != nil {
+			return false, err
+		}
+		for _, it := range page.Items {
+			if strings.HasPrefix(it.Item.URI, base+"/") {
+				return true, nil
+			}
+		}
+		if page.NumberReturned == 0 || start+page.NumberReturned >= page.TotalMatches {
+			return false, nil
+		}
+	}
+}
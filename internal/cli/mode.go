@@ -0,0 +1,231 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type modeClient interface {
+	GetTransportSettings(ctx context.Context) (sonos.TransportSettings, error)
+	SetPlayMode(ctx context.Context, mode sonos.PlayMode) error
+	GetCrossfadeMode(ctx context.Context) (bool, error)
+	SetCrossfadeMode(ctx context.Context, enabled bool) error
+}
+
+var newModeClient = func(ctx context.Context, flags *rootFlags) (modeClient, error) {
+	return coordinatorClient(ctx, flags)
+}
+
+type modeOutput struct {
+	PlayMode  sonos.PlayMode   `json:"playMode"`
+	Shuffle   bool             `json:"shuffle"`
+	Repeat    sonos.RepeatMode `json:"repeat"`
+	Crossfade bool             `json:"crossfade"`
+}
+
+func newModeCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mode",
+		Short: "Get or set shuffle, repeat and crossfade",
+		Long:  "Controls the AVTransport play mode (shuffle/repeat) and crossfade on the group coordinator.",
+	}
+	cmd.AddCommand(newModeGetCmd(flags))
+	cmd.AddCommand(newModeShuffleCmd(flags))
+	cmd.AddCommand(newModeRepeatCmd(flags, "repeat", sonos.RepeatAll))
+	cmd.AddCommand(newModeRepeatCmd(flags, "repeat-one", sonos.RepeatOne))
+	cmd.AddCommand(newModeCrossfadeCmd(flags))
+	return cmd
+}
+
+func newModeGetCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:          "get",
+		Short:        "Show play mode and crossfade",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := newModeClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			ts, err := c.GetTransportSettings(ctx)
+			if err != nil {
+				return err
+			}
+			crossfade, err := c.GetCrossfadeMode(ctx)
+			if err != nil {
+				return err
+			}
+			out := modeOutput{
+				PlayMode:  ts.PlayMode,
+				Shuffle:   ts.PlayMode.Shuffle(),
+				Repeat:    ts.PlayMode.Repeat(),
+				Crossfade: crossfade,
+			}
+
+			if isJSON(flags) {
+				return writeJSON(cmd, out)
+			}
+			if isTSV(flags) {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "play_mode\t%s\n", out.PlayMode)
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "shuffle\t%v\n", out.Shuffle)
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "repeat\t%s\n", out.Repeat)
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "crossfade\t%v\n", out.Crossfade)
+				return nil
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+			_, _ = fmt.Fprintf(w, "Play mode:\t%s\n", out.PlayMode)
+			_, _ = fmt.Fprintf(w, "Shuffle:\t%s\n", onOff(out.Shuffle))
+			_, _ = fmt.Fprintf(w, "Repeat:\t%s\n", out.Repeat)
+			_, _ = fmt.Fprintf(w, "Crossfade:\t%s\n", onOff(out.Crossfade))
+			return w.Flush()
+		},
+	}
+}
+
+func newModeShuffleCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:          "shuffle <on|off|toggle>",
+		Short:        "Turn shuffle on or off (keeps repeat)",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := newModeClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			ts, err := c.GetTransportSettings(ctx)
+			if err != nil {
+				return err
+			}
+			shuffle, err := parseSwitch(args[0], ts.PlayMode.Shuffle())
+			if err != nil {
+				return err
+			}
+			mode := ts.PlayMode.WithShuffle(shuffle)
+			if err := c.SetPlayMode(ctx, mode); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "mode.shuffle", map[string]any{"playMode": mode, "shuffle": shuffle})
+		},
+	}
+}
+
+// newModeRepeatCmd builds `repeat` (repeat all) and `repeat-one`; turning
+// either off clears repeat entirely while keeping shuffle.
+func newModeRepeatCmd(flags *rootFlags, use string, repeat sonos.RepeatMode) *cobra.Command {
+	short := "Turn repeat (all) on or off (keeps shuffle)"
+	if repeat == sonos.RepeatOne {
+		short = "Turn repeat-one on or off (keeps shuffle)"
+	}
+	return &cobra.Command{
+		Use:          use + " <on|off|toggle>",
+		Short:        short,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := newModeClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			ts, err := c.GetTransportSettings(ctx)
+			if err != nil {
+				return err
+			}
+			on, err := parseSwitch(args[0], ts.PlayMode.Repeat() == repeat)
+			if err != nil {
+				return err
+			}
+			next := sonos.RepeatOff
+			if on {
+				next = repeat
+			}
+			mode := ts.PlayMode.WithRepeat(next)
+			if err := c.SetPlayMode(ctx, mode); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "mode."+use, map[string]any{"playMode": mode, "repeat": next})
+		},
+	}
+}
+
+func newModeCrossfadeCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:          "crossfade <on|off|toggle>",
+		Short:        "Turn crossfade on or off",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := newModeClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			current := false
+			if strings.EqualFold(strings.TrimSpace(args[0]), "toggle") {
+				current, err = c.GetCrossfadeMode(ctx)
+				if err != nil {
+					return err
+				}
+			}
+			on, err := parseSwitch(args[0], current)
+			if err != nil {
+				return err
+			}
+			if err := c.SetCrossfadeMode(ctx, on); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "mode.crossfade", map[string]any{"crossfade": on})
+		},
+	}
+}
+
+func parseOnOff(val string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(val)) {
+	case "on", "true", "1", "yes":
+		return true, nil
+	case "off", "false", "0", "no":
+		return false, nil
+	default:
+		return false, errors.New("expected on|off: " + val)
+	}
+}
+
+// parseSwitch resolves on|off|toggle against the current value.
+func parseSwitch(val string, current bool) (bool, error) {
+	if strings.EqualFold(strings.TrimSpace(val), "toggle") {
+		return !current, nil
+	}
+	v, err := parseOnOff(val)
+	if err != nil {
+		return false, errors.New("expected on|off|toggle: " + val)
+	}
+	return v, nil
+}
+
+func onOff(v bool) string {
+	if v {
+		return "on"
+	}
+	return "off"
+}
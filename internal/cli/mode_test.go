@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakeModeClient struct {
+	mode      sonos.PlayMode
+	crossfade bool
+	setModes  []sonos.PlayMode
+	setFades  []bool
+}
+
+func (f *fakeModeClient) GetTransportSettings(ctx context.Context) (sonos.TransportSettings, error) {
+	return sonos.TransportSettings{PlayMode: f.mode}, nil
+}
+
+func (f *fakeModeClient) SetPlayMode(ctx context.Context, mode sonos.PlayMode) error {
+	f.setModes = append(f.setModes, mode)
+	f.mode = mode
+	return nil
+}
+
+func (f *fakeModeClient) GetCrossfadeMode(ctx context.Context) (bool, error) {
+	return f.crossfade, nil
+}
+
+func (f *fakeModeClient) SetCrossfadeMode(ctx context.Context, enabled bool) error {
+	f.setFades = append(f.setFades, enabled)
+	f.crossfade = enabled
+	return nil
+}
+
+func runModeCmd(t *testing.T, flags *rootFlags, fake *fakeModeClient, args ...string) (string, error) {
+	t.Helper()
+	orig := newModeClient
+	t.Cleanup(func() { newModeClient = orig })
+	newModeClient = func(ctx context.Context, flags *rootFlags) (modeClient, error) {
+		return fake, nil
+	}
+
+	cmd := newModeCmd(flags)
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestModeShufflePreservesRepeat(t *testing.T) {
+	flags := &rootFlags{Name: "Kitchen", Timeout: 2 * time.Second, Format: formatJSON}
+	fake := &fakeModeClient{mode: sonos.PlayModeRepeatOne}
+
+	out, err := runModeCmd(t, flags, fake, "shuffle", "on")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fake.setModes) != 1 || fake.setModes[0] != sonos.PlayModeShuffleRepeatOne {
+		t.Fatalf("unexpected modes: %v", fake.setModes)
+	}
+	if !strings.Contains(out, `"action": "mode.shuffle"`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
+
+func TestModeRepeatToggleAndRepeatOneOff(t *testing.T) {
+	flags := &rootFlags{Name: "Kitchen", Timeout: 2 * time.Second, Format: formatPlain}
+	fake := &fakeModeClient{mode: sonos.PlayModeShuffleNoRepeat}
+
+	if _, err := runModeCmd(t, flags, fake, "repeat", "toggle"); err != nil {
+		t.Fatalf("repeat toggle: %v", err)
+	}
+	if fake.mode != sonos.PlayModeShuffle {
+		t.Fatalf("after repeat toggle: %s", fake.mode)
+	}
+	if _, err := runModeCmd(t, flags, fake, "repeat-one", "on"); err != nil {
+		t.Fatalf("repeat-one on: %v", err)
+	}
+	if fake.mode != sonos.PlayModeShuffleRepeatOne {
+		t.Fatalf("after repeat-one on: %s", fake.mode)
+	}
+	if _, err := runModeCmd(t, flags, fake, "repeat-one", "off"); err != nil {
+		t.Fatalf("repeat-one off: %v", err)
+	}
+	if fake.mode != sonos.PlayModeShuffleNoRepeat {
+		t.Fatalf("after repeat-one off: %s", fake.mode)
+	}
+	if _, err := runModeCmd(t, flags, fake, "repeat", "sometimes"); err == nil {
+		t.Fatalf("expected error for invalid value")
+	}
+}
+
+func TestModeGetFormats(t *testing.T) {
+	fake := &fakeModeClient{mode: sonos.PlayModeShuffle, crossfade: true}
+
+	out, err := runModeCmd(t, &rootFlags{Name: "Kitchen", Format: formatTSV}, fake, "get")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"play_mode\tSHUFFLE\n", "shuffle\ttrue\n", "repeat\tall\n", "crossfade\ttrue\n"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in %q", want, out)
+		}
+	}
+
+	out, err = runModeCmd(t, &rootFlags{Name: "Kitchen", Format: formatPlain}, fake, "get")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Shuffle:") || !strings.Contains(out, "Crossfade:") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestModeCrossfadeToggle(t *testing.T) {
+	fake := &fakeModeClient{crossfade: true}
+	if _, err := runModeCmd(t, &rootFlags{Name: "Kitchen", Format: formatPlain}, fake, "crossfade", "toggle"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fake.setFades) != 1 || fake.setFades[0] {
+		t.Fatalf("unexpected crossfade calls: %v", fake.setFades)
+	}
+}
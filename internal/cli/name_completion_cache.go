@@ -93,6 +93,18 @@ func storeNameCompletions(now time.Time, names []string) error {
 	return os.Rename(tmp, path)
 }
 
+// invalidateNameCompletions drops the cache, e.g. after a room was renamed.
+func invalidateNameCompletions() error {
+	path, err := nameCompletionCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
 func nameCompletionCachePath() (string, error) {
 	if override := os.Getenv("SONOSCLI_COMPLETION_CACHE_DIR"); override != "" {
 		return filepath.Join(override, "sonoscli", "name-completions.json"), nil
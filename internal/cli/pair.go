@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type pairClient interface {
+	CreateStereoPair(ctx context.Context, leftUUID, rightUUID string) error
+	SeparateStereoPair(ctx context.Context, channelMapSet string) error
+}
+
+var newPairClient = func(ip string, timeout time.Duration) pairClient {
+	return sonos.NewClient(ip, timeout)
+}
+
+func newPairCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pair",
+		Short: "Create or separate stereo pairs",
+	}
+	cmd.AddCommand(newPairCreateCmd(flags))
+	cmd.AddCommand(newPairSeparateCmd(flags))
+	return cmd
+}
+
+func newPairCreateCmd(flags *rootFlags) *cobra.Command {
+	var left, right string
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Bond two speakers into a stereo pair",
+		Long: "Bonds two speakers of the same model into a stereo pair (DeviceProperties CreateStereoPair). " +
+			"The left speaker keeps its room name; the right one becomes an invisible member of that room.",
+		Example:      "  sonos pair create --left \"Shelf L\" --right \"Shelf R\"",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(left) == "" || strings.TrimSpace(right) == "" {
+				return errors.New("--left and --right are required")
+			}
+			ctx := cmd.Context()
+			tg, err := newTopologyGetter(ctx, flags.Timeout)
+			if err != nil {
+				return err
+			}
+			top, err := tg.GetTopology(ctx)
+			if err != nil {
+				return err
+			}
+			l, err := resolveMember(top, left, "")
+			if err != nil {
+				return err
+			}
+			r, err := resolveMember(top, right, "")
+			if err != nil {
+				return err
+			}
+			if l.UUID == r.UUID {
+				return errors.New("--left and --right must be different speakers")
+			}
+			for _, m := range []sonos.Member{l, r} {
+				if b, ok := top.BondFor(m.UUID); ok {
+					return fmt.Errorf("%s is already part of a %s", m.Name, strings.ReplaceAll(b.Type, "_", " "))
+				}
+			}
+
+			if err := newPairClient(l.IP, flags.Timeout).CreateStereoPair(ctx, l.UUID, r.UUID); err != nil {
+				return err
+			}
+			if isJSON(flags) {
+				return writeOK(cmd, flags, "pair.create", map[string]any{
+					"room":  l.Name,
+					"left":  map[string]any{"name": l.Name, "ip": l.IP, "uuid": l.UUID},
+					"right": map[string]any{"name": r.Name, "ip": r.IP, "uuid": r.UUID},
+				})
+			}
+			writePlainLine(cmd, flags, fmt.Sprintf("Paired %q (left) with %q (right) as %q", l.Name, r.Name, l.Name))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&left, "left", "", "Speaker for the left channel (keeps its room name)")
+	cmd.Flags().StringVar(&right, "right", "", "Speaker for the right channel")
+	return cmd
+}
+
+func newPairSeparateCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:          "separate",
+		Short:        "Split a stereo pair into two speakers",
+		Long:         "Splits the stereo pair of the --name/--ip room (DeviceProperties SeparateStereoPair with the pair's channel map).",
+		Example:      "  sonos pair separate --name Shelf",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			tg, err := newTopologyGetter(ctx, flags.Timeout)
+			if err != nil {
+				return err
+			}
+			top, err := tg.GetTopology(ctx)
+			if err != nil {
+				return err
+			}
+			mem, err := resolveMember(top, flags.Name, flags.IP)
+			if err != nil {
+				return err
+			}
+			b, ok := top.BondFor(mem.UUID)
+			if !ok || b.Type != sonos.BondStereoPair {
+				return fmt.Errorf("%s is not a stereo pair", mem.Name)
+			}
+			primary, ok := top.FindByUUID(b.Primary)
+			if !ok {
+				return fmt.Errorf("%s: pair primary %s not found", mem.Name, b.Primary)
+			}
+
+			if err := newPairClient(primary.IP, flags.Timeout).SeparateStereoPair(ctx, primary.ChannelMapSet); err != nil {
+				return err
+			}
+			if isJSON(flags) {
+				return writeOK(cmd, flags, "pair.separate", map[string]any{"room": b.Room, "members": b.Members})
+			}
+			writePlainLine(cmd, flags, fmt.Sprintf("Separated stereo pair %q", b.Room))
+			return nil
+		},
+	}
+}
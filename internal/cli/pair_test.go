@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakePairClient struct {
+	ip      string
+	created [][2]string
+	split   []string
+}
+
+func (f *fakePairClient) CreateStereoPair(ctx context.Context, leftUUID, rightUUID string) error {
+	f.created = append(f.created, [2]string{leftUUID, rightUUID})
+	return nil
+}
+
+func (f *fakePairClient) SeparateStereoPair(ctx context.Context, channelMapSet string) error {
+	f.split = append(f.split, channelMapSet)
+	return nil
+}
+
+// pairTestTopology has two standalone speakers and an existing Shelf pair.
+func pairTestTopology() sonos.Topology {
+	const shelfMap = "RINCON_SL1400:LF,LF;RINCON_SR1400:RF,RF"
+	a := sonos.Member{Name: "Desk L", IP: "192.168.1.30", UUID: "RINCON_DL1400", IsVisible: true, IsCoordinator: true}
+	b := sonos.Member{Name: "Desk R", IP: "192.168.1.31", UUID: "RINCON_DR1400", IsVisible: true, IsCoordinator: true}
+	sl := sonos.Member{Name: "Shelf", IP: "192.168.1.40", UUID: "RINCON_SL1400", IsVisible: true, IsCoordinator: true, ChannelMapSet: shelfMap}
+	sr := sonos.Member{Name: "Shelf", IP: "192.168.1.41", UUID: "RINCON_SR1400", ChannelMapSet: shelfMap}
+	return sonos.Topology{
+		Groups: []sonos.Group{
+			{ID: "RINCON_DL1400:1", Coordinator: a, Members: []sonos.Member{a}},
+			{ID: "RINCON_DR1400:1", Coordinator: b, Members: []sonos.Member{b}},
+			{ID: "RINCON_SL1400:1", Coordinator: sl, Members: []sonos.Member{sl, sr}},
+		},
+		ByName: map[string]sonos.Member{a.Name: a, b.Name: b, sl.Name: sl},
+		ByIP:   map[string]sonos.Member{a.IP: a, b.IP: b, sl.IP: sl, sr.IP: sr},
+	}
+}
+
+func runPairCmd(t *testing.T, flags *rootFlags, args ...string) (*fakePairClient, string, error) {
+	t.Helper()
+	origTG, origClient := newTopologyGetter, newPairClient
+	t.Cleanup(func() {
+		newTopologyGetter = origTG
+		newPairClient = origClient
+	})
+	newTopologyGetter = func(ctx context.Context, timeout time.Duration) (topologyGetter, error) {
+		return &fakeTopologyGetter{top: pairTestTopology()}, nil
+	}
+	fake := &fakePairClient{}
+	newPairClient = func(ip string, timeout time.Duration) pairClient {
+		fake.ip = ip
+		return fake
+	}
+
+	cmd := newPairCmd(flags)
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(newDiscardWriter())
+	cmd.SetArgs(args)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(context.Background())
+	return fake, out.String(), err
+}
+
+func TestPairCreateSendsUUIDsToLeftSpeaker(t *testing.T) {
+	fake, out, err := runPairCmd(t, &rootFlags{Timeout: time.Second, Format: formatPlain}, "create", "--left", "Desk L", "--right", "desk r")
+	if err != nil {
+		t.Fatalf("pair create: %v", err)
+	}
+	if fake.ip != "192.168.1.30" || len(fake.created) != 1 || fake.created[0] != [2]string{"RINCON_DL1400", "RINCON_DR1400"} {
+		t.Fatalf("unexpected call: ip=%s created=%v", fake.ip, fake.created)
+	}
+	if !strings.Contains(out, `Paired "Desk L" (left) with "Desk R" (right)`) {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestPairCreateRejectsBondedSpeaker(t *testing.T) {
+	fake, _, err := runPairCmd(t, &rootFlags{Timeout: time.Second}, "create", "--left", "Desk L", "--right", "Shelf")
+	if err == nil || !strings.Contains(err.Error(), "already part of a stereo pair") {
+		t.Fatalf("expected error, got %v", err)
+	}
+	if len(fake.created) != 0 {
+		t.Fatalf("unexpected CreateStereoPair: %v", fake.created)
+	}
+}
+
+func TestPairSeparateUsesPrimaryChannelMap(t *testing.T) {
+	// Targeting the invisible right speaker still separates via the primary.
+	fake, out, err := runPairCmd(t, &rootFlags{IP: "192.168.1.41", Timeout: time.Second, Format: formatJSON}, "separate")
+	if err != nil {
+		t.Fatalf("pair separate: %v", err)
+	}
+	if fake.ip != "192.168.1.40" || len(fake.split) != 1 || fake.split[0] != "RINCON_SL1400:LF,LF;RINCON_SR1400:RF,RF" {
+		t.Fatalf("unexpected call: ip=%s split=%v", fake.ip, fake.split)
+	}
+	if !strings.Contains(out, `"action": "pair.separate"`) || !strings.Contains(out, `"room": "Shelf"`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+
+	if _, _, err := runPairCmd(t, &rootFlags{Name: "Desk L", Timeout: time.Second}, "separate"); err == nil || !strings.Contains(err.Error(), "not a stereo pair") {
+		t.Fatalf("expected error, got %v", err)
+	}
+}
@@ -0,0 +1,246 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/audiotags"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type playFileClient interface {
+	AddURIToQueue(ctx context.Context, enqueuedURI, enqueuedMeta string, desiredFirstTrackNumber int, enqueueAsNext bool) (int, error)
+	PlayQueuePosition(ctx context.Context, position int) error
+	ListQueue(ctx context.Context, start, count int) (sonos.QueuePage, error)
+}
+
+var newPlayFileClient = func(ip string, timeout time.Duration) playFileClient {
+	return newSonosClient(ip, timeout)
+}
+
+// playFileIdlePoll is how often the file server checks whether the queue still references it.
+var playFileIdlePoll = 30 * time.Second
+
+// startDetachedMediaServer starts the background file server and returns the
+// base URL it reports once it is listening. The child binds the port itself,
+// so nothing can take it between a check and the real listen.
+var startDetachedMediaServer = func(ctx context.Context, args []string) (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	child := exec.Command(exe, args...)
+	child.Stdout = w
+	child.Stderr = w
+	err = child.Start()
+	_ = w.Close()
+	if err != nil {
+		return "", err
+	}
+
+	lines := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(r).ReadString('\n')
+		lines <- strings.TrimSpace(line)
+	}()
+	timer := time.NewTimer(5 * time.Second)
+	defer timer.Stop()
+	select {
+	case line := <-lines:
+		if !strings.HasPrefix(line, "http://") {
+			_ = child.Wait()
+			if line == "" {
+				line = "exited"
+			}
+			return "", fmt.Errorf("background file server did not start: %s", line)
+		}
+		return line, child.Process.Release()
+	case <-timer.C:
+		_ = child.Process.Kill()
+		return "", errors.New("background file server did not start")
+	case <-ctx.Done():
+		_ = child.Process.Kill()
+		return "", ctx.Err()
+	}
+}
+
+func newPlayFileCmd(flags *rootFlags) *cobra.Command {
+	var enqueueOnly bool
+	var detach bool
+	var port int
+	var serveOnly bool
+	var listenAddr string
+
+	cmd := &cobra.Command{
+		Use:   "play-file <path|dir>",
+		Short: "Serve local audio files and play them",
+		Long: "Starts a built-in HTTP file server (Range support, audio MIME types) on the interface the speaker can reach, " +
+			"enqueues the file(s) with metadata from tags or file names, and starts playback. Directories are walked recursively.\n\n" +
+			"The server keeps running until the queue no longer references it (or Ctrl+C). Use --detach to keep serving in the background.",
+		Example:      "  sonos play-file --name \"Office\" ~/Music/album\n  sonos play-file --name \"Office\" --detach track.flac",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			if serveOnly {
+				return runDetachedMediaServer(ctx, cmd, flags, listenAddr, args[0])
+			}
+
+			root, files, err := collectAudioFiles(args[0])
+			if err != nil {
+				return err
+			}
+			coordIP, err := resolveTargetCoordinatorIP(ctx, flags)
+			if err != nil {
+				return err
+			}
+			c := newPlayFileClient(coordIP, flags.Timeout)
+
+			listenIP, err := listenIPForRemote(coordIP)
+			if err != nil {
+				return err
+			}
+			addr := net.JoinHostPort(listenIP, fmt.Sprint(port))
+
+			var base string
+			if detach {
+				// The child serves exactly what this path enumerates to, not all of root.
+				src, err := filepath.Abs(args[0])
+				if err != nil {
+					return err
+				}
+				childArgs := []string{"play-file", "--serve-only", "--listen", addr, "--ip", coordIP, "--timeout", flags.Timeout.String(), src}
+				if base, err = startDetachedMediaServer(ctx, childArgs); err != nil {
+					return err
+				}
+			} else {
+				ln, err := net.Listen("tcp", addr)
+				if err != nil {
+					return err
+				}
+				srv, err := startMediaServer(ln, root, files)
+				if err != nil {
+					_ = ln.Close()
+					return err
+				}
+				defer srv.Close()
+				base = srv.base
+			}
+
+			first := 0
+			for _, f := range files {
+				uri, err := mediaURL(base, root, f)
+				if err != nil {
+					return err
+				}
+				tags, _ := audiotags.Read(f)
+				mt, _ := audioMIMEType(f)
+				meta := sonos.BuildTrackMeta(sonos.DIDLItem{
+					Title:  tags.Title,
+					Artist: tags.Artist,
+					Album:  tags.Album,
+					URI:    uri,
+				}, mt)
+				pos, err := c.AddURIToQueue(ctx, uri, meta, 0, false)
+				if err != nil {
+					return err
+				}
+				if first == 0 {
+					first = pos
+				}
+			}
+			if !enqueueOnly && first > 0 {
+				if err := c.PlayQueuePosition(ctx, first); err != nil {
+					return err
+				}
+			}
+
+			if err := writeOK(cmd, flags, "play-file", map[string]any{
+				"files":         len(files),
+				"firstPosition": first,
+				"serverURL":     base,
+				"detached":      detach,
+			}); err != nil {
+				return err
+			}
+			if detach {
+				writePlainLine(cmd, flags, fmt.Sprintf("Queued %d file(s); serving %s in the background.", len(files), base))
+				return nil
+			}
+			writePlainLine(cmd, flags, fmt.Sprintf("Queued %d file(s); serving %s until the queue no longer uses them (Ctrl+C to stop).", len(files), base))
+			serveWhileQueued(ctx, c, base)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&enqueueOnly, "enqueue", false, "Only add to the queue; do not start playback")
+	cmd.Flags().BoolVar(&detach, "detach", false, "Keep serving in a background process and return immediately")
+	cmd.Flags().IntVar(&port, "port", 0, "HTTP port to listen on (0 = random free port)")
+	cmd.Flags().BoolVar(&serveOnly, "serve-only", false, "Internal: run the background file server")
+	cmd.Flags().StringVar(&listenAddr, "listen", "", "Internal: address for --serve-only")
+	_ = cmd.Flags().MarkHidden("serve-only")
+	_ = cmd.Flags().MarkHidden("listen")
+	return cmd
+}
+
+// runDetachedMediaServer is the --serve-only child: it serves the files of
+// src and prints its base URL as the first line of stdout for the parent.
+func runDetachedMediaServer(ctx context.Context, cmd *cobra.Command, flags *rootFlags, listenAddr, src string) error {
+	if listenAddr == "" || flags.IP == "" {
+		return errors.New("--serve-only requires --listen and --ip")
+	}
+	// Survive the terminal that started us going away.
+	signal.Ignore(syscall.SIGHUP)
+	root, files, err := collectAudioFiles(src)
+	if err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return err
+	}
+	srv, err := startMediaServer(ln, root, files)
+	if err != nil {
+		_ = ln.Close()
+		return err
+	}
+	defer srv.Close()
+	_, _ = fmt.Fprintln(cmd.OutOrStdout(), srv.base)
+	serveWhileQueued(ctx, newPlayFileClient(flags.IP, flags.Timeout), srv.base)
+	return nil
+}
+
+// serveWhileQueued blocks until ctx is done or the queue no longer references base.
+func serveWhileQueued(ctx context.Context, c queueLister, base string) {
+	ticker := time.NewTicker(playFileIdlePoll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Transient errors (speaker rebooting, Wi-Fi hiccup) keep the server alive.
+			if inUse, err := queueReferences(ctx, c, base); err == nil && !inUse {
+				return
+			}
+		}
+	}
+}
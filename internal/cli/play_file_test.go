@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakePlayFileClient struct {
+	mu      sync.Mutex
+	queued  []sonos.DIDLItem
+	played  int
+	fetched []*http.Response
+	bodies  []string
+	cleared bool
+}
+
+func (f *fakePlayFileClient) AddURIToQueue(ctx context.Context, uri, meta string, desired int, asNext bool) (int, error) {
+	items, err := sonos.ParseDIDLItems(meta)
+	if err != nil || len(items) != 1 {
+		return 0, err
+	}
+	req, _ := http.NewRequest(http.MethodGet, uri, nil)
+	req.Header.Set("Range", "bytes=0-3")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fetched = append(f.fetched, resp)
+	f.bodies = append(f.bodies, string(body))
+	f.queued = append(f.queued, items[0])
+	return 4 + len(f.queued), nil
+}
+
+func (f *fakePlayFileClient) PlayQueuePosition(ctx context.Context, position int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.played = position
+	// Simulate the user replacing the queue right after playback started.
+	f.cleared = true
+	return nil
+}
+
+func (f *fakePlayFileClient) ListQueue(ctx context.Context, start, count int) (sonos.QueuePage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cleared {
+		return sonos.QueuePage{}, nil
+	}
+	items := make([]sonos.QueueItem, 0, len(f.queued))
+	for i, it := range f.queued {
+		items = append(items, sonos.QueueItem{Position: i + 1, Item: it})
+	}
+	return sonos.QueuePage{Items: items, NumberReturned: len(items), TotalMatches: len(items)}, nil
+}
+
+func TestCollectAudioFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.flac", "a.mp3", "cover.jpg", ".hidden.mp3", "sub/c.m4a"} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(p), 0o700)
+		if err := os.WriteFile(p, []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root, files, err := collectAudioFiles(dir)
+	if err != nil {
+		t.Fatalf("collectAudioFiles: %v", err)
+	}
+	if root != dir || len(files) != 3 || filepath.Base(files[0]) != "a.mp3" || filepath.Base(files[2]) != "c.m4a" {
+		t.Fatalf("unexpected files: %q %v", root, files)
+	}
+	if _, _, err := collectAudioFiles(filepath.Join(dir, "cover.jpg")); err == nil {
+		t.Fatalf("expected error for non-audio file")
+	}
+}
+
+func TestPlayFileServesAndEnqueues(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Blue Train")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "01 - John Coltrane - Blue Train.mp3"), []byte("0123456789"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "02 Moment's Notice.flac"), []byte("abcdefghij"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := &fakePlayFileClient{}
+	origClient, origPoll := newPlayFileClient, playFileIdlePoll
+	t.Cleanup(func() { newPlayFileClient, playFileIdlePoll = origClient, origPoll })
+	newPlayFileClient = func(ip string, timeout time.Duration) playFileClient { return fake }
+	playFileIdlePoll = 10 * time.Millisecond
+
+	flags := &rootFlags{IP: "127.0.0.1", Timeout: 200 * time.Millisecond, Format: formatPlain}
+	cmd := newPlayFileCmd(flags)
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{dir})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("play-file: %v", err)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if len(fake.queued) != 2 || fake.played != 5 {
+		t.Fatalf("unexpected queue/play: %+v played=%d", fake.queued, fake.played)
+	}
+	first := fake.queued[0]
+	if first.Title != "Blue Train" || first.Artist != "John Coltrane" || first.Album != "Blue Train" {
+		t.Fatalf("unexpected metadata: %+v", first)
+	}
+	if !strings.Contains(first.URI, "/media/01%20-%20John%20Coltrane%20-%20Blue%20Train.mp3") {
+		t.Fatalf("unexpected uri: %s", first.URI)
+	}
+	if fake.fetched[0].StatusCode != http.StatusPartialContent || fake.bodies[0] != "0123" {
+		t.Fatalf("expected range response, got %d %q", fake.fetched[0].StatusCode, fake.bodies[0])
+	}
+	if ct := fake.fetched[1].Header.Get("Content-Type"); ct != "audio/flac" {
+		t.Fatalf("unexpected content type: %q", ct)
+	}
+	if !strings.Contains(out.String(), "Queued 2 file(s)") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+}
+
+func TestMediaServerOnlyServesCollectedFiles(t *testing.T) {
+	dir := t.TempDir()
+	outside := filepath.Join(t.TempDir(), "outside.mp3")
+	for _, p := range []string{
+		filepath.Join(dir, "a.mp3"),
+		filepath.Join(dir, "b.mp3"),
+		filepath.Join(dir, ".hidden.mp3"),
+		filepath.Join(dir, "sub", "c.mp3"),
+		outside,
+	} {
+		_ = os.MkdirAll(filepath.Dir(p), 0o700)
+		if err := os.WriteFile(p, []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(outside, filepath.Join(dir, "link.mp3")); err != nil {
+		t.Fatal(err)
+	}
+
+	root, files, err := collectAudioFiles(filepath.Join(dir, "a.mp3"))
+	if err != nil {
+		t.Fatalf("collectAudioFiles: %v", err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := startMediaServer(ln, root, files)
+	if err != nil {
+		t.Fatalf("startMediaServer: %v", err)
+	}
+	defer srv.Close()
+
+	for name, want := range map[string]int{
+		"a.mp3":            http.StatusOK,
+		"b.mp3":            http.StatusNotFound,
+		".hidden.mp3":      http.StatusNotFound,
+		"sub/c.mp3":        http.StatusNotFound,
+		"link.mp3":         http.StatusNotFound,
+		"nonexistent.flac": http.StatusNotFound,
+	} {
+		resp, err := http.Get(srv.base + "/media/" + name)
+		if err != nil {
+			t.Fatalf("GET %s: %v", name, err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Fatalf("GET %s: got %d, want %d", name, resp.StatusCode, want)
+		}
+	}
+
+	// A directory serves its collected files, but not symlinks out of it.
+	_, files, err = collectAudioFiles(dir)
+	if err != nil {
+		t.Fatalf("collectAudioFiles dir: %v", err)
+	}
+	for _, f := range files {
+		if filepath.Base(f) == "link.mp3" {
+			t.Fatalf("symlink collected: %v", files)
+		}
+	}
+}
+
+func TestPlayFileDetachUsesChildReportedServer(t *testing.T) {
+	dir := t.TempDir()
+	track := filepath.Join(dir, "track.mp3")
+	for _, p := range []string{track, filepath.Join(dir, "other.mp3")} {
+		if err := os.WriteFile(p, []byte("0123456789"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fake := &fakePlayFileClient{}
+	origClient, origStart := newPlayFileClient, startDetachedMediaServer
+	t.Cleanup(func() { newPlayFileClient, startDetachedMediaServer = origClient, origStart })
+	newPlayFileClient = func(ip string, timeout time.Duration) playFileClient { return fake }
+	var childArgs []string
+	var srv *mediaServer
+	startDetachedMediaServer = func(ctx context.Context, args []string) (string, error) {
+		childArgs = args
+		// Stand in for the child: serve what the given path enumerates to.
+		root, files, err := collectAudioFiles(args[len(args)-1])
+		if err != nil {
+			return "", err
+		}
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return "", err
+		}
+		srv, err = startMediaServer(ln, root, files)
+		return srv.base, err
+	}
+
+	flags := &rootFlags{IP: "127.0.0.1", Timeout: 200 * time.Millisecond, Format: formatPlain}
+	cmd := newPlayFileCmd(flags)
+	cmd.SetOut(newDiscardWriter())
+	cmd.SetErr(newDiscardWriter())
+	cmd.SetArgs([]string{"--detach", track})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("play-file --detach: %v", err)
+	}
+	defer srv.Close()
+
+	if got := childArgs[len(childArgs)-1]; got != track {
+		t.Fatalf("child should serve the given file, got %q", got)
+	}
+	if len(fake.queued) != 1 || !strings.HasPrefix(fake.queued[0].URI, srv.base+"/media/") || fake.bodies[0] != "0123" {
+		t.Fatalf("unexpected queue: %+v %v", fake.queued, fake.bodies)
+	}
+	resp, err := http.Get(srv.base + "/media/other.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("sibling file served: %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,456 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type playlistClient interface {
+	ListPlaylists(ctx context.Context, start, count int) (sonos.PlaylistsPage, error)
+	ListPlaylistTracks(ctx context.Context, id string, start, count int) (sonos.QueuePage, error)
+	SaveQueue(ctx context.Context, title string) (string, error)
+	CreatePlaylist(ctx context.Context, title string) (string, error)
+	DeletePlaylist(ctx context.Context, id string) error
+	AddURIToPlaylist(ctx context.Context, id, uri, meta string) (int, error)
+	RemovePlaylistTracks(ctx context.Context, id string, positions []int) error
+	ReorderPlaylistTrack(ctx context.Context, id string, from, to int) error
+	EnqueuePlaylist(ctx context.Context, playlist sonos.DIDLItem) (int, error)
+	ClearQueue(ctx context.Context) error
+	PlayQueuePosition(ctx context.Context, position int) error
+}
+
+var newPlaylistClient = func(ctx context.Context, flags *rootFlags) (playlistClient, error) {
+	return coordinatorClient(ctx, flags)
+}
+
+func newPlaylistCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "playlist",
+		Short: "Manage Sonos playlists",
+		Long:  "Lists, edits and plays Sonos playlists (saved queues, ContentDirectory SQ:). Playlists can be referenced by ID (SQ:3) or title.",
+	}
+	cmd.AddCommand(newPlaylistListCmd(flags))
+	cmd.AddCommand(newPlaylistShowCmd(flags))
+	cmd.AddCommand(newPlaylistCreateCmd(flags))
+	cmd.AddCommand(newPlaylistDeleteCmd(flags))
+	cmd.AddCommand(newPlaylistAddCmd(flags))
+	cmd.AddCommand(newPlaylistRemoveCmd(flags))
+	cmd.AddCommand(newPlaylistReorderCmd(flags))
+	cmd.AddCommand(newPlaylistPlayCmd(flags))
+	return cmd
+}
+
+func newPlaylistListCmd(flags *rootFlags) *cobra.Command {
+	var start int
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List Sonos playlists",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			c, err := newPlaylistClient(cmd.Context(), flags)
+			if err != nil {
+				return err
+			}
+			page, err := c.ListPlaylists(cmd.Context(), start, limit)
+			if err != nil {
+				return err
+			}
+			if isJSON(flags) {
+				return writeJSON(cmd, page)
+			}
+			if isTSV(flags) {
+				for _, it := range page.Items {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", it.ID, it.Title)
+				}
+				return nil
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+			_, _ = fmt.Fprintf(w, "ID\tTITLE\n")
+			for _, it := range page.Items {
+				_, _ = fmt.Fprintf(w, "%s\t%s\n", it.ID, it.Title)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().IntVar(&start, "start", 0, "Starting index (0-based)")
+	cmd.Flags().IntVar(&limit, "limit", 100, "Max results to return")
+	return cmd
+}
+
+func newPlaylistShowCmd(flags *rootFlags) *cobra.Command {
+	var start int
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:          "show <playlist>",
+		Short:        "List the tracks of a Sonos playlist",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := newPlaylistClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			pl, err := findPlaylist(ctx, c, args[0])
+			if err != nil {
+				return err
+			}
+			page, err := c.ListPlaylistTracks(ctx, pl.ID, start, limit)
+			if err != nil {
+				return err
+			}
+			if isJSON(flags) {
+				return writeJSON(cmd, map[string]any{"playlist": pl, "tracks": page})
+			}
+			if isTSV(flags) {
+				for _, it := range page.Items {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%d\t%s\t%s\t%s\n", it.Position, it.Item.Title, it.Item.Artist, it.Item.URI)
+				}
+				return nil
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+			_, _ = fmt.Fprintf(w, "POS\tTITLE\tARTIST\tURI\n")
+			for _, it := range page.Items {
+				_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", it.Position, it.Item.Title, it.Item.Artist, it.Item.URI)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().IntVar(&start, "start", 0, "Starting index (0-based)")
+	cmd.Flags().IntVar(&limit, "limit", 100, "Max results to return")
+	return cmd
+}
+
+func newPlaylistCreateCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "create <name>",
+		Short:        "Create an empty Sonos playlist",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			name := strings.TrimSpace(args[0])
+			if name == "" {
+				return errors.New("name is required")
+			}
+			c, err := newPlaylistClient(cmd.Context(), flags)
+			if err != nil {
+				return err
+			}
+			id, err := c.CreatePlaylist(cmd.Context(), name)
+			if err != nil {
+				return err
+			}
+			writePlainLine(cmd, flags, fmt.Sprintf("Created playlist %q (%s)", name, id))
+			return writeOK(cmd, flags, "playlist.create", map[string]any{"id": id, "title": name})
+		},
+	}
+	return cmd
+}
+
+func newPlaylistDeleteCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "delete <playlist>",
+		Short:        "Delete a Sonos playlist",
+		Long:         "Deletes a Sonos playlist. The playlist must be given by ID (SQ:3) or full title; partial titles are rejected.",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := newPlaylistClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			pl, err := findPlaylistExact(ctx, c, args[0])
+			if err != nil {
+				return err
+			}
+			if err := c.DeletePlaylist(ctx, pl.ID); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "playlist.delete", map[string]any{"id": pl.ID, "title": pl.Title})
+		},
+	}
+	return cmd
+}
+
+func newPlaylistAddCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "add <playlist> <uri>...",
+		Short:        "Append URIs to a Sonos playlist",
+		SilenceUsage: true,
+		Args:         cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := newPlaylistClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			pl, err := findPlaylistExact(ctx, c, args[0])
+			if err != nil {
+				return err
+			}
+			added, length := 0, 0
+			for _, uri := range args[1:] {
+				uri = strings.TrimSpace(uri)
+				if uri == "" {
+					continue
+				}
+				n, err := c.AddURIToPlaylist(ctx, pl.ID, uri, "")
+				if err != nil {
+					return fmt.Errorf("add %s: %w", uri, err)
+				}
+				added++
+				length = n
+			}
+			return writeOK(cmd, flags, "playlist.add", map[string]any{"id": pl.ID, "added": added, "length": length})
+		},
+	}
+	return cmd
+}
+
+func newPlaylistRemoveCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "remove <playlist> <pos>...",
+		Short:        "Remove tracks from a Sonos playlist (1-based)",
+		SilenceUsage: true,
+		Args:         cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			positions := make([]int, 0, len(args)-1)
+			for _, arg := range args[1:] {
+				pos, err := strconv.Atoi(arg)
+				if err != nil || pos <= 0 {
+					return errors.New("pos must be an integer (1-based)")
+				}
+				positions = append(positions, pos)
+			}
+			ctx := cmd.Context()
+			c, err := newPlaylistClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			pl, err := findPlaylistExact(ctx, c, args[0])
+			if err != nil {
+				return err
+			}
+			if err := c.RemovePlaylistTracks(ctx, pl.ID, positions); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "playlist.remove", map[string]any{"id": pl.ID, "positions": positions})
+		},
+	}
+	return cmd
+}
+
+func newPlaylistReorderCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "reorder <playlist> <from> <to>",
+		Short:        "Move a track within a Sonos playlist (1-based)",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			from, err := strconv.Atoi(args[1])
+			if err != nil || from <= 0 {
+				return errors.New("from must be an integer (1-based)")
+			}
+			to, err := strconv.Atoi(args[2])
+			if err != nil || to <= 0 {
+				return errors.New("to must be an integer (1-based)")
+			}
+			ctx := cmd.Context()
+			c, err := newPlaylistClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			pl, err := findPlaylistExact(ctx, c, args[0])
+			if err != nil {
+				return err
+			}
+			if err := c.ReorderPlaylistTrack(ctx, pl.ID, from, to); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "playlist.reorder", map[string]any{"id": pl.ID, "from": from, "to": to})
+		},
+	}
+	return cmd
+}
+
+func newPlaylistPlayCmd(flags *rootFlags) *cobra.Command {
+	var enqueue bool
+
+	cmd := &cobra.Command{
+		Use:          "play <playlist>",
+		Short:        "Play a Sonos playlist",
+		Long:         "Replaces the queue with the playlist and starts playback. With --enqueue, the playlist is appended to the queue instead.",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := newPlaylistClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			pl, err := findPlaylist(ctx, c, args[0])
+			if err != nil {
+				return err
+			}
+			if !enqueue {
+				if err := c.ClearQueue(ctx); err != nil {
+					return err
+				}
+			}
+			first, err := c.EnqueuePlaylist(ctx, pl)
+			if err != nil {
+				return err
+			}
+			if !enqueue {
+				if first <= 0 {
+					first = 1
+				}
+				if err := c.PlayQueuePosition(ctx, first); err != nil {
+					return err
+				}
+			}
+			return writeOK(cmd, flags, "playlist.play", map[string]any{"playlist": pl, "enqueued": enqueue, "position": first})
+		},
+	}
+
+	cmd.Flags().BoolVar(&enqueue, "enqueue", false, "Append to the queue instead of replacing it")
+	return cmd
+}
+
+type playlistLister interface {
+	ListPlaylists(ctx context.Context, start, count int) (sonos.PlaylistsPage, error)
+}
+
+// findPlaylist resolves a playlist by ID (SQ:3) or title: exact title first,
+// then case-insensitive, then a unique substring match. Commands that change
+// or delete a playlist use findPlaylistExact instead.
+func findPlaylist(ctx context.Context, c playlistLister, ref string) (sonos.DIDLItem, error) {
+	all, err := listAllPlaylists(ctx, c, ref)
+	if err != nil {
+		return sonos.DIDLItem{}, err
+	}
+	if it, ok := matchPlaylist(all, ref); ok {
+		return it, nil
+	}
+	matches := playlistsContaining(all, ref)
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		return sonos.DIDLItem{}, errors.New("playlist not found: " + ref)
+	default:
+		return sonos.DIDLItem{}, fmt.Errorf("playlist %q is ambiguous: %s", ref, playlistTitles(matches))
+	}
+}
+
+// findPlaylistExact resolves a playlist by ID or (case-insensitive) full title
+// only, so a partial name never selects a playlist to modify.
+func findPlaylistExact(ctx context.Context, c playlistLister, ref string) (sonos.DIDLItem, error) {
+	all, err := listAllPlaylists(ctx, c, ref)
+	if err != nil {
+		return sonos.DIDLItem{}, err
+	}
+	if it, ok := matchPlaylist(all, ref); ok {
+		return it, nil
+	}
+	if matches := playlistsContaining(all, ref); len(matches) > 0 {
+		return sonos.DIDLItem{}, fmt.Errorf("playlist not found: %s (use the full title or ID: %s)", ref, playlistTitles(matches))
+	}
+	return sonos.DIDLItem{}, errors.New("playlist not found: " + ref)
+}
+
+func listAllPlaylists(ctx context.Context, c playlistLister, ref string) ([]sonos.DIDLItem, error) {
+	if strings.TrimSpace(ref) == "" {
+		return nil, errors.New("playlist is required")
+	}
+
+	const pageSize = 100
+	var all []sonos.DIDLItem
+	start := 0
+	for {
+		page, err := c.ListPlaylists(ctx, start, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Items...)
+		start += page.NumberReturned
+		if page.NumberReturned == 0 || start >= page.TotalMatches {
+			break
+		}
+	}
+	return all, nil
+}
+
+// matchPlaylist finds ref as an ID or exact title, then as a case-insensitive title.
+func matchPlaylist(all []sonos.DIDLItem, ref string) (sonos.DIDLItem, bool) {
+	ref = strings.TrimSpace(ref)
+	for _, it := range all {
+		if it.ID == ref || it.Title == ref {
+			return it, true
+		}
+	}
+	for _, it := range all {
+		if strings.EqualFold(it.Title, ref) {
+			return it, true
+		}
+	}
+	return sonos.DIDLItem{}, false
+}
+
+func playlistsContaining(all []sonos.DIDLItem, ref string) []sonos.DIDLItem {
+	var matches []sonos.DIDLItem
+	needle := strings.ToLower(strings.TrimSpace(ref))
+	for _, it := range all {
+		if strings.Contains(strings.ToLower(it.Title), needle) {
+			matches = append(matches, it)
+		}
+	}
+	return matches
+}
+
+func playlistTitles(items []sonos.DIDLItem) string {
+	names := make([]string, 0, len(items))
+	for _, it := range items {
+		names = append(names, it.Title)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakePlaylistClient struct {
+	playlists []sonos.DIDLItem
+	tracks    sonos.QueuePage
+
+	saved       string
+	created     string
+	deleted     string
+	added       []string
+	removed     []int
+	reorder     [2]int
+	enqueued    sonos.DIDLItem
+	clearCalls  int
+	playedPos   int
+	enqueueFrom int
+}
+
+func (f *fakePlaylistClient) ListPlaylists(ctx context.Context, start, count int) (sonos.PlaylistsPage, error) {
+	return sonos.PlaylistsPage{Items: f.playlists, NumberReturned: len(f.playlists), TotalMatches: len(f.playlists)}, nil
+}
+
+func (f *fakePlaylistClient) ListPlaylistTracks(ctx context.Context, id string, start, count int) (sonos.QueuePage, error) {
+	return f.tracks, nil
+}
+
+func (f *fakePlaylistClient) SaveQueue(ctx context.Context, title string) (string, error) {
+	f.saved = title
+	return "SQ:9", nil
+}
+
+func (f *fakePlaylistClient) CreatePlaylist(ctx context.Context, title string) (string, error) {
+	f.created = title
+	return "SQ:10", nil
+}
+
+func (f *fakePlaylistClient) DeletePlaylist(ctx context.Context, id string) error {
+	f.deleted = id
+	return nil
+}
+
+func (f *fakePlaylistClient) AddURIToPlaylist(ctx context.Context, id, uri, meta string) (int, error) {
+	f.added = append(f.added, uri)
+	return len(f.added), nil
+}
+
+func (f *fakePlaylistClient) RemovePlaylistTracks(ctx context.Context, id string, positions []int) error {
+	f.removed = positions
+	return nil
+}
+
+func (f *fakePlaylistClient) ReorderPlaylistTrack(ctx context.Context, id string, from, to int) error {
+	f.reorder = [2]int{from, to}
+	return nil
+}
+
+func (f *fakePlaylistClient) EnqueuePlaylist(ctx context.Context, playlist sonos.DIDLItem) (int, error) {
+	f.enqueued = playlist
+	return f.enqueueFrom, nil
+}
+
+func (f *fakePlaylistClient) ClearQueue(ctx context.Context) error {
+	f.clearCalls++
+	return nil
+}
+
+func (f *fakePlaylistClient) PlayQueuePosition(ctx context.Context, position int) error {
+	f.playedPos = position
+	return nil
+}
+
+func withFakePlaylistClient(t *testing.T, fc *fakePlaylistClient) {
+	t.Helper()
+	orig := newPlaylistClient
+	t.Cleanup(func() { newPlaylistClient = orig })
+	newPlaylistClient = func(ctx context.Context, flags *rootFlags) (playlistClient, error) { return fc, nil }
+}
+
+func runPlaylistCmd(t *testing.T, flags *rootFlags, args ...string) (string, error) {
+	t.Helper()
+	cmd := newPlaylistCmd(flags)
+	cmd.SetArgs(args)
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+var testPlaylists = []sonos.DIDLItem{
+	{ID: "SQ:1", Title: "Morning"},
+	{ID: "SQ:2", Title: "Dinner Jazz"},
+	{ID: "SQ:3", Title: "Dinner Party"},
+}
+
+func TestPlaylistListPrintsTable(t *testing.T) {
+	fc := &fakePlaylistClient{playlists: testPlaylists}
+	withFakePlaylistClient(t, fc)
+
+	out, err := runPlaylistCmd(t, &rootFlags{Name: "Kitchen", Timeout: 2 * time.Second}, "list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "ID") || !strings.Contains(out, "SQ:2") || !strings.Contains(out, "Dinner Jazz") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestPlaylistRequiresTarget(t *testing.T) {
+	_, err := runPlaylistCmd(t, &rootFlags{Timeout: 2 * time.Second}, "list")
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestFindPlaylist(t *testing.T) {
+	fc := &fakePlaylistClient{playlists: testPlaylists}
+	ctx := context.Background()
+
+	for ref, want := range map[string]string{
+		"SQ:3":        "SQ:3",
+		"morning":     "SQ:1",
+		"dinner jazz": "SQ:2",
+		"party":       "SQ:3",
+	} {
+		got, err := findPlaylist(ctx, fc, ref)
+		if err != nil {
+			t.Fatalf("findPlaylist(%q): %v", ref, err)
+		}
+		if got.ID != want {
+			t.Fatalf("findPlaylist(%q) = %s, want %s", ref, got.ID, want)
+		}
+	}
+	if _, err := findPlaylist(ctx, fc, "dinner"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguous error, got %v", err)
+	}
+	if _, err := findPlaylist(ctx, fc, "nope"); err == nil {
+		t.Fatalf("expected not found error")
+	}
+
+	if got, err := findPlaylistExact(ctx, fc, "dinner JAZZ"); err != nil || got.ID != "SQ:2" {
+		t.Fatalf("findPlaylistExact(dinner JAZZ) = %s, %v", got.ID, err)
+	}
+	if _, err := findPlaylistExact(ctx, fc, "party"); err == nil || !strings.Contains(err.Error(), "Dinner Party") {
+		t.Fatalf("expected exact-match error, got %v", err)
+	}
+}
+
+func TestPlaylistDeleteRejectsPartialTitle(t *testing.T) {
+	fc := &fakePlaylistClient{playlists: testPlaylists}
+	withFakePlaylistClient(t, fc)
+	if _, err := runPlaylistCmd(t, &rootFlags{Name: "Kitchen", Timeout: 2 * time.Second}, "delete", "party"); err == nil {
+		t.Fatalf("expected error")
+	}
+	if fc.deleted != "" {
+		t.Fatalf("deleted %q from a partial title", fc.deleted)
+	}
+}
+
+func TestPlaylistEditCommands(t *testing.T) {
+	fc := &fakePlaylistClient{playlists: testPlaylists}
+	withFakePlaylistClient(t, fc)
+	flags := &rootFlags{Name: "Kitchen", Timeout: 2 * time.Second}
+
+	if _, err := runPlaylistCmd(t, flags, "create", "Road Trip"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if fc.created != "Road Trip" {
+		t.Fatalf("created = %q", fc.created)
+	}
+	if _, err := runPlaylistCmd(t, flags, "add", "Morning", "x-file-cifs://a.mp3", "x-file-cifs://b.mp3"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if !reflect.DeepEqual(fc.added, []string{"x-file-cifs://a.mp3", "x-file-cifs://b.mp3"}) {
+		t.Fatalf("added = %v", fc.added)
+	}
+	if _, err := runPlaylistCmd(t, flags, "remove", "Morning", "3", "1"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if !reflect.DeepEqual(fc.removed, []int{3, 1}) {
+		t.Fatalf("removed = %v", fc.removed)
+	}
+	if _, err := runPlaylistCmd(t, flags, "remove", "Morning", "0"); err == nil {
+		t.Fatalf("expected error for position 0")
+	}
+	if _, err := runPlaylistCmd(t, flags, "reorder", "Morning", "4", "2"); err != nil {
+		t.Fatalf("reorder: %v", err)
+	}
+	if fc.reorder != [2]int{4, 2} {
+		t.Fatalf("reorder = %v", fc.reorder)
+	}
+	if _, err := runPlaylistCmd(t, flags, "delete", "SQ:2"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if fc.deleted != "SQ:2" {
+		t.Fatalf("deleted = %q", fc.deleted)
+	}
+}
+
+func TestPlaylistPlayReplacesQueue(t *testing.T) {
+	fc := &fakePlaylistClient{playlists: testPlaylists, enqueueFrom: 1}
+	withFakePlaylistClient(t, fc)
+
+	out, err := runPlaylistCmd(t, &rootFlags{Name: "Kitchen", Timeout: 2 * time.Second, Format: formatJSON}, "play", "morning")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fc.clearCalls != 1 || fc.enqueued.ID != "SQ:1" || fc.playedPos != 1 {
+		t.Fatalf("unexpected calls: %+v", fc)
+	}
+	var res map[string]any
+	if err := json.Unmarshal([]byte(out), &res); err != nil {
+		t.Fatalf("json: %v (%q)", err, out)
+	}
+	if res["action"] != "playlist.play" {
+		t.Fatalf("unexpected json: %v", res)
+	}
+}
+
+func TestPlaylistPlayEnqueueKeepsQueue(t *testing.T) {
+	fc := &fakePlaylistClient{playlists: testPlaylists, enqueueFrom: 7}
+	withFakePlaylistClient(t, fc)
+
+	if _, err := runPlaylistCmd(t, &rootFlags{Name: "Kitchen", Timeout: 2 * time.Second}, "play", "--enqueue", "SQ:3"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fc.clearCalls != 0 || fc.playedPos != 0 || fc.enqueued.ID != "SQ:3" {
+		t.Fatalf("unexpected calls: %+v", fc)
+	}
+}
+
+func TestQueueSave(t *testing.T) {
+	fc := &fakePlaylistClient{}
+	withFakePlaylistClient(t, fc)
+
+	cmd := newQueueSaveCmd(&rootFlags{Name: "Kitchen", Timeout: 2 * time.Second})
+	cmd.SetArgs([]string{"Saturday"})
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fc.saved != "Saturday" || !strings.Contains(out.String(), "SQ:9") {
+		t.Fatalf("saved=%q out=%q", fc.saved, out.String())
+	}
+}
@@ -1,10 +1,16 @@
 package cli
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
 	"strconv"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
@@ -16,6 +22,10 @@ type queueClient interface {
 	ClearQueue(ctx context.Context) error
 	RemoveQueuePosition(ctx context.Context, position int) error
 	PlayQueuePosition(ctx context.Context, position int) error
+	MoveQueueTracks(ctx context.Context, from, count, to int) error
+	EnqueueURIs(ctx context.Context, entries []sonos.QueueEntry) (first, added int, err error)
+	EnqueueSpotify(ctx context.Context, input string, opts sonos.EnqueueOptions) (int, error)
+	ListQueueTracks(ctx context.Context) ([]sonos.QueueTrack, error)
 }
 
 var newQueueClient = func(ctx context.Context, flags *rootFlags) (queueClient, error) {
@@ -31,6 +41,12 @@ func newQueueCmd(flags *rootFlags) *cobra.Command {
 	cmd.AddCommand(newQueueClearCmd(flags))
 	cmd.AddCommand(newQueuePlayCmd(flags))
 	cmd.AddCommand(newQueueRemoveCmd(flags))
+	cmd.AddCommand(newQueueMoveCmd(flags))
+	cmd.AddCommand(newQueueMoveRangeCmd(flags))
+	cmd.AddCommand(newQueueAddCmd(flags))
+	cmd.AddCommand(newQueueExportCmd(flags))
+	cmd.AddCommand(newQueueImportCmd(flags))
+	cmd.AddCommand(newQueueSaveCmd(flags))
 	return cmd
 }
 
@@ -167,3 +183,202 @@ func newQueueRemoveCmd(flags *rootFlags) *cobra.Command {
 	}
 	return cmd
 }
+
+func newQueueSaveCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "save <name>",
+		Short:        "Save the queue as a Sonos playlist",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			name := strings.TrimSpace(args[0])
+			if name == "" {
+				return errors.New("name is required")
+			}
+			c, err := newPlaylistClient(cmd.Context(), flags)
+			if err != nil {
+				return err
+			}
+			id, err := c.SaveQueue(cmd.Context(), name)
+			if err != nil {
+				return err
+			}
+			writePlainLine(cmd, flags, fmt.Sprintf("Saved queue as %q (%s)", name, id))
+			return writeOK(cmd, flags, "queue.save", map[string]any{"id": id, "title": name})
+		},
+	}
+	return cmd
+}
+
+func newQueueMoveCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "move <from> <to>",
+		Short:        "Move a queue entry (1-based)",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			from, err := parseQueuePosition("from", args[0])
+			if err != nil {
+				return err
+			}
+			to, err := parseQueuePosition("to", args[1])
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := newQueueClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			if err := c.MoveQueueTracks(ctx, from, 1, to); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "queue.move", map[string]any{"from": from, "to": to})
+		},
+	}
+	return cmd
+}
+
+func newQueueMoveRangeCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "move-range <start> <end> <to>",
+		Short:        "Move queue entries start..end so that start ends up at to (1-based, inclusive)",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			start, err := parseQueuePosition("start", args[0])
+			if err != nil {
+				return err
+			}
+			end, err := parseQueuePosition("end", args[1])
+			if err != nil {
+				return err
+			}
+			to, err := parseQueuePosition("to", args[2])
+			if err != nil {
+				return err
+			}
+			if end < start {
+				return errors.New("end must be >= start")
+			}
+			ctx := cmd.Context()
+			c, err := newQueueClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			if err := c.MoveQueueTracks(ctx, start, end-start+1, to); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "queue.moveRange", map[string]any{"start": start, "end": end, "to": to})
+		},
+	}
+	return cmd
+}
+
+func newQueueAddCmd(flags *rootFlags) *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:          "add [uri...]",
+		Short:        "Append URIs to the queue",
+		Long:         "Appends URIs to the queue in batches (AVTransport AddMultipleURIsToQueue). URIs come from the arguments and/or --file (one per line; blank lines and #comments are skipped; use - for stdin).",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			uris := append([]string(nil), args...)
+			if file != "" {
+				var r io.Reader = cmd.InOrStdin()
+				if file != "-" {
+					f, err := os.Open(file)
+					if err != nil {
+						return err
+					}
+					defer f.Close()
+					r = f
+				}
+				lines, err := readURIList(r)
+				if err != nil {
+					return err
+				}
+				uris = append(uris, lines...)
+			}
+			entries := make([]sonos.QueueEntry, 0, len(uris))
+			for _, u := range uris {
+				if u = strings.TrimSpace(u); u != "" {
+					entries = append(entries, queueEntryForURI(u))
+				}
+			}
+			if len(entries) == 0 {
+				return errors.New("provide URIs as arguments or via --file")
+			}
+
+			ctx := cmd.Context()
+			c, err := newQueueClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			first, added, err := c.EnqueueURIs(ctx, entries)
+			if err != nil {
+				return err
+			}
+			writePlainLine(cmd, flags, fmt.Sprintf("Added %d tracks (first position %d)", added, first))
+			return writeOK(cmd, flags, "queue.add", map[string]any{"first": first, "added": added})
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Read URIs from a file, one per line (- for stdin)")
+	return cmd
+}
+
+func parseQueuePosition(name, s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s must be an integer (1-based)", name)
+	}
+	return n, nil
+}
+
+// readURIList reads one URI per line, skipping blank lines and # comments
+// (so simple M3U files work as-is).
+func readURIList(r io.Reader) ([]string, error) {
+	var out []string
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		out = append(out, line)
+	}
+	return out, sc.Err()
+}
+
+// queueEntryForURI builds track DIDL for a bare URI, using the last path
+// segment as the title and the file extension for the MIME type.
+func queueEntryForURI(uri string) sonos.QueueEntry {
+	title := uri
+	mimeType := ""
+	if u, err := url.Parse(uri); err == nil && u.Path != "" {
+		base := path.Base(u.Path)
+		if base != "/" && base != "." {
+			title = strings.TrimSuffix(base, path.Ext(base))
+		}
+		mimeType, _ = audioMIMEType(u.Path)
+	}
+	return sonos.QueueEntry{
+		URI:  uri,
+		Meta: sonos.BuildTrackMeta(sonos.DIDLItem{Title: title, URI: uri}, mimeType),
+	}
+}
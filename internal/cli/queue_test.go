@@ -17,6 +17,10 @@ type fakeQueueClient struct {
 	removeCalls  int
 	playCalls    int
 	lastPosition int
+	moves        [][3]int
+	enqueued     []sonos.QueueEntry
+	spotify      []string
+	tracks       []sonos.QueueTrack
 	err          error
 }
 
@@ -42,6 +46,25 @@ func (f *fakeQueueClient) PlayQueuePosition(ctx context.Context, position int) e
 	return f.err
 }
 
+func (f *fakeQueueClient) MoveQueueTracks(ctx context.Context, from, count, to int) error {
+	f.moves = append(f.moves, [3]int{from, count, to})
+	return f.err
+}
+
+func (f *fakeQueueClient) EnqueueURIs(ctx context.Context, entries []sonos.QueueEntry) (int, int, error) {
+	f.enqueued = append(f.enqueued, entries...)
+	return 3, len(entries), f.err
+}
+
+func (f *fakeQueueClient) EnqueueSpotify(ctx context.Context, input string, opts sonos.EnqueueOptions) (int, error) {
+	f.spotify = append(f.spotify, input)
+	return 1, f.err
+}
+
+func (f *fakeQueueClient) ListQueueTracks(ctx context.Context) ([]sonos.QueueTrack, error) {
+	return f.tracks, f.err
+}
+
 func TestQueueListRequiresTarget(t *testing.T) {
 	flags := &rootFlags{Timeout: 2 * time.Second}
 	cmd := newQueueListCmd(flags)
@@ -175,3 +198,66 @@ func TestQueueClientErrorPropagates(t *testing.T) {
 		t.Fatalf("expected boom, got %v", err)
 	}
 }
+
+func runQueueCmd(t *testing.T, fc *fakeQueueClient, stdin string, args ...string) (string, error) {
+	t.Helper()
+	orig := newQueueClient
+	t.Cleanup(func() { newQueueClient = orig })
+	newQueueClient = func(ctx context.Context, flags *rootFlags) (queueClient, error) { return fc, nil }
+
+	cmd := newQueueCmd(&rootFlags{Name: "Kitchen", Timeout: 2 * time.Second})
+	cmd.SetArgs(args)
+	cmd.SetIn(strings.NewReader(stdin))
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestQueueMoveAndMoveRange(t *testing.T) {
+	fc := &fakeQueueClient{}
+	if _, err := runQueueCmd(t, fc, "", "move", "5", "2"); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if _, err := runQueueCmd(t, fc, "", "move-range", "3", "6", "1"); err != nil {
+		t.Fatalf("move-range: %v", err)
+	}
+	if len(fc.moves) != 2 || fc.moves[0] != [3]int{5, 1, 2} || fc.moves[1] != [3]int{3, 4, 1} {
+		t.Fatalf("moves = %v", fc.moves)
+	}
+	if _, err := runQueueCmd(t, fc, "", "move", "0", "2"); err == nil {
+		t.Fatalf("expected error for position 0")
+	}
+	if _, err := runQueueCmd(t, fc, "", "move-range", "6", "3", "1"); err == nil {
+		t.Fatalf("expected error for end < start")
+	}
+}
+
+func TestQueueAddFromArgsAndStdin(t *testing.T) {
+	fc := &fakeQueueClient{}
+	stdin := "#EXTM3U\n\nhttp://192.0.2.9/music/Song%20Two.flac\n  http://192.0.2.9/three.mp3  \n"
+	out, err := runQueueCmd(t, fc, stdin, "add", "http://192.0.2.9/one.mp3", "--file", "-")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if len(fc.enqueued) != 3 {
+		t.Fatalf("enqueued = %+v", fc.enqueued)
+	}
+	if fc.enqueued[2].URI != "http://192.0.2.9/three.mp3" {
+		t.Fatalf("unexpected URI: %q", fc.enqueued[2].URI)
+	}
+	meta := fc.enqueued[1].Meta
+	if !strings.Contains(meta, "<dc:title>Song Two</dc:title>") || !strings.Contains(meta, "audio/flac") {
+		t.Fatalf("unexpected meta: %s", meta)
+	}
+	if !strings.Contains(out, "Added 3 tracks") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	if _, err := runQueueCmd(t, &fakeQueueClient{}, "", "add"); err == nil {
+		t.Fatalf("expected error without URIs")
+	}
+}
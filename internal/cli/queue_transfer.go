@@ -0,0 +1,453 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+// Queue files keep each entry's URI plus its DIDL metadata, so items from
+// music services can be enqueued again. M3U stores the DIDL base64-encoded in
+// a #SONOSDIDL: line, XSPF in a <meta rel="..."> element.
+const (
+	queueFileM3U  = "m3u"
+	queueFileXSPF = "xspf"
+	queueFileJSON = "json"
+
+	m3uDIDLPrefix = "#SONOSDIDL:"
+	xspfDIDLRel   = "urn:sonoscli:didl"
+)
+
+type queueFile struct {
+	Version int              `json:"version"`
+	Tracks  []queueFileTrack `json:"tracks"`
+}
+
+type queueFileTrack struct {
+	URI    string `json:"uri"`
+	Title  string `json:"title,omitempty"`
+	Artist string `json:"artist,omitempty"`
+	Album  string `json:"album,omitempty"`
+	Meta   string `json:"meta,omitempty"`
+
+	line    int    // source line (M3U) or entry number (JSON/XSPF), for error reports
+	invalid string // set when the entry could not be parsed
+}
+
+type xspfPlaylist struct {
+	XMLName xml.Name    `xml:"playlist"`
+	XMLNS   string      `xml:"xmlns,attr,omitempty"`
+	Version string      `xml:"version,attr"`
+	Tracks  []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Location string     `xml:"location"`
+	Title    string     `xml:"title,omitempty"`
+	Creator  string     `xml:"creator,omitempty"`
+	Album    string     `xml:"album,omitempty"`
+	Meta     []xspfMeta `xml:"meta,omitempty"`
+}
+
+type xspfMeta struct {
+	Rel   string `xml:"rel,attr"`
+	Value string `xml:",chardata"`
+}
+
+type queueImportIssue struct {
+	Line  int    `json:"line"`
+	Input string `json:"input"`
+	Error string `json:"error"`
+}
+
+func newQueueExportCmd(flags *rootFlags) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:          "export",
+		Short:        "Export the queue as M3U, XSPF or JSON",
+		Long:         "Writes the whole queue to stdout. Each entry keeps its URI and DIDL metadata, so `sonos queue import` can restore music-service items in another room or household.",
+		Example:      "  sonos queue export --name \"Kitchen\" > kitchen.m3u\n  sonos queue export --name \"Kitchen\" --format xspf > kitchen.xspf",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			format = strings.ToLower(strings.TrimSpace(format))
+			switch format {
+			case queueFileM3U, queueFileXSPF, queueFileJSON:
+			default:
+				return fmt.Errorf("invalid --format %q (use m3u, xspf or json)", format)
+			}
+
+			ctx := cmd.Context()
+			c, err := newQueueClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			queue, err := c.ListQueueTracks(ctx)
+			if err != nil {
+				return err
+			}
+			tracks := make([]queueFileTrack, 0, len(queue))
+			for _, qt := range queue {
+				tracks = append(tracks, queueFileTrack{
+					URI:    qt.Item.URI,
+					Title:  qt.Item.Title,
+					Artist: qt.Item.Artist,
+					Album:  qt.Item.Album,
+					Meta:   qt.Meta,
+				})
+			}
+			return writeQueueFile(cmd.OutOrStdout(), format, tracks)
+		},
+	}
+
+	// Shadows the global --format: the output here is a file, not a report.
+	cmd.Flags().StringVar(&format, "format", queueFileM3U, "File format: m3u|xspf|json")
+	return cmd
+}
+
+func newQueueImportCmd(flags *rootFlags) *cobra.Command {
+	var replace bool
+
+	cmd := &cobra.Command{
+		Use:          "import <file|->",
+		Short:        "Append entries from an M3U, XSPF or JSON file to the queue",
+		Long:         "Reads a file written by `sonos queue export` (or any M3U/XSPF file or plain URI list) and enqueues its entries. Spotify URIs/links are enqueued like `sonos enqueue`. Entries that cannot be resolved are reported and skipped.",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			var data []byte
+			var err error
+			if args[0] == "-" {
+				data, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return err
+			}
+			tracks, err := parseQueueFile(data, detectQueueFileFormat(args[0], data))
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			c, err := newQueueClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			if replace {
+				if err := c.ClearQueue(ctx); err != nil {
+					return err
+				}
+			}
+			first, added, issues := importQueueTracks(ctx, c, tracks)
+
+			if isJSON(flags) {
+				if issues == nil {
+					issues = []queueImportIssue{}
+				}
+				if err := writeOK(cmd, flags, "queue.import", map[string]any{"ok": added > 0 || len(issues) == 0, "first": first, "added": added, "unresolved": issues}); err != nil {
+					return err
+				}
+			} else {
+				for _, is := range issues {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "line %d: %s: %s\n", is.Line, is.Input, is.Error)
+				}
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Imported %d tracks (%d unresolved)\n", added, len(issues))
+			}
+			if added == 0 && len(issues) > 0 {
+				return errors.New("nothing imported")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&replace, "replace", false, "Clear the queue before importing")
+	return cmd
+}
+
+type queueImporter interface {
+	EnqueueURIs(ctx context.Context, entries []sonos.QueueEntry) (first, added int, err error)
+	EnqueueSpotify(ctx context.Context, input string, opts sonos.EnqueueOptions) (int, error)
+}
+
+// importQueueTracks enqueues tracks in order. Plain URIs go in batches; when a
+// batch fails, its entries are retried one by one so only the bad ones are
+// reported.
+func importQueueTracks(ctx context.Context, c queueImporter, tracks []queueFileTrack) (first, added int, issues []queueImportIssue) {
+	type pending struct {
+		track queueFileTrack
+		entry sonos.QueueEntry
+	}
+	var batch []pending
+	note := func(n, k int) {
+		if first == 0 {
+			first = n
+		}
+		added += k
+	}
+	report := func(t queueFileTrack, err error) {
+		issues = append(issues, queueImportIssue{Line: t.line, Input: t.URI, Error: err.Error()})
+	}
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		entries := make([]sonos.QueueEntry, 0, len(batch))
+		for _, p := range batch {
+			entries = append(entries, p.entry)
+		}
+		if n, k, err := c.EnqueueURIs(ctx, entries); err == nil {
+			note(n, k)
+		} else {
+			for _, p := range batch {
+				n, k, err := c.EnqueueURIs(ctx, []sonos.QueueEntry{p.entry})
+				if err != nil {
+					report(p.track, err)
+					continue
+				}
+				note(n, k)
+			}
+		}
+		batch = batch[:0]
+	}
+
+	for _, t := range tracks {
+		entry, spotify, err := resolveQueueTrack(t)
+		if err != nil {
+			report(t, err)
+			continue
+		}
+		if spotify {
+			flush()
+			n, err := c.EnqueueSpotify(ctx, t.URI, sonos.EnqueueOptions{Title: t.Title})
+			if err != nil {
+				report(t, err)
+				continue
+			}
+			note(n, 1)
+			continue
+		}
+		batch = append(batch, pending{track: t, entry: entry})
+		if len(batch) == sonos.MaxURIsPerAdd {
+			flush()
+		}
+	}
+	flush()
+	return first, added, issues
+}
+
+// resolveQueueTrack turns a file entry into something the speaker can enqueue.
+// spotify is true for Spotify URIs/links, which need the Sonos share DIDL.
+func resolveQueueTrack(t queueFileTrack) (entry sonos.QueueEntry, spotify bool, err error) {
+	if t.invalid != "" {
+		return sonos.QueueEntry{}, false, errors.New(t.invalid)
+	}
+	uri := strings.TrimSpace(t.URI)
+	if uri == "" {
+		return sonos.QueueEntry{}, false, errors.New("missing URI")
+	}
+	lower := strings.ToLower(uri)
+	if t.Meta == "" && (strings.HasPrefix(lower, "spotify:") || strings.Contains(lower, "open.spotify.com/")) {
+		if _, ok := sonos.ParseSpotifyRef(uri); !ok {
+			return sonos.QueueEntry{}, false, errors.New("unsupported Spotify reference")
+		}
+		return sonos.QueueEntry{}, true, nil
+	}
+	u, err := url.Parse(uri)
+	if err != nil || len(u.Scheme) <= 1 {
+		return sonos.QueueEntry{}, false, errors.New("not a URI the speaker can fetch (use `sonos play-file` for local files)")
+	}
+	if t.Meta != "" {
+		if _, err := sonos.ParseDIDLItems(t.Meta); err != nil {
+			return sonos.QueueEntry{}, false, fmt.Errorf("invalid DIDL metadata: %w", err)
+		}
+		return sonos.QueueEntry{URI: uri, Meta: t.Meta}, false, nil
+	}
+	if t.Title == "" {
+		return queueEntryForURI(uri), false, nil
+	}
+	mimeType, _ := audioMIMEType(u.Path)
+	meta := sonos.BuildTrackMeta(sonos.DIDLItem{Title: t.Title, Artist: t.Artist, Album: t.Album, URI: uri}, mimeType)
+	return sonos.QueueEntry{URI: uri, Meta: meta}, false, nil
+}
+
+func writeQueueFile(w io.Writer, format string, tracks []queueFileTrack) error {
+	switch format {
+	case queueFileJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if tracks == nil {
+			tracks = []queueFileTrack{}
+		}
+		return enc.Encode(queueFile{Version: 1, Tracks: tracks})
+	case queueFileXSPF:
+		pl := xspfPlaylist{XMLNS: "http://xspf.org/ns/0/", Version: "1"}
+		for _, t := range tracks {
+			xt := xspfTrack{Location: t.URI, Title: t.Title, Creator: t.Artist, Album: t.Album}
+			if t.Meta != "" {
+				xt.Meta = []xspfMeta{{Rel: xspfDIDLRel, Value: t.Meta}}
+			}
+			pl.Tracks = append(pl.Tracks, xt)
+		}
+		if _, err := io.WriteString(w, xml.Header); err != nil {
+			return err
+		}
+		enc := xml.NewEncoder(w)
+		enc.Indent("", "  ")
+		if err := enc.Encode(pl); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, "\n")
+		return err
+	default:
+		bw := bufio.NewWriter(w)
+		_, _ = bw.WriteString("#EXTM3U\n")
+		for _, t := range tracks {
+			name := t.Title
+			if t.Artist != "" && name != "" {
+				name = t.Artist + " - " + name
+			}
+			if name != "" {
+				_, _ = fmt.Fprintf(bw, "#EXTINF:-1,%s\n", oneLine(name))
+			}
+			if t.Meta != "" {
+				_, _ = bw.WriteString(m3uDIDLPrefix + base64.StdEncoding.EncodeToString([]byte(t.Meta)) + "\n")
+			}
+			_, _ = bw.WriteString(t.URI + "\n")
+		}
+		return bw.Flush()
+	}
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// detectQueueFileFormat uses the file extension and falls back to sniffing
+// the content; anything unrecognized is read as M3U/plain URI list.
+func detectQueueFileFormat(name string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return queueFileJSON
+	case ".xspf":
+		return queueFileXSPF
+	case ".m3u", ".m3u8", ".txt":
+		return queueFileM3U
+	}
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")), bytes.HasPrefix(trimmed, []byte("[")):
+		return queueFileJSON
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		return queueFileXSPF
+	default:
+		return queueFileM3U
+	}
+}
+
+func parseQueueFile(data []byte, format string) ([]queueFileTrack, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	switch format {
+	case queueFileJSON:
+		var tracks []queueFileTrack
+		trimmed := bytes.TrimSpace(data)
+		if bytes.HasPrefix(trimmed, []byte("[")) {
+			if err := json.Unmarshal(trimmed, &tracks); err != nil {
+				return nil, fmt.Errorf("parse JSON queue file: %w", err)
+			}
+		} else {
+			var f queueFile
+			if err := json.Unmarshal(trimmed, &f); err != nil {
+				return nil, fmt.Errorf("parse JSON queue file: %w", err)
+			}
+			tracks = f.Tracks
+		}
+		for i := range tracks {
+			tracks[i].line = i + 1
+		}
+		return tracks, nil
+	case queueFileXSPF:
+		var pl xspfPlaylist
+		if err := xml.Unmarshal(data, &pl); err != nil {
+			return nil, fmt.Errorf("parse XSPF queue file: %w", err)
+		}
+		tracks := make([]queueFileTrack, 0, len(pl.Tracks))
+		for i, xt := range pl.Tracks {
+			t := queueFileTrack{
+				URI:    strings.TrimSpace(xt.Location),
+				Title:  xt.Title,
+				Artist: xt.Creator,
+				Album:  xt.Album,
+				line:   i + 1,
+			}
+			for _, m := range xt.Meta {
+				if m.Rel == xspfDIDLRel {
+					t.Meta = strings.TrimSpace(m.Value)
+				}
+			}
+			tracks = append(tracks, t)
+		}
+		return tracks, nil
+	default:
+		return parseM3U(data)
+	}
+}
+
+func parseM3U(data []byte) ([]queueFileTrack, error) {
+	var tracks []queueFileTrack
+	var next queueFileTrack
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#EXTINF:"):
+			if i := strings.IndexByte(line, ','); i >= 0 {
+				name := strings.TrimSpace(line[i+1:])
+				if artist, title, ok := strings.Cut(name, " - "); ok {
+					next.Artist, next.Title = artist, title
+				} else {
+					next.Title = name
+				}
+			}
+		case strings.HasPrefix(line, m3uDIDLPrefix):
+			meta, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(line, m3uDIDLPrefix))
+			if err != nil {
+				next.invalid = "invalid " + strings.TrimSuffix(m3uDIDLPrefix, ":") + " payload"
+				continue
+			}
+			next.Meta = string(meta)
+		case strings.HasPrefix(line, "#"):
+		default:
+			next.URI = line
+			next.line = lineNo
+			tracks = append(tracks, next)
+			next = queueFileTrack{}
+		}
+	}
+	return tracks, sc.Err()
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+const testServiceDIDL = `<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/"><item id="Q:0/1" parentID="Q:0"><dc:title>Song &amp; Co</dc:title><desc id="cdudn" nameSpace="urn:schemas-rinconnetworks-com:metadata-1-0/">SA_RINCON2311_X_#Svc2311-0-Token</desc></item></DIDL-Lite>`
+
+var testQueueTracks = []sonos.QueueTrack{
+	{Position: 1, Item: sonos.DIDLItem{Title: "Song & Co", Artist: "Band", URI: "x-sonos-spotify:spotify%3atrack%3a1?sid=9&flags=8224&sn=2"}, Meta: testServiceDIDL},
+	{Position: 2, Item: sonos.DIDLItem{Title: "Local", URI: "x-file-cifs://nas/music/local.mp3"}},
+}
+
+func TestQueueExportImportRoundTrip(t *testing.T) {
+	for _, format := range []string{queueFileM3U, queueFileXSPF, queueFileJSON} {
+		t.Run(format, func(t *testing.T) {
+			out, err := runQueueCmd(t, &fakeQueueClient{tracks: testQueueTracks}, "", "export", "--format", format)
+			if err != nil {
+				t.Fatalf("export: %v", err)
+			}
+			if got := detectQueueFileFormat("-", []byte(out)); got != format {
+				t.Fatalf("detected %q, want %q", got, format)
+			}
+
+			fc := &fakeQueueClient{}
+			if _, err := runQueueCmd(t, fc, out, "import", "-"); err != nil {
+				t.Fatalf("import: %v", err)
+			}
+			if len(fc.enqueued) != 2 {
+				t.Fatalf("enqueued = %+v", fc.enqueued)
+			}
+			if fc.enqueued[0].URI != testQueueTracks[0].Item.URI || fc.enqueued[0].Meta != testServiceDIDL {
+				t.Fatalf("service item not preserved: %+v", fc.enqueued[0])
+			}
+			if !strings.Contains(fc.enqueued[1].Meta, "<dc:title>Local</dc:title>") {
+				t.Fatalf("unexpected built meta: %s", fc.enqueued[1].Meta)
+			}
+		})
+	}
+}
+
+func TestQueueImportReportsUnresolvedLines(t *testing.T) {
+	m3u := strings.Join([]string{
+		"#EXTM3U",
+		"http://192.0.2.9/a.mp3",
+		"Music/local.mp3",
+		"spotify:track:6NmXV4o6bmp704aPGyTVVG",
+		"#SONOSDIDL:!!!",
+		"http://192.0.2.9/b.mp3",
+		"http://192.0.2.9/c.mp3",
+	}, "\n")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "q.m3u")
+	if err := os.WriteFile(path, []byte(m3u), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fc := &fakeQueueClient{}
+	var stderr bytes.Buffer
+	orig := newQueueClient
+	t.Cleanup(func() { newQueueClient = orig })
+	newQueueClient = func(ctx context.Context, flags *rootFlags) (queueClient, error) { return fc, nil }
+	cmd := newQueueCmd(&rootFlags{Name: "Kitchen"})
+	cmd.SetArgs([]string{"import", path})
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(&stderr)
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	if len(fc.spotify) != 1 || len(fc.enqueued) != 2 {
+		t.Fatalf("spotify=%v enqueued=%+v", fc.spotify, fc.enqueued)
+	}
+	if !strings.Contains(stderr.String(), "line 3: Music/local.mp3") || !strings.Contains(stderr.String(), "line 6: http://192.0.2.9/b.mp3") {
+		t.Fatalf("unexpected report: %q", stderr.String())
+	}
+	if !strings.Contains(out.String(), "Imported 3 tracks (2 unresolved)") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+type failingImporter struct {
+	bad   string
+	calls int
+}
+
+func (f *failingImporter) EnqueueURIs(ctx context.Context, entries []sonos.QueueEntry) (int, int, error) {
+	f.calls++
+	for _, e := range entries {
+		if e.URI == f.bad {
+			return 0, 0, errors.New("UPnP error 800")
+		}
+	}
+	return 1, len(entries), nil
+}
+
+func (f *failingImporter) EnqueueSpotify(ctx context.Context, input string, opts sonos.EnqueueOptions) (int, error) {
+	return 0, errors.New("unexpected")
+}
+
+func TestImportQueueTracksRetriesFailedBatchOneByOne(t *testing.T) {
+	tracks := []queueFileTrack{
+		{URI: "http://h/1.mp3", line: 1},
+		{URI: "http://h/2.mp3", line: 2},
+		{URI: "http://h/3.mp3", line: 3},
+	}
+	fi := &failingImporter{bad: "http://h/2.mp3"}
+	_, added, issues := importQueueTracks(context.Background(), fi, tracks)
+	if added != 2 || len(issues) != 1 || issues[0].Line != 2 || fi.calls != 4 {
+		t.Fatalf("added=%d issues=%+v calls=%d", added, issues, fi.calls)
+	}
+}
+
+func TestQueueImportJSONOutput(t *testing.T) {
+	orig := newQueueClient
+	t.Cleanup(func() { newQueueClient = orig })
+	fc := &fakeQueueClient{}
+	newQueueClient = func(ctx context.Context, flags *rootFlags) (queueClient, error) { return fc, nil }
+
+	cmd := newQueueCmd(&rootFlags{Name: "Kitchen", Format: formatJSON})
+	cmd.SetArgs([]string{"import", "--replace", "-"})
+	cmd.SetIn(strings.NewReader(`{"version":1,"tracks":[{"uri":"http://h/1.mp3","title":"One"},{"uri":""}]}`))
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	var res struct {
+		OK         bool               `json:"ok"`
+		Added      int                `json:"added"`
+		Unresolved []queueImportIssue `json:"unresolved"`
+	}
+	if err := json.Unmarshal([]byte(out.String()), &res); err != nil {
+		t.Fatalf("json: %v (%q)", err, out.String())
+	}
+	if !res.OK || res.Added != 1 || len(res.Unresolved) != 1 || res.Unresolved[0].Line != 2 || fc.clearCalls != 1 {
+		t.Fatalf("unexpected result: %+v clear=%d", res, fc.clearCalls)
+	}
+}
@@ -66,24 +66,7 @@ func newRootCmd() (*cobra.Command, *rootFlags, error) {
 	rootCmd.SetVersionTemplate("sonos {{.Version}}\n")
 
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-		if flags.Debug {
-			enableDebugLogging()
-		}
-
-		format := strings.TrimSpace(flags.Format)
-		if format == "" {
-			format = formatPlain
-		}
-		format = strings.ToLower(format)
-		if flags.JSON && format == formatPlain {
-			format = formatJSON
-		}
-		norm, err := normalizeFormat(format)
-		if err != nil {
-			return err
-		}
-		flags.Format = norm
-		return nil
+		return applyRootFlags(flags)
 	}
 
 	rootCmd.PersistentFlags().StringVar(&flags.IP, "ip", "", "Target speaker IP address")
@@ -106,6 +89,7 @@ func newRootCmd() (*cobra.Command, *rootFlags, error) {
 	rootCmd.AddCommand(newStopCmd(flags))
 	rootCmd.AddCommand(newNextCmd(flags))
 	rootCmd.AddCommand(newPrevCmd(flags))
+	rootCmd.AddCommand(newSeekCmd(flags))
 	rootCmd.AddCommand(newOpenCmd(flags))
 	rootCmd.AddCommand(newEnqueueCmd(flags))
 	rootCmd.AddCommand(newSearchCmd(flags))
@@ -113,18 +97,53 @@ func newRootCmd() (*cobra.Command, *rootFlags, error) {
 	rootCmd.AddCommand(newSMAPICmd(flags))
 	rootCmd.AddCommand(newGroupCmd(flags))
 	rootCmd.AddCommand(newSceneCmd(flags))
+	rootCmd.AddCommand(newSnapshotCmd(flags))
+	rootCmd.AddCommand(newAnnounceCmd(flags))
 	rootCmd.AddCommand(newFavoritesCmd(flags))
 	rootCmd.AddCommand(newPlayURICmd(flags))
+	rootCmd.AddCommand(newPlayFileCmd(flags))
 	rootCmd.AddCommand(newLineInCmd(flags))
 	rootCmd.AddCommand(newTVCmd(flags))
 	rootCmd.AddCommand(newQueueCmd(flags))
+	rootCmd.AddCommand(newPlaylistCmd(flags))
+	rootCmd.AddCommand(newLibraryCmd(flags))
 	rootCmd.AddCommand(newVolumeCmd(flags))
 	rootCmd.AddCommand(newMuteCmd(flags))
+	rootCmd.AddCommand(newEQCmd(flags))
+	rootCmd.AddCommand(newHTCmd(flags))
+	rootCmd.AddCommand(newDeviceCmd(flags))
+	rootCmd.AddCommand(newPairCmd(flags))
+	rootCmd.AddCommand(newModeCmd(flags))
+	rootCmd.AddCommand(newSleepCmd(flags))
+	rootCmd.AddCommand(newAlarmCmd(flags))
 	rootCmd.AddCommand(newWatchCmd(flags))
 
 	return rootCmd, flags, nil
 }
 
+// applyRootFlags enables debug logging and normalizes the output format after
+// the persistent flags have been parsed.
+func applyRootFlags(flags *rootFlags) error {
+	if flags.Debug {
+		enableDebugLogging()
+	}
+
+	format := strings.TrimSpace(flags.Format)
+	if format == "" {
+		format = formatPlain
+	}
+	format = strings.ToLower(format)
+	if flags.JSON && format == formatPlain {
+		format = formatJSON
+	}
+	norm, err := normalizeFormat(format)
+	if err != nil {
+		return err
+	}
+	flags.Format = norm
+	return nil
+}
+
 func nameFlagCompletion(flags *rootFlags) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
 	return func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		timeout := completionTimeoutForFlags(flags)
@@ -189,6 +189,7 @@ func newSceneSaveCmd(flags *rootFlags) *cobra.Command {
 
 func newSceneApplyCmd(flags *rootFlags) *cobra.Command {
 	var only string
+	var force bool
 
 	cmd := &cobra.Command{
 		Use:          "apply <name>",
@@ -201,6 +202,10 @@ func newSceneApplyCmd(flags *rootFlags) *cobra.Command {
 				return errors.New("scene name is required")
 			}
 
+			limiter, err := newVolumeLimiter(force)
+			if err != nil {
+				return err
+			}
 			store, err := newSceneStore()
 			if err != nil {
 				return err
@@ -329,7 +334,8 @@ func newSceneApplyCmd(flags *rootFlags) *cobra.Command {
 				}
 			}
 
-			// Step 3: restore per-device volume/mute.
+			// Step 3: restore per-device volume/mute, held to volume limits.
+			limited := map[string]int{}
 			for _, dev := range scene.Devices {
 				if !involved[dev.UUID] || !isVisible(dev.UUID) {
 					continue
@@ -341,16 +347,29 @@ func newSceneApplyCmd(flags *rootFlags) *cobra.Command {
 				if ip == "" {
 					continue
 				}
+				room := dev.Name
+				if m, ok := uuidToMember[dev.UUID]; ok && m.Name != "" {
+					room = m.Name
+				}
+				vol, wasLimited := clampVolume(cmd, limiter.limit(room), room, dev.Volume)
+				if wasLimited {
+					limited[room] = vol
+				}
 				c := newSceneSpeakerClient(ip, flags.Timeout)
 				_ = c.SetMute(cmd.Context(), dev.Mute)
-				_ = c.SetVolume(cmd.Context(), dev.Volume)
+				_ = c.SetVolume(cmd.Context(), vol)
 			}
 
-			return writeOK(cmd, flags, "scene.apply", map[string]any{"name": scene.Name, "only": strings.TrimSpace(only)})
+			out := map[string]any{"name": scene.Name, "only": strings.TrimSpace(only)}
+			if len(limited) > 0 {
+				out["limited"] = limited
+			}
+			return writeOK(cmd, flags, "scene.apply", out)
 		},
 	}
 
 	cmd.Flags().StringVar(&only, "only", "", "Only apply to a single room name (experimental)")
+	cmd.Flags().BoolVar(&force, "force", false, "Ignore configured volume limits")
 	return cmd
 }
 
@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"errors"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -50,6 +51,18 @@ func (f *fakeSceneStore) Delete(name string) error {
 	return nil
 }
 
+func (f *fakeSceneStore) RenameRoom(oldName, newName string) ([]string, error) {
+	var changed []string
+	for name, sc := range f.scenes {
+		if sc.RenameRoom(oldName, newName) {
+			f.scenes[name] = sc
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
 type fakeSceneTopologyGetter struct {
 	top sonos.Topology
 	err error
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type seekClient interface {
+	GetPositionInfo(ctx context.Context) (sonos.PositionInfo, error)
+	SeekTo(ctx context.Context, pos time.Duration) error
+}
+
+var newSeekClient = func(ctx context.Context, flags *rootFlags) (seekClient, error) {
+	return coordinatorClient(ctx, flags)
+}
+
+func newSeekCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "seek <position|+offset|-offset|percent%>",
+		Short: "Seek within the current track",
+		Long: "Seeks the group coordinator within the current track.\n\n" +
+			"Targets:\n" +
+			"  1:23, 1:02:03, 90s   absolute position\n" +
+			"  +30s, -15s, +1:00    relative to the current position\n" +
+			"  50%                  percentage of the track duration\n\n" +
+			"Radio streams, line-in and TV audio cannot seek.",
+		Example:      "  sonos seek --name \"Kitchen\" 1:23\n  sonos seek --name \"Kitchen\" +30s\n  sonos seek --name \"Kitchen\" -15s\n  sonos seek --name \"Kitchen\" 50%",
+		Args:         cobra.ArbitraryArgs,
+		SilenceUsage: true,
+		// Flags are parsed in parseSeekArgs so that `-15s` is not taken for a
+		// shorthand flag.
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			arg, help, err := parseSeekArgs(cmd, args)
+			if err != nil {
+				return err
+			}
+			if help {
+				return cmd.Help()
+			}
+			if err := applyRootFlags(flags); err != nil {
+				return err
+			}
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			target, err := parseSeekTarget(arg)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			c, err := newSeekClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+
+			var pos sonos.PositionInfo
+			if target.kind != seekAbsolute {
+				pos, err = c.GetPositionInfo(ctx)
+				if err != nil {
+					return err
+				}
+			}
+			to, err := target.resolve(pos)
+			if err != nil {
+				return err
+			}
+			if err := c.SeekTo(ctx, to); err != nil {
+				if errors.Is(err, sonos.ErrSeekNotSupported) {
+					return errors.New("cannot seek: the current source (radio, line-in or TV) does not support seeking")
+				}
+				return err
+			}
+			return writeOK(cmd, flags, "seek", map[string]any{
+				"position":        sonos.FormatHHMMSS(to),
+				"positionSeconds": int(to / time.Second),
+			})
+		},
+	}
+}
+
+type seekKind int
+
+const (
+	seekAbsolute seekKind = iota
+	seekRelative
+	seekPercent
+)
+
+type seekTarget struct {
+	kind    seekKind
+	offset  time.Duration // absolute position or signed relative offset
+	percent float64
+}
+
+func parseSeekTarget(s string) (seekTarget, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return seekTarget{}, errors.New("seek target is required")
+	}
+	if strings.HasSuffix(s, "%") {
+		p, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, "%")), 64)
+		if err != nil || p < 0 || p > 100 {
+			return seekTarget{}, fmt.Errorf("invalid percentage: %q (expected 0-100%%)", s)
+		}
+		return seekTarget{kind: seekPercent, percent: p}, nil
+	}
+	sign := time.Duration(0)
+	switch s[0] {
+	case '+':
+		sign = 1
+	case '-':
+		sign = -1
+	}
+	if sign != 0 {
+		d, err := parseSeekOffset(s[1:])
+		if err != nil {
+			return seekTarget{}, err
+		}
+		return seekTarget{kind: seekRelative, offset: sign * d}, nil
+	}
+	d, err := parseSeekOffset(s)
+	if err != nil {
+		return seekTarget{}, err
+	}
+	return seekTarget{kind: seekAbsolute, offset: d}, nil
+}
+
+// parseSeekOffset accepts clock notation (1:23, 1:02:03), Go durations (90s, 1m30s)
+// or bare seconds.
+func parseSeekOffset(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, ":") {
+		return sonos.ParseHHMMSS(s)
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return time.Duration(n) * time.Second, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid seek target: %q (use 1:23, +30s, -15s or 50%%)", s)
+	}
+	return d, nil
+}
+
+func (t seekTarget) resolve(pos sonos.PositionInfo) (time.Duration, error) {
+	if t.kind == seekAbsolute {
+		return t.offset, nil
+	}
+
+	duration, durErr := sonos.ParseHHMMSS(pos.TrackDuration)
+	if durErr != nil || duration <= 0 {
+		duration = 0
+	}
+
+	var to time.Duration
+	switch t.kind {
+	case seekPercent:
+		if duration == 0 {
+			return 0, errors.New("cannot seek by percentage: track duration is unknown (stream?)")
+		}
+		to = time.Duration(float64(duration) * t.percent / 100).Round(time.Second)
+	case seekRelative:
+		cur, err := sonos.ParseHHMMSS(pos.RelTime)
+		if err != nil {
+			return 0, errors.New("cannot seek relatively: current position is unknown (stream?)")
+		}
+		to = cur + t.offset
+	}
+
+	if to < 0 {
+		to = 0
+	}
+	if duration > 0 && to > duration {
+		to = duration
+	}
+	return to, nil
+}
+
+var negativeSeekArg = regexp.MustCompile(`^-[0-9]`)
+
+// parseSeekArgs parses the flags of the seek command (including the inherited
+// --name/--ip/--format) and returns its single positional target. Tokens that
+// look like a negative offset are kept as the target instead of being parsed as
+// shorthand flags.
+func parseSeekArgs(cmd *cobra.Command, args []string) (string, bool, error) {
+	fs := cmd.Flags()
+	fs.AddFlagSet(cmd.InheritedFlags())
+
+	var rest, negative []string
+	for i, a := range args {
+		if a == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		if negativeSeekArg.MatchString(a) {
+			negative = append(negative, a)
+			continue
+		}
+		rest = append(rest, a)
+	}
+	if err := fs.Parse(rest); err != nil {
+		return "", false, err
+	}
+	if help, _ := fs.GetBool("help"); help {
+		return "", true, nil
+	}
+	pos := append(fs.Args(), negative...)
+	if len(pos) != 1 {
+		return "", false, fmt.Errorf("accepts 1 arg(s), received %d", len(pos))
+	}
+	return pos[0], false, nil
+}
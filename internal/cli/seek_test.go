@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/appconfig"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakeSeekClient struct {
+	pos     sonos.PositionInfo
+	seekErr error
+	seeks   []time.Duration
+}
+
+func (f *fakeSeekClient) GetPositionInfo(ctx context.Context) (sonos.PositionInfo, error) {
+	return f.pos, nil
+}
+
+func (f *fakeSeekClient) SeekTo(ctx context.Context, pos time.Duration) error {
+	f.seeks = append(f.seeks, pos)
+	return f.seekErr
+}
+
+func runSeekCmd(t *testing.T, fake *fakeSeekClient, args ...string) (string, error) {
+	t.Helper()
+	orig := newSeekClient
+	t.Cleanup(func() { newSeekClient = orig })
+	newSeekClient = func(ctx context.Context, flags *rootFlags) (seekClient, error) {
+		return fake, nil
+	}
+
+	cmd := newSeekCmd(&rootFlags{Name: "Kitchen", Format: formatJSON})
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestSeekTargets(t *testing.T) {
+	pos := sonos.PositionInfo{RelTime: "0:01:00", TrackDuration: "0:04:00"}
+	cases := []struct {
+		arg  string
+		want time.Duration
+	}{
+		{"1:23", 83 * time.Second},
+		{"90s", 90 * time.Second},
+		{"+30s", 90 * time.Second},
+		{"-15", 45 * time.Second},
+		{"-5m", 0},
+		{"+10m", 4 * time.Minute},
+		{"50%", 2 * time.Minute},
+	}
+	for _, tc := range cases {
+		target, err := parseSeekTarget(tc.arg)
+		if err != nil {
+			t.Fatalf("parseSeekTarget(%q): %v", tc.arg, err)
+		}
+		got, err := target.resolve(pos)
+		if err != nil {
+			t.Fatalf("resolve(%q): %v", tc.arg, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%q: got %v want %v", tc.arg, got, tc.want)
+		}
+	}
+
+	for _, bad := range []string{"", "abc", "150%", "+x"} {
+		if _, err := parseSeekTarget(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+
+	target, _ := parseSeekTarget("50%")
+	if _, err := target.resolve(sonos.PositionInfo{RelTime: "NOT_IMPLEMENTED", TrackDuration: "0:00:00"}); err == nil {
+		t.Fatalf("expected error for percentage without duration")
+	}
+}
+
+func TestSeekCmdRelative(t *testing.T) {
+	fake := &fakeSeekClient{pos: sonos.PositionInfo{RelTime: "0:02:00", TrackDuration: "0:05:00"}}
+	out, err := runSeekCmd(t, fake, "--", "-15s")
+	if err != nil {
+		t.Fatalf("seek: %v", err)
+	}
+	if len(fake.seeks) != 1 || fake.seeks[0] != 105*time.Second {
+		t.Fatalf("unexpected seeks: %v", fake.seeks)
+	}
+	if !strings.Contains(out, `"position": "00:01:45"`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
+
+func TestSeekCmdUnsupportedSource(t *testing.T) {
+	fake := &fakeSeekClient{seekErr: fmt.Errorf("%w (upnp error 701)", sonos.ErrSeekNotSupported)}
+	_, err := runSeekCmd(t, fake, "1:00")
+	if err == nil || !strings.Contains(err.Error(), "does not support seeking") || strings.Contains(err.Error(), "701") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSeekCmdNegativeOffsetViaRoot(t *testing.T) {
+	origCfg, origClient := loadAppConfig, newSeekClient
+	t.Cleanup(func() {
+		loadAppConfig = origCfg
+		newSeekClient = origClient
+	})
+	loadAppConfig = func() (appconfig.Config, error) { return appconfig.Config{}.Normalize(), nil }
+	fake := &fakeSeekClient{pos: sonos.PositionInfo{RelTime: "0:02:00", TrackDuration: "0:05:00"}}
+	var gotName string
+	newSeekClient = func(ctx context.Context, flags *rootFlags) (seekClient, error) {
+		gotName = flags.Name
+		return fake, nil
+	}
+
+	root, _, err := newRootCmd()
+	if err != nil {
+		t.Fatalf("newRootCmd: %v", err)
+	}
+	var out captureWriter
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	root.SetArgs([]string{"seek", "--name", "Kitchen", "-15s", "--format", "JSON"})
+	if err := root.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("seek: %v", err)
+	}
+	if gotName != "Kitchen" || len(fake.seeks) != 1 || fake.seeks[0] != 105*time.Second {
+		t.Fatalf("name=%q seeks=%v", gotName, fake.seeks)
+	}
+	if !strings.Contains(out.String(), `"position": "00:01:45"`) {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+}
+
+func TestSeekCmdRequiresOneTarget(t *testing.T) {
+	fake := &fakeSeekClient{}
+	if _, err := runSeekCmd(t, fake, "1:00", "-15s"); err == nil {
+		t.Fatalf("expected error for two targets")
+	}
+	if _, err := runSeekCmd(t, fake); err == nil {
+		t.Fatalf("expected error without target")
+	}
+	if len(fake.seeks) != 0 {
+		t.Fatalf("unexpected seeks: %v", fake.seeks)
+	}
+}
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type sleepClient interface {
+	ConfigureSleepTimer(ctx context.Context, d time.Duration) error
+	GetRemainingSleepTimerDuration(ctx context.Context) (time.Duration, error)
+}
+
+var newSleepClient = func(ctx context.Context, flags *rootFlags) (sleepClient, error) {
+	return coordinatorClient(ctx, flags)
+}
+
+func newSleepCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sleep",
+		Short: "Get or set the sleep timer",
+		Long:  "Controls the AVTransport sleep timer on the group coordinator. Playback stops for the whole group when the timer runs out.",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:          "get",
+		Short:        "Show remaining sleep time",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := newSleepClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			d, err := c.GetRemainingSleepTimerDuration(ctx)
+			if err != nil {
+				return err
+			}
+			if isJSON(flags) {
+				return writeJSON(cmd, map[string]any{
+					"active":           d > 0,
+					"remaining":        formatSleepRemaining(d),
+					"remainingSeconds": int(d / time.Second),
+				})
+			}
+			if isTSV(flags) {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "sleep\t%s\n", formatSleepRemaining(d))
+				return nil
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), formatSleepRemaining(d))
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:          "set <duration>",
+		Short:        "Stop playback after a duration (e.g. 30m, 1h15m, 0:45:00)",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			d, err := parseSleepDuration(args[0])
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := newSleepClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			if err := c.ConfigureSleepTimer(ctx, d); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "sleep.set", map[string]any{"duration": sonos.FormatHHMMSS(d)})
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:          "off",
+		Short:        "Cancel the sleep timer",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := newSleepClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			if err := c.ConfigureSleepTimer(ctx, 0); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "sleep.off", nil)
+		},
+	})
+
+	return cmd
+}
+
+// parseDurationArg accepts Go durations (30m, 1h15m), clock values
+// (h:mm:ss or m:ss) and bare integers as minutes.
+func parseDurationArg(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("duration is required")
+	}
+	var d time.Duration
+	var err error
+	switch {
+	case strings.Contains(s, ":"):
+		d, err = sonos.ParseHHMMSS(s)
+	default:
+		if n, convErr := strconv.Atoi(s); convErr == nil {
+			d = time.Duration(n) * time.Minute
+		} else {
+			d, err = time.ParseDuration(s)
+		}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q (expected e.g. 30m, 1h15m or 0:45:00)", s)
+	}
+	return d, nil
+}
+
+func parseSleepDuration(s string) (time.Duration, error) {
+	d, err := parseDurationArg(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < time.Second {
+		return 0, errors.New("duration must be at least 1s (use `sonos sleep off` to cancel)")
+	}
+	if d >= 24*time.Hour {
+		return 0, errors.New("duration must be less than 24h")
+	}
+	return d, nil
+}
+
+func formatSleepRemaining(d time.Duration) string {
+	if d <= 0 {
+		return "off"
+	}
+	return sonos.FormatHHMMSS(d)
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeSleepClient struct {
+	remaining time.Duration
+	set       []time.Duration
+}
+
+func (f *fakeSleepClient) ConfigureSleepTimer(ctx context.Context, d time.Duration) error {
+	f.set = append(f.set, d)
+	f.remaining = d
+	return nil
+}
+
+func (f *fakeSleepClient) GetRemainingSleepTimerDuration(ctx context.Context) (time.Duration, error) {
+	return f.remaining, nil
+}
+
+func runSleepCmd(t *testing.T, flags *rootFlags, fake *fakeSleepClient, args ...string) (string, error) {
+	t.Helper()
+	orig := newSleepClient
+	t.Cleanup(func() { newSleepClient = orig })
+	newSleepClient = func(ctx context.Context, flags *rootFlags) (sleepClient, error) {
+		return fake, nil
+	}
+
+	cmd := newSleepCmd(flags)
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestSleepSetOffGet(t *testing.T) {
+	fake := &fakeSleepClient{}
+	flags := &rootFlags{Name: "Bedroom", Format: formatJSON}
+
+	out, err := runSleepCmd(t, flags, fake, "set", "30m")
+	if err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if !strings.Contains(out, `"duration": "00:30:00"`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+
+	out, err = runSleepCmd(t, &rootFlags{Name: "Bedroom", Format: formatPlain}, fake, "get")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if strings.TrimSpace(out) != "00:30:00" {
+		t.Fatalf("unexpected get output: %q", out)
+	}
+
+	if _, err := runSleepCmd(t, flags, fake, "off"); err != nil {
+		t.Fatalf("off: %v", err)
+	}
+	if len(fake.set) != 2 || fake.set[1] != 0 {
+		t.Fatalf("unexpected calls: %v", fake.set)
+	}
+
+	out, err = runSleepCmd(t, &rootFlags{Name: "Bedroom", Format: formatTSV}, fake, "get")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if out != "sleep\toff\n" {
+		t.Fatalf("unexpected tsv output: %q", out)
+	}
+}
+
+func TestParseSleepDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"30m":     30 * time.Minute,
+		"1h15m":   75 * time.Minute,
+		"0:45:00": 45 * time.Minute,
+		"45":      45 * time.Minute,
+	}
+	for in, want := range cases {
+		got, err := parseSleepDuration(in)
+		if err != nil {
+			t.Fatalf("parseSleepDuration(%q): %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("parseSleepDuration(%q) = %s, want %s", in, got, want)
+		}
+	}
+	for _, in := range []string{"", "soon", "0", "24h", "-5m"} {
+		if _, err := parseSleepDuration(in); err == nil {
+			t.Fatalf("parseSleepDuration(%q): expected error", in)
+		}
+	}
+}
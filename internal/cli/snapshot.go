@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/snapshots"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type snapshotClient interface {
+	GetTopology(ctx context.Context) (sonos.Topology, error)
+	TakeSnapshot(ctx context.Context, group sonos.Group) (sonos.Snapshot, error)
+}
+
+var newSnapshotStore = func() (snapshots.Store, error) {
+	return snapshots.NewFileStore()
+}
+
+var newSnapshotClient = func(ctx context.Context, flags *rootFlags) (snapshotClient, error) {
+	return coordinatorClient(ctx, flags)
+}
+
+var restoreSnapshot = func(ctx context.Context, snap sonos.Snapshot, timeout time.Duration) error {
+	return snap.Restore(ctx, newSonosClient(snap.CoordinatorIP, timeout))
+}
+
+func newSnapshotCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save and restore playback state",
+		Long:  "Snapshots capture what a group is playing (source, queue position, elapsed time, play mode, transport state) plus every member's volume/mute. Restoring puts it back and resumes only if the group was playing. Grouping is not changed; use scenes for that.",
+	}
+	cmd.AddCommand(newSnapshotListCmd(flags))
+	cmd.AddCommand(newSnapshotSaveCmd(flags))
+	cmd.AddCommand(newSnapshotRestoreCmd(flags))
+	cmd.AddCommand(newSnapshotDeleteCmd(flags))
+	return cmd
+}
+
+func newSnapshotListCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:          "list",
+		Short:        "List saved snapshots",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := newSnapshotStore()
+			if err != nil {
+				return err
+			}
+			metas, err := store.List()
+			if err != nil {
+				return err
+			}
+			if isJSON(flags) {
+				return writeJSON(cmd, metas)
+			}
+			if isTSV(flags) {
+				for _, m := range metas {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\t%s\n", m.ID, m.Coordinator, m.Source, formatCreatedAt(m.CreatedAt))
+				}
+				return nil
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+			_, _ = fmt.Fprintf(w, "ID\tROOM\tSOURCE\tCREATED\n")
+			for _, m := range metas {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.ID, m.Coordinator, m.Source, formatCreatedAt(m.CreatedAt))
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func newSnapshotSaveCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:          "save <id>",
+		Short:        "Snapshot the target room's group",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTarget(flags); err != nil {
+				return err
+			}
+			id := strings.TrimSpace(args[0])
+			if id == "" {
+				return errors.New("snapshot id is required")
+			}
+
+			ctx := cmd.Context()
+			store, err := newSnapshotStore()
+			if err != nil {
+				return err
+			}
+			c, err := newSnapshotClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			top, err := c.GetTopology(ctx)
+			if err != nil {
+				return err
+			}
+			mem, err := resolveMember(top, flags.Name, flags.IP)
+			if err != nil {
+				return err
+			}
+			group, ok := top.GroupForIP(mem.IP)
+			if !ok {
+				return errors.New("group not found for: " + mem.Name)
+			}
+			snap, err := c.TakeSnapshot(ctx, group)
+			if err != nil {
+				return err
+			}
+			if err := store.Put(snapshots.Entry{ID: id, CreatedAt: time.Now().UTC(), Snapshot: snap}); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "snapshot.save", map[string]any{"id": id, "snapshot": snap})
+		},
+	}
+}
+
+func newSnapshotRestoreCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:          "restore <id>",
+		Short:        "Restore a saved snapshot",
+		Long:         "Restores the snapshot on the group it was taken from (located by UUID, so IP changes are fine).",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := strings.TrimSpace(args[0])
+			ctx := cmd.Context()
+			store, err := newSnapshotStore()
+			if err != nil {
+				return err
+			}
+			entry, ok, err := store.Get(id)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errors.New("snapshot not found: " + id)
+			}
+
+			snap := entry.Snapshot
+			// Best-effort: refresh IPs; fall back to the stored ones if discovery fails.
+			if tg, err := newTopologyGetter(ctx, flags.Timeout); err == nil {
+				if top, err := tg.GetTopology(ctx); err == nil {
+					snap = snap.UpdateIPs(top)
+				}
+			}
+			if snap.CoordinatorIP == "" {
+				return errors.New("snapshot has no coordinator IP: " + id)
+			}
+			if err := restoreSnapshot(ctx, snap, flags.Timeout); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "snapshot.restore", map[string]any{"id": id, "coordinatorIP": snap.CoordinatorIP})
+		},
+	}
+}
+
+func newSnapshotDeleteCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:          "delete <id>",
+		Short:        "Delete a saved snapshot",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := newSnapshotStore()
+			if err != nil {
+				return err
+			}
+			if err := store.Delete(args[0]); err != nil {
+				return err
+			}
+			return writeOK(cmd, flags, "snapshot.delete", map[string]any{"id": args[0]})
+		},
+	}
+}
+
+func formatCreatedAt(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/snapshots"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakeSnapshotStore struct {
+	entries map[string]snapshots.Entry
+}
+
+func (f *fakeSnapshotStore) List() ([]snapshots.EntryMeta, error) {
+	out := make([]snapshots.EntryMeta, 0, len(f.entries))
+	for _, e := range f.entries {
+		out = append(out, snapshots.EntryMeta{ID: e.ID, CreatedAt: e.CreatedAt, Coordinator: e.Snapshot.CoordinatorName, Source: string(e.Snapshot.Source)})
+	}
+	return out, nil
+}
+
+func (f *fakeSnapshotStore) Get(id string) (snapshots.Entry, bool, error) {
+	e, ok := f.entries[id]
+	return e, ok, nil
+}
+
+func (f *fakeSnapshotStore) Put(entry snapshots.Entry) error {
+	if f.entries == nil {
+		f.entries = map[string]snapshots.Entry{}
+	}
+	f.entries[entry.ID] = entry
+	return nil
+}
+
+func (f *fakeSnapshotStore) Delete(id string) error {
+	delete(f.entries, id)
+	return nil
+}
+
+type fakeSnapshotClient struct {
+	top       sonos.Topology
+	snapGroup sonos.Group
+}
+
+func (f *fakeSnapshotClient) GetTopology(ctx context.Context) (sonos.Topology, error) {
+	return f.top, nil
+}
+
+func (f *fakeSnapshotClient) TakeSnapshot(ctx context.Context, group sonos.Group) (sonos.Snapshot, error) {
+	f.snapGroup = group
+	return sonos.Snapshot{
+		CoordinatorUUID: group.Coordinator.UUID,
+		CoordinatorName: group.Coordinator.Name,
+		CoordinatorIP:   group.Coordinator.IP,
+		Source:          sonos.SourceQueue,
+		TransportState:  "PLAYING",
+	}, nil
+}
+
+func TestSnapshotSaveAndRestore(t *testing.T) {
+	kitchen := sonos.Member{Name: "Kitchen", IP: "192.168.1.10", UUID: "RINCON_K1400", IsVisible: true, IsCoordinator: true}
+	top := sonos.Topology{
+		Groups: []sonos.Group{{ID: "g1", Coordinator: kitchen, Members: []sonos.Member{kitchen}}},
+		ByName: map[string]sonos.Member{"Kitchen": kitchen},
+		ByIP:   map[string]sonos.Member{kitchen.IP: kitchen},
+	}
+	store := &fakeSnapshotStore{}
+	client := &fakeSnapshotClient{top: top}
+
+	origStore, origClient, origRestore, origTG := newSnapshotStore, newSnapshotClient, restoreSnapshot, newTopologyGetter
+	t.Cleanup(func() {
+		newSnapshotStore, newSnapshotClient, restoreSnapshot, newTopologyGetter = origStore, origClient, origRestore, origTG
+	})
+	newSnapshotStore = func() (snapshots.Store, error) { return store, nil }
+	newSnapshotClient = func(ctx context.Context, flags *rootFlags) (snapshotClient, error) { return client, nil }
+
+	// The coordinator moved to a new IP since the snapshot was taken.
+	moved := kitchen
+	moved.IP = "192.168.1.99"
+	newTopologyGetter = func(ctx context.Context, timeout time.Duration) (topologyGetter, error) {
+		return &fakeSceneTopologyGetter{top: sonos.Topology{ByIP: map[string]sonos.Member{moved.IP: moved}}}, nil
+	}
+	var restored []sonos.Snapshot
+	restoreSnapshot = func(ctx context.Context, snap sonos.Snapshot, timeout time.Duration) error {
+		restored = append(restored, snap)
+		return nil
+	}
+
+	flags := &rootFlags{Name: "Kitchen", Timeout: time.Second, Format: formatPlain}
+	cmd := newSnapshotCmd(flags)
+	cmd.SetOut(newDiscardWriter())
+	cmd.SetErr(newDiscardWriter())
+	cmd.SetArgs([]string{"save", "before-announce"})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if client.snapGroup.ID != "g1" {
+		t.Fatalf("unexpected group: %+v", client.snapGroup)
+	}
+	if _, ok := store.entries["before-announce"]; !ok {
+		t.Fatalf("snapshot not stored")
+	}
+
+	var out captureWriter
+	cmd = newSnapshotCmd(flags)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"list"})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if !strings.Contains(out.String(), "before-announce") || !strings.Contains(out.String(), "queue") {
+		t.Fatalf("unexpected list output: %s", out.String())
+	}
+
+	cmd = newSnapshotCmd(flags)
+	cmd.SetOut(newDiscardWriter())
+	cmd.SetArgs([]string{"restore", "before-announce"})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if len(restored) != 1 || restored[0].CoordinatorIP != "192.168.1.99" {
+		t.Fatalf("unexpected restore: %+v", restored)
+	}
+
+	cmd = newSnapshotCmd(flags)
+	cmd.SetOut(newDiscardWriter())
+	cmd.SetErr(newDiscardWriter())
+	cmd.SilenceErrors = true
+	cmd.SetArgs([]string{"restore", "missing"})
+	if err := cmd.ExecuteContext(context.Background()); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -124,6 +124,7 @@ func newLineInCmd(flags *rootFlags) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&from, "from", "", "Source speaker name or IP that has line-in (defaults to target)")
+	cmd.AddCommand(newLineInSettingsCmd(flags))
 	return cmd
 }
 
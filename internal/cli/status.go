@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steipete/sonoscli/internal/sonos"
@@ -12,6 +13,9 @@ type statusClient interface {
 	GetDeviceDescription(ctx context.Context) (sonos.Device, error)
 	GetTransportInfo(ctx context.Context) (sonos.TransportInfo, error)
 	GetPositionInfo(ctx context.Context) (sonos.PositionInfo, error)
+	GetMediaInfo(ctx context.Context) (sonos.MediaInfo, error)
+	GetTransportSettings(ctx context.Context) (sonos.TransportSettings, error)
+	GetRemainingSleepTimerDuration(ctx context.Context) (time.Duration, error)
 	GetVolume(ctx context.Context) (int, error)
 	GetMute(ctx context.Context) (bool, error)
 }
@@ -24,6 +28,10 @@ type statusOutput struct {
 	Device      sonos.Device        `json:"device"`
 	Transport   sonos.TransportInfo `json:"transport"`
 	Position    sonos.PositionInfo  `json:"position"`
+	Source      sonos.Source        `json:"source,omitempty"`
+	QueueLength int                 `json:"queueLength,omitempty"`
+	PlayMode    sonos.PlayMode      `json:"playMode,omitempty"`
+	SleepTimer  string              `json:"sleepTimer,omitempty"`
 	NowPlaying  *sonos.DIDLItem     `json:"nowPlaying,omitempty"`
 	AlbumArtURL string              `json:"albumArtURL,omitempty"`
 	Volume      int                 `json:"volume"`
@@ -35,7 +43,7 @@ func newStatusCmd(flags *rootFlags) *cobra.Command {
 		Use:          "status",
 		Aliases:      []string{"now"},
 		Short:        "Show current playback status",
-		Long:         "Prints coordinator status (transport state, track URI, time, volume/mute). Parses TrackMetaData when available to show title/artist/album/album art. Use --format json for machine-readable output.",
+		Long:         "Prints coordinator status (transport state, source, queue length, track URI, time, play mode, sleep timer, volume/mute). Parses TrackMetaData when available to show title/artist/album/album art. Use --format json for machine-readable output.",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := validateTarget(flags); err != nil {
@@ -50,6 +58,9 @@ func newStatusCmd(flags *rootFlags) *cobra.Command {
 			dev, _ := c.GetDeviceDescription(ctx)
 			transport, _ := c.GetTransportInfo(ctx)
 			position, _ := c.GetPositionInfo(ctx)
+			media, mediaErr := c.GetMediaInfo(ctx)
+			settings, _ := c.GetTransportSettings(ctx)
+			sleepRemaining, _ := c.GetRemainingSleepTimerDuration(ctx)
 			vol, _ := c.GetVolume(ctx)
 			mute, _ := c.GetMute(ctx)
 
@@ -60,10 +71,28 @@ func newStatusCmd(flags *rootFlags) *cobra.Command {
 				albumArtURL = sonos.AlbumArtURL(dev.IP, np.AlbumArtURI)
 			}
 
+			var source sonos.Source
+			var queueLength int
+			if mediaErr == nil {
+				source = sonos.ClassifySource(media.CurrentURI)
+				if source == sonos.SourceQueue {
+					queueLength = media.NrTracks
+				}
+			}
+
+			var sleepTimer string
+			if sleepRemaining > 0 {
+				sleepTimer = sonos.FormatHHMMSS(sleepRemaining)
+			}
+
 			out := statusOutput{
 				Device:      dev,
 				Transport:   transport,
 				Position:    position,
+				Source:      source,
+				QueueLength: queueLength,
+				PlayMode:    settings.PlayMode,
+				SleepTimer:  sleepTimer,
 				NowPlaying:  nowPlaying,
 				AlbumArtURL: albumArtURL,
 				Volume:      vol,
@@ -81,6 +110,12 @@ func newStatusCmd(flags *rootFlags) *cobra.Command {
 					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "udn\t%s\n", dev.UDN)
 				}
 				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "state\t%s\n", transport.State)
+				if source != "" {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "source\t%s\n", source)
+				}
+				if source == sonos.SourceQueue {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "queue_length\t%d\n", queueLength)
+				}
 				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "track\t%s\n", position.Track)
 				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "uri\t%s\n", position.TrackURI)
 				if nowPlaying != nil {
@@ -99,6 +134,12 @@ func newStatusCmd(flags *rootFlags) *cobra.Command {
 				}
 				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "time\t%s\n", position.RelTime)
 				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "duration\t%s\n", position.TrackDuration)
+				if settings.PlayMode != "" {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "play_mode\t%s\n", settings.PlayMode)
+				}
+				if sleepTimer != "" {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "sleep_timer\t%s\n", sleepTimer)
+				}
 				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "volume\t%d\n", vol)
 				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "mute\t%v\n", mute)
 				return nil
@@ -109,6 +150,11 @@ func newStatusCmd(flags *rootFlags) *cobra.Command {
 				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "UDN:\t\t%s\n", dev.UDN)
 			}
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "State:\t\t%s\n", transport.State)
+			if source == sonos.SourceQueue {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Source:\t\t%s (%d tracks)\n", source, queueLength)
+			} else if source != "" {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Source:\t\t%s\n", source)
+			}
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Track:\t\t%s\n", position.Track)
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "URI:\t\t%s\n", position.TrackURI)
 			if nowPlaying != nil {
@@ -126,6 +172,12 @@ func newStatusCmd(flags *rootFlags) *cobra.Command {
 				}
 			}
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Time:\t\t%s / %s\n", position.RelTime, position.TrackDuration)
+			if settings.PlayMode != "" {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Mode:\t\tshuffle %s, repeat %s\n", onOff(settings.PlayMode.Shuffle()), settings.PlayMode.Repeat())
+			}
+			if sleepTimer != "" {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Sleep:\t\t%s remaining\n", sleepTimer)
+			}
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Volume:\t\t%d\n", vol)
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Mute:\t\t%v\n", mute)
 			return nil
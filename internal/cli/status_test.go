@@ -13,6 +13,9 @@ type fakeStatusClient struct {
 	dev       sonos.Device
 	transport sonos.TransportInfo
 	position  sonos.PositionInfo
+	media     sonos.MediaInfo
+	settings  sonos.TransportSettings
+	sleep     time.Duration
 	volume    int
 	mute      bool
 }
@@ -29,6 +32,18 @@ func (f *fakeStatusClient) GetPositionInfo(ctx context.Context) (sonos.PositionI
 	return f.position, nil
 }
 
+func (f *fakeStatusClient) GetMediaInfo(ctx context.Context) (sonos.MediaInfo, error) {
+	return f.media, nil
+}
+
+func (f *fakeStatusClient) GetTransportSettings(ctx context.Context) (sonos.TransportSettings, error) {
+	return f.settings, nil
+}
+
+func (f *fakeStatusClient) GetRemainingSleepTimerDuration(ctx context.Context) (time.Duration, error) {
+	return f.sleep, nil
+}
+
 func (f *fakeStatusClient) GetVolume(ctx context.Context) (int, error) {
 	return f.volume, nil
 }
@@ -65,8 +80,11 @@ func TestStatusShowsNowPlayingFields(t *testing.T) {
 			RelTime:       "0:00:10",
 			TrackDuration: "0:03:00",
 		},
-		volume: 25,
-		mute:   false,
+		media:    sonos.MediaInfo{NrTracks: 12, CurrentURI: "x-rincon-queue:RINCON_OFFICE1400#0"},
+		settings: sonos.TransportSettings{PlayMode: sonos.PlayModeShuffle},
+		sleep:    29*time.Minute + 53*time.Second,
+		volume:   25,
+		mute:     false,
 	}
 
 	orig := newStatusClient
@@ -99,6 +117,15 @@ func TestStatusShowsNowPlayingFields(t *testing.T) {
 	if !strings.Contains(s, "AlbumArt:\thttp://192.168.1.50:1400/getaa?s=1&u=abc") {
 		t.Fatalf("missing album art url: %s", s)
 	}
+	if !strings.Contains(s, "Source:\t\tqueue (12 tracks)") {
+		t.Fatalf("missing source: %s", s)
+	}
+	if !strings.Contains(s, "Mode:\t\tshuffle on, repeat all") {
+		t.Fatalf("missing play mode: %s", s)
+	}
+	if !strings.Contains(s, "Sleep:\t\t00:29:53 remaining") {
+		t.Fatalf("missing sleep timer: %s", s)
+	}
 }
 
 func TestStatusJSONIncludesNowPlaying(t *testing.T) {
@@ -116,6 +143,7 @@ func TestStatusJSONIncludesNowPlaying(t *testing.T) {
 		dev:       sonos.Device{Name: "Office", IP: "192.168.1.50"},
 		transport: sonos.TransportInfo{State: "PLAYING"},
 		position:  sonos.PositionInfo{TrackMeta: didl},
+		media:     sonos.MediaInfo{NrTracks: 1, CurrentURI: "x-sonosapi-stream:s24896?sid=254"},
 		volume:    10,
 		mute:      false,
 	}
@@ -144,6 +172,9 @@ func TestStatusJSONIncludesNowPlaying(t *testing.T) {
 	if !strings.Contains(s, "\"title\": \"My Song\"") {
 		t.Fatalf("missing title: %s", s)
 	}
+	if !strings.Contains(s, "\"source\": \"radio\"") || strings.Contains(s, "queueLength") {
+		t.Fatalf("unexpected source fields: %s", s)
+	}
 	// encoding/json escapes '&' as "\u0026"
 	if !strings.Contains(s, "\"albumArtURL\": \"http://192.168.1.50:1400/getaa?s=1") || !strings.Contains(s, "u=abc") {
 		t.Fatalf("missing albumArtURL: %s", s)
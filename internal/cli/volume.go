@@ -10,7 +10,7 @@ import (
 func newVolumeCmd(flags *rootFlags) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "volume",
-		Short: "Get or set volume",
+		Short: "Get, set, step or fade volume",
 		Long:  "Controls RenderingControl volume on the group coordinator (0-100).",
 	}
 
@@ -39,9 +39,11 @@ func newVolumeCmd(flags *rootFlags) *cobra.Command {
 		},
 	})
 
-	cmd.AddCommand(&cobra.Command{
+	var force bool
+	setCmd := &cobra.Command{
 		Use:   "set <0-100>",
 		Short: "Set volume",
+		Long:  "Sets the volume. Configured per-room limits (maxVolume, minVolume, quiet hours; see `sonos config`) are applied unless --force is given.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
@@ -53,12 +55,28 @@ func newVolumeCmd(flags *rootFlags) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			limiter, err := newVolumeLimiter(force)
+			if err != nil {
+				return err
+			}
+			lim, err := limiter.targetLimit(ctx, flags, false)
+			if err != nil {
+				return err
+			}
+			requested := v
+			v, limited := clampVolume(cmd, lim, "", v)
 			if err := c.SetVolume(ctx, v); err != nil {
 				return err
 			}
-			return writeOK(cmd, flags, "volume.set", map[string]any{"coordinatorIP": c.IP, "volume": v})
+			return writeOK(cmd, flags, "volume.set", limitedOutput(map[string]any{"coordinatorIP": c.IP, "volume": v}, requested, limited))
 		},
-	})
+	}
+	setCmd.Flags().BoolVar(&force, "force", false, "Ignore configured volume limits")
+	cmd.AddCommand(setCmd)
+
+	cmd.AddCommand(newVolumeStepCmd(flags, "up"))
+	cmd.AddCommand(newVolumeStepCmd(flags, "down"))
+	cmd.AddCommand(newVolumeFadeCmd(flags))
 
 	return cmd
 }
@@ -0,0 +1,350 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/appconfig"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type volumeClient interface {
+	GetVolume(ctx context.Context) (int, error)
+	SetVolume(ctx context.Context, volume int) error
+	SetRelativeVolume(ctx context.Context, adjustment int) (int, error)
+	RampToVolume(ctx context.Context, rampType string, volume int) (time.Duration, error)
+	GetGroupVolume(ctx context.Context) (int, error)
+	SetGroupVolume(ctx context.Context, volume int) error
+	SetRelativeGroupVolume(ctx context.Context, adjustment int) (int, error)
+}
+
+var newVolumeClient = func(ctx context.Context, flags *rootFlags) (volumeClient, error) {
+	return coordinatorClient(ctx, flags)
+}
+
+// fadeSleep waits between fade steps; tests replace it to run instantly.
+var fadeSleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// fadeMinInterval keeps stepped fades from flooding the speaker with
+// SetVolume calls; large changes over short durations use bigger steps.
+const fadeMinInterval = 250 * time.Millisecond
+
+var rampTypes = map[string]string{
+	"sleep":    sonos.RampTypeSleepTimer,
+	"alarm":    sonos.RampTypeAlarm,
+	"autoplay": sonos.RampTypeAutoplay,
+}
+
+func newVolumeStepCmd(flags *rootFlags, direction string) *cobra.Command {
+	var group, force bool
+	sign := 1
+	short := "Raise volume by a step"
+	if direction == "down" {
+		sign = -1
+		short = "Lower volume by a step"
+	}
+
+	cmd := &cobra.Command{
+		Use:          direction + " [step]",
+		Short:        short,
+		Long:         "Changes the volume relative to its current level (RenderingControl SetRelativeVolume; GroupRenderingControl SetRelativeGroupVolume with --group). The default step is 5.",
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			step := 5
+			if len(args) == 1 {
+				n, err := strconv.Atoi(args[0])
+				if err != nil || n < 1 || n > 100 {
+					return fmt.Errorf("step must be 1-100, got %q", args[0])
+				}
+				step = n
+			}
+			ctx := cmd.Context()
+			limiter, err := newVolumeLimiter(force)
+			if err != nil {
+				return err
+			}
+			lim, err := limiter.targetLimit(ctx, flags, group)
+			if err != nil {
+				return err
+			}
+			c, err := newVolumeClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			if group {
+				if c, err = withMemberLimits(ctx, cmd, flags, limiter, c); err != nil {
+					return err
+				}
+			}
+			var v, requested int
+			var limited bool
+			switch {
+			case restricts(lim):
+				v, requested, limited, err = stepVolumeWithin(ctx, cmd, c, group, sign*step, lim)
+			case group:
+				v, err = c.SetRelativeGroupVolume(ctx, sign*step)
+			default:
+				v, err = c.SetRelativeVolume(ctx, sign*step)
+			}
+			if err != nil {
+				return err
+			}
+			if isTSV(flags) {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "volume\t%d\n", v)
+				return nil
+			}
+			writePlainLine(cmd, flags, strconv.Itoa(v))
+			return writeOK(cmd, flags, "volume."+direction, limitedOutput(map[string]any{"volume": v, "step": step, "group": group}, requested, limited))
+		},
+	}
+	cmd.Flags().BoolVar(&group, "group", false, "Change the whole group's volume (keeps relative levels)")
+	cmd.Flags().BoolVar(&force, "force", false, "Ignore configured volume limits")
+	return cmd
+}
+
+// stepVolumeWithin is the relative change for rooms with volume limits: it
+// reads the current volume and sets the clamped result instead of letting the
+// speaker step past the limit.
+func stepVolumeWithin(ctx context.Context, cmd *cobra.Command, c volumeClient, group bool, adjustment int, lim appconfig.VolumeLimit) (volume, requested int, limited bool, err error) {
+	get, set := c.GetVolume, c.SetVolume
+	if group {
+		get, set = c.GetGroupVolume, c.SetGroupVolume
+	}
+	cur, err := get(ctx)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	requested = appconfig.ClampPercent(cur + adjustment)
+	volume, limited = clampVolume(cmd, lim, "", requested)
+	if err := set(ctx, volume); err != nil {
+		return 0, 0, false, err
+	}
+	return volume, requested, limited, nil
+}
+
+// memberLimitedVolumeClient holds limited group members to their own limits
+// after every group volume change (see volumeLimiter.memberClamp).
+type memberLimitedVolumeClient struct {
+	volumeClient
+	clamp func(context.Context) error
+}
+
+func (c memberLimitedVolumeClient) SetGroupVolume(ctx context.Context, volume int) error {
+	if err := c.volumeClient.SetGroupVolume(ctx, volume); err != nil {
+		return err
+	}
+	return c.clamp(ctx)
+}
+
+func (c memberLimitedVolumeClient) SetRelativeGroupVolume(ctx context.Context, adjustment int) (int, error) {
+	v, err := c.volumeClient.SetRelativeGroupVolume(ctx, adjustment)
+	if err != nil {
+		return 0, err
+	}
+	return v, c.clamp(ctx)
+}
+
+func withMemberLimits(ctx context.Context, cmd *cobra.Command, flags *rootFlags, limiter volumeLimiter, c volumeClient) (volumeClient, error) {
+	clamp, err := limiter.memberClamp(ctx, cmd, flags)
+	if err != nil || clamp == nil {
+		return c, err
+	}
+	return memberLimitedVolumeClient{volumeClient: c, clamp: clamp}, nil
+}
+
+type fadeResult struct {
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Group  bool   `json:"group"`
+	Method string `json:"method"`
+}
+
+func newVolumeFadeCmd(flags *rootFlags) *cobra.Command {
+	var over time.Duration
+	var group, force bool
+	var ramp string
+
+	cmd := &cobra.Command{
+		Use:   "fade <0-100>",
+		Short: "Fade volume to a target",
+		Long: "Fades the volume to <0-100> in small SetVolume steps spread over --over (SetGroupVolume with --group, for whole-group wake-up and wind-down routines). " +
+			"Stepping is the default because RampToVolume ramps at a fixed speed chosen by the speaker and cannot honor --over. " +
+			"With --ramp sleep|alarm|autoplay the speaker ramps by itself (RampToVolume) and the command waits for it; speakers that reject RampToVolume fall back to stepping. " +
+			"Ctrl+C stops the fade (or the speaker's ramp) at the current level. The target is held to configured volume limits unless --force is given; " +
+			"with --group, rooms with limits are also held to their own limits after each step.",
+		Example:      "  sonos volume fade 10 --name Bedroom --over 5m\n  sonos volume fade 35 --name Kitchen --group --over 30s\n  sonos volume fade 25 --name Bedroom --ramp alarm",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := strconv.Atoi(args[0])
+			if err != nil || target < 0 || target > 100 {
+				return fmt.Errorf("target volume must be 0-100, got %q", args[0])
+			}
+			if over < 0 {
+				return errors.New("--over must not be negative")
+			}
+			rampType := ""
+			if ramp != "" {
+				rt, ok := rampTypes[strings.ToLower(strings.TrimSpace(ramp))]
+				if !ok {
+					return fmt.Errorf("unknown --ramp %q (use sleep, alarm or autoplay)", ramp)
+				}
+				if group {
+					return errors.New("--ramp cannot be combined with --group")
+				}
+				rampType = rt
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			limiter, err := newVolumeLimiter(force)
+			if err != nil {
+				return err
+			}
+			lim, err := limiter.targetLimit(ctx, flags, group)
+			if err != nil {
+				return err
+			}
+			requested := target
+			target, limited := clampVolume(cmd, lim, "", target)
+
+			c, err := newVolumeClient(ctx, flags)
+			if err != nil {
+				return err
+			}
+			if group {
+				if c, err = withMemberLimits(ctx, cmd, flags, limiter, c); err != nil {
+					return err
+				}
+			}
+			res, err := fadeVolume(ctx, c, target, over, group, rampType)
+			if err != nil {
+				return err
+			}
+			if res.Method == "ramp" {
+				writePlainLine(cmd, flags, fmt.Sprintf("Ramping volume %d → %d.", res.From, res.To))
+			} else {
+				writePlainLine(cmd, flags, fmt.Sprintf("Volume %d → %d.", res.From, res.To))
+			}
+			return writeOK(cmd, flags, "volume.fade", limitedOutput(map[string]any{
+				"from":   res.From,
+				"to":     res.To,
+				"group":  res.Group,
+				"method": res.Method,
+			}, requested, limited))
+		},
+	}
+	cmd.Flags().DurationVar(&over, "over", 10*time.Second, "Fade duration (stepped fades only)")
+	cmd.Flags().BoolVar(&group, "group", false, "Fade the whole group's volume")
+	cmd.Flags().StringVar(&ramp, "ramp", "", "Let the speaker ramp natively: sleep|alarm|autoplay")
+	cmd.Flags().BoolVar(&force, "force", false, "Ignore configured volume limits")
+	return cmd
+}
+
+// fadeVolume moves the volume from its current level to target. rampType
+// selects RampToVolume, waiting out the ramp so an interrupt can stop it; on a
+// UPnP error it falls back to client-side steps.
+func fadeVolume(ctx context.Context, c volumeClient, target int, over time.Duration, group bool, rampType string) (fadeResult, error) {
+	get, set := c.GetVolume, c.SetVolume
+	if group {
+		get, set = c.GetGroupVolume, c.SetGroupVolume
+	}
+	from, err := get(ctx)
+	if err != nil {
+		return fadeResult{}, err
+	}
+	res := fadeResult{From: from, To: target, Group: group, Method: "steps"}
+
+	if rampType != "" {
+		rampTime, err := c.RampToVolume(ctx, rampType, target)
+		if err == nil {
+			res.Method = "ramp"
+			if err := fadeSleep(ctx, rampTime); err != nil {
+				return fadeResult{}, stopRamp(ctx, get, set)
+			}
+			return res, nil
+		}
+		var upnpErr *sonos.UPnPError
+		if !errors.As(err, &upnpErr) {
+			return fadeResult{}, err
+		}
+	}
+
+	levels, interval := fadeSteps(from, target, over)
+	current := from
+	for i, v := range levels {
+		if i > 0 {
+			if err := fadeSleep(ctx, interval); err != nil {
+				return fadeResult{}, fmt.Errorf("fade interrupted at volume %d", current)
+			}
+		}
+		if err := set(ctx, v); err != nil {
+			if ctx.Err() != nil {
+				return fadeResult{}, fmt.Errorf("fade interrupted at volume %d", current)
+			}
+			return fadeResult{}, err
+		}
+		current = v
+	}
+	return res, nil
+}
+
+// stopRamp halts a speaker-side ramp by setting the volume it has reached.
+// ctx is already cancelled, so the calls run without its cancellation.
+func stopRamp(ctx context.Context, get func(context.Context) (int, error), set func(context.Context, int) error) error {
+	ctx = context.WithoutCancel(ctx)
+	current, err := get(ctx)
+	if err != nil {
+		return fmt.Errorf("fade interrupted; stop ramp: %w", err)
+	}
+	if err := set(ctx, current); err != nil {
+		return fmt.Errorf("fade interrupted; stop ramp: %w", err)
+	}
+	return fmt.Errorf("fade interrupted at volume %d", current)
+}
+
+// fadeSteps returns the volumes to set when fading from → to over d and the
+// pause between them. Steps are at least fadeMinInterval apart; the last level
+// is always to.
+func fadeSteps(from, to int, d time.Duration) ([]int, time.Duration) {
+	delta := to - from
+	if delta == 0 {
+		return nil, 0
+	}
+	n := delta
+	if n < 0 {
+		n = -n
+	}
+	if limit := int(d / fadeMinInterval); n > limit {
+		n = limit
+	}
+	if n < 1 {
+		n = 1
+	}
+	levels := make([]int, n)
+	for i := 1; i <= n; i++ {
+		levels[i-1] = from + delta*i/n
+	}
+	if n == 1 {
+		return levels, 0
+	}
+	return levels, d / time.Duration(n-1)
+}
@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/appconfig"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type fakeVolumeClient struct {
+	volume      int
+	groupVolume int
+	rampErr     error
+	calls       []string
+}
+
+func (f *fakeVolumeClient) GetVolume(ctx context.Context) (int, error) { return f.volume, nil }
+
+func (f *fakeVolumeClient) SetVolume(ctx context.Context, volume int) error {
+	f.calls = append(f.calls, "set="+strconv.Itoa(volume))
+	f.volume = volume
+	return nil
+}
+
+func (f *fakeVolumeClient) SetRelativeVolume(ctx context.Context, adjustment int) (int, error) {
+	f.calls = append(f.calls, "rel="+strconv.Itoa(adjustment))
+	f.volume += adjustment
+	return f.volume, nil
+}
+
+func (f *fakeVolumeClient) RampToVolume(ctx context.Context, rampType string, volume int) (time.Duration, error) {
+	f.calls = append(f.calls, "ramp="+rampType+":"+strconv.Itoa(volume))
+	return 10 * time.Second, f.rampErr
+}
+
+func (f *fakeVolumeClient) GetGroupVolume(ctx context.Context) (int, error) {
+	return f.groupVolume, nil
+}
+
+func (f *fakeVolumeClient) SetGroupVolume(ctx context.Context, volume int) error {
+	f.calls = append(f.calls, "group-set="+strconv.Itoa(volume))
+	f.groupVolume = volume
+	return nil
+}
+
+func (f *fakeVolumeClient) SetRelativeGroupVolume(ctx context.Context, adjustment int) (int, error) {
+	f.calls = append(f.calls, "group-rel="+strconv.Itoa(adjustment))
+	f.groupVolume += adjustment
+	return f.groupVolume, nil
+}
+
+func runVolumeCmd(t *testing.T, flags *rootFlags, c *fakeVolumeClient, args ...string) (string, error) {
+	t.Helper()
+	return runVolumeCmdWithConfig(t, flags, c, appconfig.Config{}, args...)
+}
+
+func runVolumeCmdWithConfig(t *testing.T, flags *rootFlags, c *fakeVolumeClient, cfg appconfig.Config, args ...string) (string, error) {
+	t.Helper()
+	origNew, origSleep, origLoad := newVolumeClient, fadeSleep, loadAppConfig
+	t.Cleanup(func() {
+		newVolumeClient = origNew
+		fadeSleep = origSleep
+		loadAppConfig = origLoad
+	})
+	loadAppConfig = func() (appconfig.Config, error) { return cfg, nil }
+	newVolumeClient = func(ctx context.Context, flags *rootFlags) (volumeClient, error) { return c, nil }
+	fadeSleep = func(ctx context.Context, d time.Duration) error { return ctx.Err() }
+
+	cmd := newVolumeCmd(flags)
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestVolumeUpDown(t *testing.T) {
+	flags := &rootFlags{Name: "Kitchen", Timeout: time.Second, Format: formatPlain}
+	c := &fakeVolumeClient{volume: 20, groupVolume: 30}
+
+	out, err := runVolumeCmd(t, flags, c, "up")
+	if err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if strings.TrimSpace(out) != "25" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if _, err := runVolumeCmd(t, flags, c, "down", "--group", "12"); err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	if want := []string{"rel=5", "group-rel=-12"}; !reflect.DeepEqual(c.calls, want) {
+		t.Fatalf("calls: %v", c.calls)
+	}
+	if _, err := runVolumeCmd(t, flags, c, "up", "0"); err == nil {
+		t.Fatalf("expected error for step 0")
+	}
+}
+
+func TestVolumeFadeStepsAndJSON(t *testing.T) {
+	flags := &rootFlags{Name: "Bedroom", Timeout: time.Second, Format: formatJSON}
+	c := &fakeVolumeClient{volume: 10}
+
+	out, err := runVolumeCmd(t, flags, c, "fade", "14", "--over", "2s")
+	if err != nil {
+		t.Fatalf("fade: %v", err)
+	}
+	if want := []string{"set=11", "set=12", "set=13", "set=14"}; !reflect.DeepEqual(c.calls, want) {
+		t.Fatalf("calls: %v", c.calls)
+	}
+	for _, want := range []string{`"action": "volume.fade"`, `"from": 10`, `"to": 14`, `"method": "steps"`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %s: %s", want, out)
+		}
+	}
+}
+
+func TestVolumeFadeGroup(t *testing.T) {
+	flags := &rootFlags{Name: "Kitchen", Timeout: time.Second, Format: formatPlain}
+	c := &fakeVolumeClient{groupVolume: 40}
+
+	out, err := runVolumeCmd(t, flags, c, "fade", "0", "--group", "--over", "500ms")
+	if err != nil {
+		t.Fatalf("fade: %v", err)
+	}
+	if want := []string{"group-set=20", "group-set=0"}; !reflect.DeepEqual(c.calls, want) {
+		t.Fatalf("calls: %v", c.calls)
+	}
+	if strings.TrimSpace(out) != "Volume 40 → 0." {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestVolumeFadeRampFallsBackOnUPnPError(t *testing.T) {
+	flags := &rootFlags{Name: "Bedroom", Timeout: time.Second, Format: formatPlain}
+
+	c := &fakeVolumeClient{volume: 5}
+	out, err := runVolumeCmd(t, flags, c, "fade", "25", "--ramp", "alarm")
+	if err != nil {
+		t.Fatalf("fade: %v", err)
+	}
+	if want := []string{"ramp=ALARM_RAMP_TYPE:25"}; !reflect.DeepEqual(c.calls, want) {
+		t.Fatalf("calls: %v", c.calls)
+	}
+	if !strings.Contains(out, "Ramping volume 5 → 25.") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	c = &fakeVolumeClient{volume: 5, rampErr: &sonos.UPnPError{Code: "401", Description: "Invalid Action"}}
+	if _, err := runVolumeCmd(t, flags, c, "fade", "7", "--ramp", "alarm", "--over", "0"); err != nil {
+		t.Fatalf("fade fallback: %v", err)
+	}
+	if want := []string{"ramp=ALARM_RAMP_TYPE:7", "set=7"}; !reflect.DeepEqual(c.calls, want) {
+		t.Fatalf("calls: %v", c.calls)
+	}
+
+	if _, err := runVolumeCmd(t, flags, c, "fade", "7", "--ramp", "alarm", "--group"); err == nil {
+		t.Fatalf("expected error for --ramp with --group")
+	}
+}
+
+func TestVolumeFadeInterrupted(t *testing.T) {
+	c := &fakeVolumeClient{volume: 50}
+	ctx, cancel := context.WithCancel(context.Background())
+	origSleep := fadeSleep
+	t.Cleanup(func() { fadeSleep = origSleep })
+	fadeSleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return ctx.Err()
+	}
+
+	_, err := fadeVolume(ctx, c, 40, 10*time.Second, false, "")
+	if err == nil || err.Error() != "fade interrupted at volume 49" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"set=49"}; !reflect.DeepEqual(c.calls, want) {
+		t.Fatalf("calls: %v", c.calls)
+	}
+}
+
+func TestVolumeFadeRampInterruptedStopsRamp(t *testing.T) {
+	c := &fakeVolumeClient{volume: 5}
+	ctx, cancel := context.WithCancel(context.Background())
+	origSleep := fadeSleep
+	t.Cleanup(func() { fadeSleep = origSleep })
+	var waited time.Duration
+	fadeSleep = func(ctx context.Context, d time.Duration) error {
+		waited = d
+		c.volume = 12 // the speaker is part-way through its ramp
+		cancel()
+		return ctx.Err()
+	}
+
+	_, err := fadeVolume(ctx, c, 25, 0, false, sonos.RampTypeAlarm)
+	if err == nil || err.Error() != "fade interrupted at volume 12" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if waited != 10*time.Second {
+		t.Fatalf("expected to wait for the ramp, waited %s", waited)
+	}
+	if want := []string{"ramp=ALARM_RAMP_TYPE:25", "set=12"}; !reflect.DeepEqual(c.calls, want) {
+		t.Fatalf("calls: %v", c.calls)
+	}
+}
+
+func TestFadeSteps(t *testing.T) {
+	levels, interval := fadeSteps(20, 0, time.Second)
+	if want := []int{15, 10, 5, 0}; !reflect.DeepEqual(levels, want) {
+		t.Fatalf("levels: %v", levels)
+	}
+	if interval != time.Second/3 {
+		t.Fatalf("interval: %s", interval)
+	}
+	if levels, _ := fadeSteps(30, 30, time.Minute); len(levels) != 0 {
+		t.Fatalf("expected no steps: %v", levels)
+	}
+	levels, interval = fadeSteps(10, 13, time.Minute)
+	if !reflect.DeepEqual(levels, []int{11, 12, 13}) || interval != 30*time.Second {
+		t.Fatalf("levels %v interval %s", levels, interval)
+	}
+}
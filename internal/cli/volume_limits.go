@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/sonoscli/internal/appconfig"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+// volumeNow is the clock used for quiet hours; tests pin it.
+var volumeNow = time.Now
+
+// volumeLimitTopology finds out which rooms a volume change affects. It is
+// only called when volume limits are configured.
+var volumeLimitTopology = func(ctx context.Context, flags *rootFlags) (sonos.Topology, error) {
+	if ip := strings.TrimSpace(flags.IP); ip != "" {
+		return newSonosClient(ip, flags.Timeout).GetTopology(ctx)
+	}
+	tg, err := newTopologyGetter(ctx, flags.Timeout)
+	if err != nil {
+		return sonos.Topology{}, err
+	}
+	return tg.GetTopology(ctx)
+}
+
+// volumeLimiter applies the per-room limits from the config file (maxVolume,
+// minVolume, quiet hours) unless --force was given.
+type volumeLimiter struct {
+	cfg   appconfig.Config
+	force bool
+	now   time.Time
+}
+
+func newVolumeLimiter(force bool) (volumeLimiter, error) {
+	cfg, err := loadAppConfig()
+	if err != nil {
+		return volumeLimiter{}, err
+	}
+	return volumeLimiter{cfg: cfg.Normalize(), force: force, now: volumeNow()}, nil
+}
+
+func (l volumeLimiter) enabled() bool {
+	return !l.force && len(l.cfg.Rooms) > 0
+}
+
+func (l volumeLimiter) limit(rooms ...string) appconfig.VolumeLimit {
+	if !l.enabled() {
+		return appconfig.VolumeLimit{Min: 0, Max: 100}
+	}
+	return l.cfg.VolumeLimit(l.now, rooms...)
+}
+
+// memberVolumeClient reads and sets one room's own volume.
+type memberVolumeClient interface {
+	GetVolume(ctx context.Context) (int, error)
+	SetVolume(ctx context.Context, volume int) error
+}
+
+var newMemberVolumeClient = func(ip string, timeout time.Duration) memberVolumeClient {
+	return newSonosClient(ip, timeout)
+}
+
+// targetLimit returns the limit for the --name/--ip target: its group
+// coordinator (whose volume `volume` commands change), or every visible member
+// of the group when group is set.
+func (l volumeLimiter) targetLimit(ctx context.Context, flags *rootFlags, group bool) (appconfig.VolumeLimit, error) {
+	if !l.enabled() {
+		return l.limit(), nil
+	}
+	top, err := volumeLimitTopology(ctx, flags)
+	if err != nil {
+		return appconfig.VolumeLimit{}, fmt.Errorf("volume limits: look up room: %w (use --force to skip limits)", err)
+	}
+	mem, err := resolveMember(top, flags.Name, flags.IP)
+	if err != nil {
+		return appconfig.VolumeLimit{}, fmt.Errorf("volume limits: %w (use --force to skip limits)", err)
+	}
+	rooms := []string{mem.Name}
+	if g, ok := top.GroupForIP(mem.IP); ok {
+		rooms = []string{g.Coordinator.Name}
+		if group {
+			rooms = rooms[:0]
+			for _, m := range g.Members {
+				if m.IsVisible {
+					rooms = append(rooms, m.Name)
+				}
+			}
+			sort.Strings(rooms)
+		}
+	}
+	return l.limit(rooms...), nil
+}
+
+// memberClamp returns a function that holds each visible member of the
+// target's group to its own limits. Group volume is an average that scales
+// members proportionally, so capping it does not keep a member below its
+// maxVolume; call the function after every group volume change. It returns
+// nil when no member has a limit.
+func (l volumeLimiter) memberClamp(ctx context.Context, cmd *cobra.Command, flags *rootFlags) (func(context.Context) error, error) {
+	if !l.enabled() {
+		return nil, nil
+	}
+	top, err := volumeLimitTopology(ctx, flags)
+	if err != nil {
+		return nil, fmt.Errorf("volume limits: look up room: %w (use --force to skip limits)", err)
+	}
+	mem, err := resolveMember(top, flags.Name, flags.IP)
+	if err != nil {
+		return nil, fmt.Errorf("volume limits: %w (use --force to skip limits)", err)
+	}
+	members := []sonos.Member{mem}
+	if g, ok := top.GroupForIP(mem.IP); ok {
+		members = g.Members
+	}
+	type limitedMember struct {
+		member sonos.Member
+		lim    appconfig.VolumeLimit
+	}
+	var limited []limitedMember
+	for _, m := range members {
+		if lim := l.limit(m.Name); m.IsVisible && restricts(lim) {
+			limited = append(limited, limitedMember{member: m, lim: lim})
+		}
+	}
+	if len(limited) == 0 {
+		return nil, nil
+	}
+	noted := map[string]bool{}
+	return func(ctx context.Context) error {
+		for _, lm := range limited {
+			c := newMemberVolumeClient(lm.member.IP, flags.Timeout)
+			cur, err := c.GetVolume(ctx)
+			if err != nil {
+				return fmt.Errorf("volume limits: %s: %w", lm.member.Name, err)
+			}
+			v, reason := lm.lim.Clamp(cur)
+			if reason == "" || v == cur {
+				continue
+			}
+			// Fades call this after every step; say it once per room.
+			if !noted[lm.member.Name] {
+				clampVolume(cmd, lm.lim, lm.member.Name, cur)
+				noted[lm.member.Name] = true
+			}
+			if err := c.SetVolume(ctx, v); err != nil {
+				return fmt.Errorf("volume limits: %s: %w", lm.member.Name, err)
+			}
+		}
+		return nil
+	}, nil
+}
+
+// restricts reports whether lim is narrower than 0-100 because of the config.
+func restricts(lim appconfig.VolumeLimit) bool {
+	return lim.MinReason != "" || lim.MaxReason != ""
+}
+
+// clampVolume applies lim to v and, when a configured limit changed it, says
+// so on stderr (prefixed with label, if any).
+func clampVolume(cmd *cobra.Command, lim appconfig.VolumeLimit, label string, v int) (int, bool) {
+	got, reason := lim.Clamp(v)
+	if reason == "" || got == v {
+		return v, false
+	}
+	verb := "capped"
+	if got > v {
+		verb = "raised"
+	}
+	if label != "" {
+		label += ": "
+	}
+	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%svolume %d %s to %d (%s); use --force to override\n", label, v, verb, got, reason)
+	return got, true
+}
+
+// limitedOutput adds the requested volume to JSON output when it was clamped.
+func limitedOutput(out map[string]any, requested int, limited bool) map[string]any {
+	if limited {
+		out["limited"] = true
+		out["requested"] = requested
+	}
+	return out
+}
@@ -0,0 +1,314 @@
+package cli
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/sonoscli/internal/appconfig"
+	"github.com/steipete/sonoscli/internal/scenes"
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+func volumeLimitsTestConfig() appconfig.Config {
+	return appconfig.Config{Rooms: map[string]appconfig.RoomConfig{
+		"Kids Room": {MaxVolume: 40, QuietHours: []appconfig.QuietHours{{Start: "19:30", End: "07:00", MaxVolume: 15}}},
+		"Kitchen":   {MinVolume: 10},
+	}}
+}
+
+// fakeMemberVolumes stands in for the rooms' own RenderingControl volume.
+type fakeMemberVolumes struct {
+	volumes map[string]int // by IP
+	sets    []string
+}
+
+type fakeMemberVolumeClient struct {
+	f  *fakeMemberVolumes
+	ip string
+}
+
+func (c fakeMemberVolumeClient) GetVolume(ctx context.Context) (int, error) {
+	return c.f.volumes[c.ip], nil
+}
+
+func (c fakeMemberVolumeClient) SetVolume(ctx context.Context, volume int) error {
+	c.f.volumes[c.ip] = volume
+	c.f.sets = append(c.f.sets, c.ip+"="+strconv.Itoa(volume))
+	return nil
+}
+
+// pinVolumeLimitTopology makes Kitchen the coordinator of a group with Kids
+// Room and fixes the clock at hour:00. The rooms' own volumes start at 20.
+func pinVolumeLimitTopology(t *testing.T, hour int) *fakeMemberVolumes {
+	t.Helper()
+	k := sonos.Member{Name: "Kitchen", IP: "192.168.1.20", UUID: "RINCON_K1400", IsVisible: true, IsCoordinator: true}
+	kids := sonos.Member{Name: "Kids Room", IP: "192.168.1.21", UUID: "RINCON_KR1400", IsVisible: true}
+	top := sonos.Topology{
+		Groups: []sonos.Group{{ID: "RINCON_K1400:1", Coordinator: k, Members: []sonos.Member{k, kids}}},
+		ByName: map[string]sonos.Member{k.Name: k, kids.Name: kids},
+		ByIP:   map[string]sonos.Member{k.IP: k, kids.IP: kids},
+	}
+	members := &fakeMemberVolumes{volumes: map[string]int{k.IP: 20, kids.IP: 20}}
+	origTop, origNow, origMember := volumeLimitTopology, volumeNow, newMemberVolumeClient
+	t.Cleanup(func() {
+		volumeLimitTopology = origTop
+		volumeNow = origNow
+		newMemberVolumeClient = origMember
+	})
+	volumeLimitTopology = func(ctx context.Context, flags *rootFlags) (sonos.Topology, error) { return top, nil }
+	volumeNow = func() time.Time { return time.Date(2025, 6, 1, hour, 0, 0, 0, time.Local) }
+	newMemberVolumeClient = func(ip string, timeout time.Duration) memberVolumeClient {
+		return fakeMemberVolumeClient{f: members, ip: ip}
+	}
+	return members
+}
+
+func TestGroupVolumeSetUsesStrictestRoomLimit(t *testing.T) {
+	pinVolumeLimitTopology(t, 12)
+	origLoad, origClient := loadAppConfig, newGroupAudioClient
+	t.Cleanup(func() {
+		loadAppConfig = origLoad
+		newGroupAudioClient = origClient
+	})
+	loadAppConfig = func() (appconfig.Config, error) { return volumeLimitsTestConfig(), nil }
+	fake := &fakeGroupAudioClient{}
+	newGroupAudioClient = func(ctx context.Context, flags *rootFlags) (groupAudioClient, error) { return fake, nil }
+
+	run := func(args ...string) (string, string) {
+		t.Helper()
+		cmd := newGroupVolumeCmd(&rootFlags{Name: "Kitchen", Timeout: time.Second, Format: formatJSON})
+		var out, errOut captureWriter
+		cmd.SetOut(&out)
+		cmd.SetErr(&errOut)
+		cmd.SetArgs(args)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		if err := cmd.ExecuteContext(context.Background()); err != nil {
+			t.Fatalf("group volume %v: %v", args, err)
+		}
+		return out.String(), errOut.String()
+	}
+
+	out, errOut := run("set", "90")
+	if fake.setVolValue != 40 {
+		t.Fatalf("expected group volume 40, got %d", fake.setVolValue)
+	}
+	if !strings.Contains(errOut, "volume 90 capped to 40 (Kids Room maxVolume)") {
+		t.Fatalf("missing limit note: %q", errOut)
+	}
+	for _, want := range []string{`"volume": 40`, `"requested": 90`, `"limited": true`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %s: %s", want, out)
+		}
+	}
+
+	if _, errOut := run("set", "90", "--force"); fake.setVolValue != 90 || errOut != "" {
+		t.Fatalf("--force: volume %d, note %q", fake.setVolValue, errOut)
+	}
+}
+
+func TestGroupVolumeChangesHoldMembersToTheirOwnLimits(t *testing.T) {
+	// Group volume scales members proportionally: the group average stays
+	// under Kids Room's cap while Kids Room itself ends up above it.
+	members := pinVolumeLimitTopology(t, 12)
+	members.volumes["192.168.1.21"] = 55
+	origLoad, origClient := loadAppConfig, newGroupAudioClient
+	t.Cleanup(func() {
+		loadAppConfig = origLoad
+		newGroupAudioClient = origClient
+	})
+	loadAppConfig = func() (appconfig.Config, error) { return volumeLimitsTestConfig(), nil }
+	newGroupAudioClient = func(ctx context.Context, flags *rootFlags) (groupAudioClient, error) {
+		return &fakeGroupAudioClient{}, nil
+	}
+
+	cmd := newGroupVolumeCmd(&rootFlags{Name: "Kitchen", Timeout: time.Second, Format: formatPlain})
+	var errOut captureWriter
+	cmd.SetOut(newDiscardWriter())
+	cmd.SetErr(&errOut)
+	cmd.SetArgs([]string{"set", "35"})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("group volume set: %v", err)
+	}
+	if want := []string{"192.168.1.21=40"}; !reflect.DeepEqual(members.sets, want) {
+		t.Fatalf("member sets: %v", members.sets)
+	}
+	if !strings.Contains(errOut.String(), "Kids Room: volume 55 capped to 40 (Kids Room maxVolume)") {
+		t.Fatalf("missing member note: %q", errOut.String())
+	}
+
+	// A stepped group fade re-checks members after every step and notes it once.
+	members.sets = nil
+	members.volumes["192.168.1.21"] = 60
+	c := &fakeVolumeClient{groupVolume: 10}
+	out, err := runVolumeCmdWithConfig(t, &rootFlags{Name: "Kitchen", Timeout: time.Second, Format: formatPlain}, c, volumeLimitsTestConfig(), "fade", "13", "--group", "--over", "1s")
+	if err != nil {
+		t.Fatalf("fade: %v", err)
+	}
+	if want := []string{"group-set=11", "group-set=12", "group-set=13"}; !reflect.DeepEqual(c.calls, want) {
+		t.Fatalf("calls: %v", c.calls)
+	}
+	if want := []string{"192.168.1.21=40"}; !reflect.DeepEqual(members.sets, want) {
+		t.Fatalf("member sets: %v", members.sets)
+	}
+	if strings.Count(out, "Kids Room: volume 60 capped to 40") != 1 {
+		t.Fatalf("expected one member note: %q", out)
+	}
+}
+
+func TestVolumeSetIgnoresLimitsOfOtherGroupMembers(t *testing.T) {
+	pinVolumeLimitTopology(t, 21)
+	c := &fakeVolumeClient{volume: 30}
+
+	// `volume` changes only the coordinator (Kitchen), so Kids Room's quiet
+	// hours don't apply, but Kitchen's minVolume does.
+	out, err := runVolumeCmdWithConfig(t, &rootFlags{Name: "Kids Room", Timeout: time.Second, Format: formatPlain}, c, volumeLimitsTestConfig(), "fade", "0", "--over", "0")
+	if err != nil {
+		t.Fatalf("fade: %v", err)
+	}
+	if c.volume != 10 || !strings.Contains(out, "volume 0 raised to 10 (Kitchen minVolume)") {
+		t.Fatalf("volume %d, output %q", c.volume, out)
+	}
+}
+
+func TestVolumeFadeAndStepRespectQuietHours(t *testing.T) {
+	pinVolumeLimitTopology(t, 21)
+	flags := &rootFlags{Name: "Kitchen", Timeout: time.Second, Format: formatPlain}
+
+	c := &fakeVolumeClient{groupVolume: 10}
+	out, err := runVolumeCmdWithConfig(t, flags, c, volumeLimitsTestConfig(), "fade", "50", "--group", "--over", "0")
+	if err != nil {
+		t.Fatalf("fade: %v", err)
+	}
+	if want := []string{"group-set=15"}; !reflect.DeepEqual(c.calls, want) {
+		t.Fatalf("calls: %v", c.calls)
+	}
+	if !strings.Contains(out, "Kids Room quiet hours 19:30-07:00") {
+		t.Fatalf("missing limit note: %q", out)
+	}
+
+	// Limited rooms step with absolute volumes so they cannot overshoot.
+	c = &fakeVolumeClient{groupVolume: 12}
+	out, err = runVolumeCmdWithConfig(t, flags, c, volumeLimitsTestConfig(), "up", "--group", "10")
+	if err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if want := []string{"group-set=15"}; !reflect.DeepEqual(c.calls, want) {
+		t.Fatalf("calls: %v", c.calls)
+	}
+	if !strings.HasSuffix(strings.TrimSpace(out), "15") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestSceneApplyClampsPerRoom(t *testing.T) {
+	pinVolumeLimitTopology(t, 12)
+	scene := scenes.Scene{
+		Name:   "Party",
+		Groups: []scenes.SceneGroup{{CoordinatorUUID: "RINCON_K1400", MemberUUIDs: []string{"RINCON_K1400", "RINCON_KR1400"}}},
+		Devices: []scenes.SceneDevice{
+			{UUID: "RINCON_K1400", Name: "Kitchen", IP: "192.168.1.20", Volume: 60},
+			{UUID: "RINCON_KR1400", Name: "Kids Room", IP: "192.168.1.21", Volume: 80},
+		},
+	}
+	top := sonos.Topology{ByIP: map[string]sonos.Member{
+		"192.168.1.20": {Name: "Kitchen", IP: "192.168.1.20", UUID: "RINCON_K1400", IsVisible: true},
+		"192.168.1.21": {Name: "Kids Room", IP: "192.168.1.21", UUID: "RINCON_KR1400", IsVisible: true},
+	}}
+	speakers := map[string]*fakeSceneSpeaker{"192.168.1.20": {}, "192.168.1.21": {}}
+
+	origLoad, origStore, origTG, origClient := loadAppConfig, newSceneStore, newSceneTopologyGetter, newSceneSpeakerClient
+	t.Cleanup(func() {
+		loadAppConfig = origLoad
+		newSceneStore = origStore
+		newSceneTopologyGetter = origTG
+		newSceneSpeakerClient = origClient
+	})
+	loadAppConfig = func() (appconfig.Config, error) { return volumeLimitsTestConfig(), nil }
+	newSceneStore = func() (scenes.Store, error) {
+		return &fakeSceneStore{scenes: map[string]scenes.Scene{"Party": scene}}, nil
+	}
+	newSceneTopologyGetter = func(ctx context.Context, timeout time.Duration) (sceneTopologyGetter, error) {
+		return &fakeSceneTopologyGetter{top: top}, nil
+	}
+	newSceneSpeakerClient = func(ip string, timeout time.Duration) sceneSpeakerClient { return speakers[ip] }
+
+	cmd := newSceneCmd(&rootFlags{Timeout: time.Second, Format: formatJSON})
+	var out, errOut captureWriter
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetArgs([]string{"apply", "Party"})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("scene apply: %v", err)
+	}
+	if speakers["192.168.1.20"].setVolValue != 60 || speakers["192.168.1.21"].setVolValue != 40 {
+		t.Fatalf("volumes: kitchen=%d kids=%d", speakers["192.168.1.20"].setVolValue, speakers["192.168.1.21"].setVolValue)
+	}
+	if !strings.Contains(errOut.String(), "Kids Room: volume 80 capped to 40") {
+		t.Fatalf("missing limit note: %q", errOut.String())
+	}
+	if !strings.Contains(out.String(), `"Kids Room": 40`) {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+}
+
+func TestConfigSetRoomVolumeKeys(t *testing.T) {
+	store, err := appconfig.NewFileStore(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	orig := newConfigStore
+	t.Cleanup(func() { newConfigStore = orig })
+	newConfigStore = func() (appconfig.Store, error) { return store, nil }
+
+	flags := &rootFlags{Timeout: time.Second, Format: formatPlain}
+	for _, args := range [][]string{
+		{"set", "maxVolume.Kids Room", "40"},
+		{"set", "quietHours.kids room", "19:30-7:00=15"},
+		{"set", "minVolume.Kitchen", "10"},
+		{"unset", "minVolume.Kitchen"},
+	} {
+		cmd := newConfigCmd(flags)
+		cmd.SetOut(newDiscardWriter())
+		cmd.SetErr(newDiscardWriter())
+		cmd.SetArgs(args)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		if err := cmd.ExecuteContext(context.Background()); err != nil {
+			t.Fatalf("config %v: %v", args, err)
+		}
+	}
+
+	cfg, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := map[string]appconfig.RoomConfig{
+		"Kids Room": {MaxVolume: 40, QuietHours: []appconfig.QuietHours{{Start: "19:30", End: "07:00", MaxVolume: 15}}},
+	}
+	if !reflect.DeepEqual(cfg.Rooms, want) {
+		t.Fatalf("rooms: %+v", cfg.Rooms)
+	}
+
+	cmd := newConfigCmd(flags)
+	var out captureWriter
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"get"})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("config get: %v", err)
+	}
+	if !strings.Contains(out.String(), "maxVolume.Kids Room=40\n") || !strings.Contains(out.String(), "quietHours.Kids Room=19:30-07:00=15\n") {
+		t.Fatalf("unexpected get output: %q", out.String())
+	}
+
+	if _, err := setConfigKey(cfg, "maxVolume.Kids Room", "loud"); err == nil {
+		t.Fatalf("expected error for invalid maxVolume")
+	}
+}
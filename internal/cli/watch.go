@@ -148,6 +148,12 @@ func newWatchCmd(flags *rootFlags) *cobra.Command {
 				case <-ctx.Done():
 					return nil
 				case ev := <-events:
+					// The event only carries a generation counter; fetch the actual remaining time.
+					if _, ok := ev.Vars["sleep_timer_generation"]; ok && ev.Service == "avtransport" {
+						if d, err := c.GetRemainingSleepTimerDuration(ctx); err == nil {
+							ev.Vars["sleep_timer_remaining"] = formatSleepRemaining(d)
+						}
+					}
 					if isJSON(flags) {
 						_ = writeJSONLine(cmd, ev)
 						continue
@@ -16,6 +16,9 @@ type Store interface {
 	Get(name string) (Scene, bool, error)
 	Put(scene Scene) error
 	Delete(name string) error
+	// RenameRoom updates scenes that refer to a room by oldName and returns
+	// the names of the scenes it changed.
+	RenameRoom(oldName, newName string) ([]string, error)
 }
 
 type FileStore struct {
@@ -89,6 +92,29 @@ func (s *FileStore) Delete(name string) error {
 	return s.writeAll(data)
 }
 
+func (s *FileStore) RenameRoom(oldName, newName string) ([]string, error) {
+	oldName, newName = strings.TrimSpace(oldName), strings.TrimSpace(newName)
+	if oldName == "" || newName == "" {
+		return nil, errors.New("room name is required")
+	}
+	data, err := s.readAll()
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	for name, sc := range data {
+		if sc.RenameRoom(oldName, newName) {
+			data[name] = sc
+			changed = append(changed, name)
+		}
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	sort.Strings(changed)
+	return changed, s.writeAll(data)
+}
+
 type fileFormat struct {
 	Scenes map[string]Scene `json:"scenes"`
 }
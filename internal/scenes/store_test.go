@@ -53,3 +53,48 @@ func TestFileStoreCRUD(t *testing.T) {
 		t.Fatalf("expected missing after delete")
 	}
 }
+
+func TestFileStoreRenameRoom(t *testing.T) {
+	t.Parallel()
+
+	s := &FileStore{path: filepath.Join(t.TempDir(), "scenes.json")}
+	for _, sc := range []Scene{
+		{
+			Name:    "Evening",
+			Groups:  []SceneGroup{{CoordinatorUUID: "RINCON_O", CoordinatorName: "Office", MemberUUIDs: []string{"RINCON_O"}}},
+			Devices: []SceneDevice{{UUID: "RINCON_O", Name: "Office", Volume: 20}},
+		},
+		{
+			Name:    "Kitchen only",
+			Groups:  []SceneGroup{{CoordinatorUUID: "RINCON_K", CoordinatorName: "Kitchen", MemberUUIDs: []string{"RINCON_K"}}},
+			Devices: []SceneDevice{{UUID: "RINCON_K", Name: "Kitchen", Volume: 30}},
+		},
+	} {
+		if err := s.Put(sc); err != nil {
+			t.Fatalf("put: %v", err)
+		}
+	}
+
+	changed, err := s.RenameRoom("Office", "Studio")
+	if err != nil {
+		t.Fatalf("RenameRoom: %v", err)
+	}
+	if len(changed) != 1 || changed[0] != "Evening" {
+		t.Fatalf("changed: %v", changed)
+	}
+	got, _, err := s.Get("Evening")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Devices[0].Name != "Studio" || got.Groups[0].CoordinatorName != "Studio" {
+		t.Fatalf("scene not renamed: %+v", got)
+	}
+	kitchen, _, _ := s.Get("Kitchen only")
+	if kitchen.Devices[0].Name != "Kitchen" {
+		t.Fatalf("unrelated scene changed: %+v", kitchen)
+	}
+
+	if changed, err := s.RenameRoom("Office", "Studio"); err != nil || len(changed) != 0 {
+		t.Fatalf("second rename: %v, %v", changed, err)
+	}
+}
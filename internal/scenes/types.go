@@ -24,6 +24,25 @@ type SceneDevice struct {
 	Mute   bool   `json:"mute"`
 }
 
+// RenameRoom replaces the room name oldName with newName in the scene's
+// devices and group coordinators. It reports whether anything changed.
+func (s *Scene) RenameRoom(oldName, newName string) bool {
+	changed := false
+	for i := range s.Devices {
+		if s.Devices[i].Name == oldName {
+			s.Devices[i].Name = newName
+			changed = true
+		}
+	}
+	for i := range s.Groups {
+		if s.Groups[i].CoordinatorName == oldName {
+			s.Groups[i].CoordinatorName = newName
+			changed = true
+		}
+	}
+	return changed
+}
+
 type SceneMeta struct {
 	Name      string    `json:"name"`
 	CreatedAt time.Time `json:"createdAt"`
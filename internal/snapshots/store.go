@@ -0,0 +1,134 @@
+package snapshots
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Store interface {
+	List() ([]EntryMeta, error)
+	Get(id string) (Entry, bool, error)
+	Put(entry Entry) error
+	Delete(id string) error
+}
+
+type FileStore struct {
+	path string
+}
+
+func NewFileStore() (*FileStore, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return &FileStore{path: filepath.Join(dir, "sonoscli", "snapshots.json")}, nil
+}
+
+func (s *FileStore) List() ([]EntryMeta, error) {
+	data, err := s.readAll()
+	if err != nil {
+		return nil, err
+	}
+	metas := make([]EntryMeta, 0, len(data))
+	for _, e := range data {
+		metas = append(metas, EntryMeta{
+			ID:          e.ID,
+			CreatedAt:   e.CreatedAt,
+			Coordinator: e.Snapshot.CoordinatorName,
+			Source:      string(e.Snapshot.Source),
+		})
+	}
+	sort.Slice(metas, func(i, j int) bool { return metas[i].ID < metas[j].ID })
+	return metas, nil
+}
+
+func (s *FileStore) Get(id string) (Entry, bool, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return Entry{}, false, nil
+	}
+	data, err := s.readAll()
+	if err != nil {
+		return Entry{}, false, err
+	}
+	e, ok := data[id]
+	return e, ok, nil
+}
+
+func (s *FileStore) Put(entry Entry) error {
+	entry.ID = strings.TrimSpace(entry.ID)
+	if entry.ID == "" {
+		return errors.New("snapshot id is required")
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+
+	data, err := s.readAll()
+	if err != nil {
+		return err
+	}
+	data[entry.ID] = entry
+	return s.writeAll(data)
+}
+
+func (s *FileStore) Delete(id string) error {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return errors.New("snapshot id is required")
+	}
+	data, err := s.readAll()
+	if err != nil {
+		return err
+	}
+	if _, ok := data[id]; !ok {
+		return nil
+	}
+	delete(data, id)
+	return s.writeAll(data)
+}
+
+type fileFormat struct {
+	Snapshots map[string]Entry `json:"snapshots"`
+}
+
+func (s *FileStore) readAll() (map[string]Entry, error) {
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]Entry{}, nil
+		}
+		return nil, err
+	}
+	var ff fileFormat
+	if err := json.Unmarshal(b, &ff); err != nil {
+		return nil, fmt.Errorf("parse snapshots store: %w", err)
+	}
+	if ff.Snapshots == nil {
+		ff.Snapshots = map[string]Entry{}
+	}
+	return ff.Snapshots, nil
+}
+
+func (s *FileStore) writeAll(data map[string]Entry) error {
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	ff := fileFormat{Snapshots: data}
+	b, err := json.MarshalIndent(ff, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
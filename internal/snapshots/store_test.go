@@ -0,0 +1,68 @@
+package snapshots
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+func TestNewFileStore_PathSuffix(t *testing.T) {
+	s, err := NewFileStore()
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	p := filepath.ToSlash(s.path)
+	if !strings.HasSuffix(p, "/sonoscli/snapshots.json") {
+		t.Fatalf("unexpected path: %q", p)
+	}
+}
+
+func TestFileStoreCRUD(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	s := &FileStore{path: filepath.Join(dir, "snapshots.json")}
+
+	if metas, err := s.List(); err != nil || len(metas) != 0 {
+		t.Fatalf("expected empty list, got metas=%v err=%v", metas, err)
+	}
+
+	entry := Entry{ID: "before-announce", Snapshot: sonos.Snapshot{
+		CoordinatorName: "Kitchen",
+		URI:             "x-rincon-queue:RINCON_A1400#0",
+		Source:          sonos.SourceQueue,
+		Track:           3,
+		Members:         []sonos.SnapshotMember{{UUID: "RINCON_A1400", Volume: 20}},
+	}}
+	if err := s.Put(entry); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	if err := s.Put(Entry{ID: " "}); err == nil {
+		t.Fatalf("expected error for empty id")
+	}
+
+	got, ok, err := s.Get("before-announce")
+	if err != nil || !ok {
+		t.Fatalf("get: ok=%v err=%v", ok, err)
+	}
+	if got.Snapshot.Track != 3 || len(got.Snapshot.Members) != 1 || got.CreatedAt.IsZero() {
+		t.Fatalf("unexpected entry: %+v", got)
+	}
+
+	metas, err := s.List()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(metas) != 1 || metas[0].Coordinator != "Kitchen" || metas[0].Source != "queue" {
+		t.Fatalf("unexpected metas: %v", metas)
+	}
+
+	if err := s.Delete("before-announce"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, ok, _ := s.Get("before-announce"); ok {
+		t.Fatalf("expected missing after delete")
+	}
+}
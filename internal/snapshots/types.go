@@ -0,0 +1,20 @@
+package snapshots
+
+import (
+	"time"
+
+	"github.com/steipete/sonoscli/internal/sonos"
+)
+
+type Entry struct {
+	ID        string         `json:"id"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Snapshot  sonos.Snapshot `json:"snapshot"`
+}
+
+type EntryMeta struct {
+	ID          string    `json:"id"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Coordinator string    `json:"coordinator,omitempty"`
+	Source      string    `json:"source,omitempty"`
+}
@@ -0,0 +1,285 @@
+package sonos
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AlarmChimeURI is the built-in Sonos chime used when an alarm has no program.
+const AlarmChimeURI = "x-rincon-buzzer:0"
+
+// Recurrence values understood by the AlarmClock service. Specific days are
+// encoded as ON_<days>, where 0 = Sunday ... 6 = Saturday (e.g. ON_135).
+const (
+	RecurrenceOnce     = "ONCE"
+	RecurrenceDaily    = "DAILY"
+	RecurrenceWeekdays = "WEEKDAYS"
+	RecurrenceWeekends = "WEEKENDS"
+)
+
+// Alarm mirrors an <Alarm> entry of AlarmClock.ListAlarms. Alarms are stored
+// household-wide, so any speaker can list or modify them.
+type Alarm struct {
+	ID                 string   `json:"id"`
+	StartTime          string   `json:"startTime"` // local time, HH:MM:SS
+	Duration           string   `json:"duration"`  // HH:MM:SS
+	Recurrence         string   `json:"recurrence"`
+	Enabled            bool     `json:"enabled"`
+	RoomUUID           string   `json:"roomUUID"`
+	ProgramURI         string   `json:"programURI"`
+	ProgramMetaData    string   `json:"programMetaData,omitempty"`
+	PlayMode           PlayMode `json:"playMode"`
+	Volume             int      `json:"volume"`
+	IncludeLinkedZones bool     `json:"includeLinkedZones"`
+}
+
+// ProgramTitle returns a display title for the alarm's program.
+func (a Alarm) ProgramTitle() string {
+	if a.ProgramURI == "" || a.ProgramURI == AlarmChimeURI {
+		return "Sonos Chime"
+	}
+	if items, err := ParseDIDLItems(a.ProgramMetaData); err == nil && len(items) > 0 && items[0].Title != "" {
+		return items[0].Title
+	}
+	return a.ProgramURI
+}
+
+type alarmList struct {
+	Alarms []struct {
+		ID                 string `xml:"ID,attr"`
+		StartTime          string `xml:"StartTime,attr"`
+		Duration           string `xml:"Duration,attr"`
+		Recurrence         string `xml:"Recurrence,attr"`
+		Enabled            string `xml:"Enabled,attr"`
+		RoomUUID           string `xml:"RoomUUID,attr"`
+		ProgramURI         string `xml:"ProgramURI,attr"`
+		ProgramMetaData    string `xml:"ProgramMetaData,attr"`
+		PlayMode           string `xml:"PlayMode,attr"`
+		Volume             string `xml:"Volume,attr"`
+		IncludeLinkedZones string `xml:"IncludeLinkedZones,attr"`
+	} `xml:"Alarm"`
+}
+
+func parseAlarmListXML(payload string) ([]Alarm, error) {
+	payload = strings.TrimSpace(payload)
+	if payload == "" {
+		return nil, nil
+	}
+	var list alarmList
+	if err := xml.Unmarshal([]byte(payload), &list); err != nil {
+		return nil, fmt.Errorf("parse alarm list: %w", err)
+	}
+	out := make([]Alarm, 0, len(list.Alarms))
+	for _, a := range list.Alarms {
+		vol, _ := strconv.Atoi(a.Volume)
+		out = append(out, Alarm{
+			ID:                 a.ID,
+			StartTime:          a.StartTime,
+			Duration:           a.Duration,
+			Recurrence:         a.Recurrence,
+			Enabled:            a.Enabled == "1",
+			RoomUUID:           a.RoomUUID,
+			ProgramURI:         a.ProgramURI,
+			ProgramMetaData:    a.ProgramMetaData,
+			PlayMode:           PlayMode(a.PlayMode),
+			Volume:             vol,
+			IncludeLinkedZones: a.IncludeLinkedZones == "1",
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		ni, _ := strconv.Atoi(out[i].ID)
+		nj, _ := strconv.Atoi(out[j].ID)
+		return ni < nj
+	})
+	return out, nil
+}
+
+func (c *Client) ListAlarms(ctx context.Context) ([]Alarm, error) {
+	resp, err := c.soapCall(ctx, controlAlarmClock, urnAlarmClock, "ListAlarms", nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseAlarmListXML(resp["CurrentAlarmList"])
+}
+
+// CreateAlarm adds a new alarm and returns the ID assigned by the speaker.
+func (c *Client) CreateAlarm(ctx context.Context, alarm Alarm) (string, error) {
+	args, err := alarmArgs(alarm)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.soapCall(ctx, controlAlarmClock, urnAlarmClock, "CreateAlarm", args)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(resp["AssignedID"]), nil
+}
+
+// UpdateAlarm replaces every field of an existing alarm; callers should start
+// from the value returned by ListAlarms.
+func (c *Client) UpdateAlarm(ctx context.Context, alarm Alarm) error {
+	if strings.TrimSpace(alarm.ID) == "" {
+		return errors.New("alarm ID is required")
+	}
+	args, err := alarmArgs(alarm)
+	if err != nil {
+		return err
+	}
+	args["ID"] = alarm.ID
+	_, err = c.soapCall(ctx, controlAlarmClock, urnAlarmClock, "UpdateAlarm", args)
+	return err
+}
+
+func (c *Client) DestroyAlarm(ctx context.Context, id string) error {
+	if strings.TrimSpace(id) == "" {
+		return errors.New("alarm ID is required")
+	}
+	_, err := c.soapCall(ctx, controlAlarmClock, urnAlarmClock, "DestroyAlarm", map[string]string{
+		"ID": id,
+	})
+	return err
+}
+
+func alarmArgs(a Alarm) (map[string]string, error) {
+	start, err := NormalizeAlarmTime(a.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	duration := a.Duration
+	if duration == "" {
+		duration = "01:00:00"
+	}
+	recurrence, err := ParseRecurrence(a.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	if a.RoomUUID == "" {
+		return nil, errors.New("alarm room UUID is required")
+	}
+	programURI := a.ProgramURI
+	if programURI == "" {
+		programURI = AlarmChimeURI
+	}
+	playMode := a.PlayMode
+	if playMode == "" {
+		playMode = PlayModeShuffleNoRepeat
+	}
+	if _, err := ParsePlayMode(string(playMode)); err != nil {
+		return nil, err
+	}
+	volume := a.Volume
+	if volume < 0 {
+		volume = 0
+	}
+	if volume > 100 {
+		volume = 100
+	}
+	return map[string]string{
+		"StartLocalTime":     start,
+		"Duration":           duration,
+		"Recurrence":         recurrence,
+		"Enabled":            boolArg(a.Enabled),
+		"RoomUUID":           a.RoomUUID,
+		"ProgramURI":         programURI,
+		"ProgramMetaData":    a.ProgramMetaData,
+		"PlayMode":           string(playMode),
+		"Volume":             strconv.Itoa(volume),
+		"IncludeLinkedZones": boolArg(a.IncludeLinkedZones),
+	}, nil
+}
+
+func boolArg(v bool) string {
+	if v {
+		return "1"
+	}
+	return "0"
+}
+
+// NormalizeAlarmTime accepts 24h "H:MM" or "H:MM:SS" and returns "HH:MM:SS".
+func NormalizeAlarmTime(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return "", fmt.Errorf("invalid alarm time %q (expected HH:MM or HH:MM:SS)", s)
+	}
+	limits := []int{24, 60, 60}
+	vals := []int{0, 0, 0}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || n >= limits[i] {
+			return "", fmt.Errorf("invalid alarm time %q (expected HH:MM or HH:MM:SS)", s)
+		}
+		vals[i] = n
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", vals[0], vals[1], vals[2]), nil
+}
+
+var recurrenceDays = map[string]byte{
+	"sun": '0', "sunday": '0',
+	"mon": '1', "monday": '1',
+	"tue": '2', "tuesday": '2',
+	"wed": '3', "wednesday": '3',
+	"thu": '4', "thursday": '4',
+	"fri": '5', "friday": '5',
+	"sat": '6', "saturday": '6',
+}
+
+// ParseRecurrence accepts once|daily|weekdays|weekends, a raw ON_<digits>
+// value, or a comma-separated list of day names (e.g. "mon,wed,fri").
+func ParseRecurrence(s string) (string, error) {
+	v := strings.TrimSpace(s)
+	if v == "" {
+		return RecurrenceDaily, nil
+	}
+	switch strings.ToUpper(v) {
+	case RecurrenceOnce, RecurrenceDaily, RecurrenceWeekdays, RecurrenceWeekends:
+		return strings.ToUpper(v), nil
+	}
+
+	var days []byte
+	if upper := strings.ToUpper(v); strings.HasPrefix(upper, "ON_") {
+		days = []byte(upper[3:])
+		for _, d := range days {
+			if d < '0' || d > '6' {
+				return "", fmt.Errorf("invalid recurrence %q", s)
+			}
+		}
+	} else {
+		for _, part := range strings.Split(v, ",") {
+			d, ok := recurrenceDays[strings.ToLower(strings.TrimSpace(part))]
+			if !ok {
+				return "", fmt.Errorf("invalid recurrence %q (expected once|daily|weekdays|weekends or day names like mon,wed,fri)", s)
+			}
+			days = append(days, d)
+		}
+	}
+	if len(days) == 0 {
+		return "", fmt.Errorf("invalid recurrence %q", s)
+	}
+
+	seen := map[byte]bool{}
+	uniq := make([]byte, 0, len(days))
+	for _, d := range days {
+		if !seen[d] {
+			seen[d] = true
+			uniq = append(uniq, d)
+		}
+	}
+	sort.Slice(uniq, func(i, j int) bool { return uniq[i] < uniq[j] })
+	return "ON_" + string(uniq), nil
+}
+
+// AlarmProgramFromFavorite returns the ProgramURI/ProgramMetaData pair for
+// using a Sonos Favorite as an alarm source.
+func AlarmProgramFromFavorite(favorite DIDLItem) (uri, meta string, err error) {
+	uri = favoriteURI(favorite)
+	if uri == "" {
+		return "", "", errors.New("favorite has no URI")
+	}
+	return uri, favorite.ResMD, nil
+}
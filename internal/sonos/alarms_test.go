@@ -0,0 +1,156 @@
+package sonos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListAlarmsParsesAttributes(t *testing.T) {
+	t.Parallel()
+
+	list := `<Alarms>` +
+		`<Alarm ID="14" StartTime="07:00:00" Duration="02:00:00" Recurrence="WEEKDAYS" Enabled="1" RoomUUID="RINCON_BED1400" ProgramURI="x-sonosapi-stream:s6712?sid=254" ProgramMetaData="&lt;DIDL-Lite xmlns:dc=&quot;http://purl.org/dc/elements/1.1/&quot; xmlns=&quot;urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/&quot;&gt;&lt;item id=&quot;x&quot;&gt;&lt;dc:title&gt;Radio 6&lt;/dc:title&gt;&lt;/item&gt;&lt;/DIDL-Lite&gt;" PlayMode="SHUFFLE_NOREPEAT" Volume="25" IncludeLinkedZones="0"/>` +
+		`<Alarm ID="3" StartTime="22:30:00" Duration="00:30:00" Recurrence="ON_06" Enabled="0" RoomUUID="RINCON_KID1400" ProgramURI="x-rincon-buzzer:0" ProgramMetaData="" PlayMode="NORMAL" Volume="10" IncludeLinkedZones="1"/>` +
+		`</Alarms>`
+	escaped := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(list)
+
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/AlarmClock/Control" {
+			t.Fatalf("path: %s", r.URL.Path)
+		}
+		if !strings.Contains(r.Header.Get("SOAPACTION"), "AlarmClock:1#ListAlarms") {
+			t.Fatalf("SOAPACTION: %q", r.Header.Get("SOAPACTION"))
+		}
+		return httpResponse(200, `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><u:ListAlarmsResponse xmlns:u="urn:schemas-upnp-org:service:AlarmClock:1"><CurrentAlarmList>`+escaped+`</CurrentAlarmList><CurrentAlarmListVersion>RINCON_X:42</CurrentAlarmListVersion></u:ListAlarmsResponse></s:Body></s:Envelope>`), nil
+	})
+
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+	alarms, err := c.ListAlarms(context.Background())
+	if err != nil {
+		t.Fatalf("ListAlarms: %v", err)
+	}
+	if len(alarms) != 2 {
+		t.Fatalf("alarms: %d", len(alarms))
+	}
+	// Sorted by numeric ID.
+	if alarms[0].ID != "3" || alarms[1].ID != "14" {
+		t.Fatalf("order: %q %q", alarms[0].ID, alarms[1].ID)
+	}
+	a := alarms[1]
+	if a.StartTime != "07:00:00" || a.Duration != "02:00:00" || a.Recurrence != "WEEKDAYS" || !a.Enabled ||
+		a.RoomUUID != "RINCON_BED1400" || a.PlayMode != PlayModeShuffleNoRepeat || a.Volume != 25 || a.IncludeLinkedZones {
+		t.Fatalf("unexpected alarm: %+v", a)
+	}
+	if a.ProgramTitle() != "Radio 6" {
+		t.Fatalf("program title: %q", a.ProgramTitle())
+	}
+	if alarms[0].Enabled || !alarms[0].IncludeLinkedZones || alarms[0].ProgramTitle() != "Sonos Chime" {
+		t.Fatalf("unexpected alarm: %+v", alarms[0])
+	}
+}
+
+func TestCreateUpdateDestroyAlarm(t *testing.T) {
+	t.Parallel()
+
+	bodies := map[string]string{}
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		name := action[strings.Index(action, "#")+1 : len(action)-1]
+		bodies[name] = readBody(t, r)
+		switch name {
+		case "CreateAlarm":
+			return httpResponse(200, `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><u:CreateAlarmResponse xmlns:u="urn:schemas-upnp-org:service:AlarmClock:1"><AssignedID>21</AssignedID></u:CreateAlarmResponse></s:Body></s:Envelope>`), nil
+		case "UpdateAlarm", "DestroyAlarm":
+			return httpResponse(200, `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><u:`+name+`Response xmlns:u="urn:schemas-upnp-org:service:AlarmClock:1"></u:`+name+`Response></s:Body></s:Envelope>`), nil
+		default:
+			t.Fatalf("unexpected SOAPACTION: %q", action)
+			return nil, nil
+		}
+	})
+
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+	alarm := Alarm{
+		StartTime:  "6:45",
+		Recurrence: "mon,wed",
+		Enabled:    true,
+		RoomUUID:   "RINCON_BED1400",
+		Volume:     120,
+	}
+	id, err := c.CreateAlarm(context.Background(), alarm)
+	if err != nil {
+		t.Fatalf("CreateAlarm: %v", err)
+	}
+	if id != "21" {
+		t.Fatalf("id: %q", id)
+	}
+	body := bodies["CreateAlarm"]
+	for _, want := range []string{
+		"<StartLocalTime>06:45:00</StartLocalTime>",
+		"<Duration>01:00:00</Duration>",
+		"<Recurrence>ON_13</Recurrence>",
+		"<Enabled>1</Enabled>",
+		"<ProgramURI>x-rincon-buzzer:0</ProgramURI>",
+		"<PlayMode>SHUFFLE_NOREPEAT</PlayMode>",
+		"<Volume>100</Volume>",
+		"<IncludeLinkedZones>0</IncludeLinkedZones>",
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("missing %s in %s", want, body)
+		}
+	}
+
+	alarm.ID = "21"
+	alarm.Enabled = false
+	if err := c.UpdateAlarm(context.Background(), alarm); err != nil {
+		t.Fatalf("UpdateAlarm: %v", err)
+	}
+	if !strings.Contains(bodies["UpdateAlarm"], "<ID>21</ID>") || !strings.Contains(bodies["UpdateAlarm"], "<Enabled>0</Enabled>") {
+		t.Fatalf("update body: %s", bodies["UpdateAlarm"])
+	}
+	if err := c.UpdateAlarm(context.Background(), Alarm{StartTime: "07:00"}); err == nil {
+		t.Fatalf("expected error without ID")
+	}
+
+	if err := c.DestroyAlarm(context.Background(), "21"); err != nil {
+		t.Fatalf("DestroyAlarm: %v", err)
+	}
+	if !strings.Contains(bodies["DestroyAlarm"], "<ID>21</ID>") {
+		t.Fatalf("destroy body: %s", bodies["DestroyAlarm"])
+	}
+}
+
+func TestParseRecurrenceAndAlarmTime(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"":                "DAILY",
+		"weekdays":        "WEEKDAYS",
+		"Once":            "ONCE",
+		"on_531":          "ON_135",
+		"sat,sun":         "ON_06",
+		"Friday, mon,fri": "ON_15",
+	}
+	for in, want := range cases {
+		got, err := ParseRecurrence(in)
+		if err != nil || got != want {
+			t.Fatalf("ParseRecurrence(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"ON_7", "ON_", "someday"} {
+		if _, err := ParseRecurrence(in); err == nil {
+			t.Fatalf("ParseRecurrence(%q): expected error", in)
+		}
+	}
+
+	if got, err := NormalizeAlarmTime("7:05"); err != nil || got != "07:05:00" {
+		t.Fatalf("NormalizeAlarmTime: %q %v", got, err)
+	}
+	for _, in := range []string{"24:00", "7", "07:60", "x:00"} {
+		if _, err := NormalizeAlarmTime(in); err == nil {
+			t.Fatalf("NormalizeAlarmTime(%q): expected error", in)
+		}
+	}
+}
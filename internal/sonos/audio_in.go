@@ -0,0 +1,168 @@
+package sonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrLineInNotSupported is returned when a speaker has no line-in port
+// (no AudioIn service, or it rejects the action).
+var ErrLineInNotSupported = errors.New("speaker has no line-in")
+
+// Line-in levels accepted by SetLineInLevel (the Sonos app's "Line-In Source
+// Level" 1-10; 0 is accepted by the speaker as well).
+const (
+	MinLineInLevel = 0
+	MaxLineInLevel = 10
+)
+
+// LineInSettings is the line-in configuration of the speaker that owns the
+// port. Autoplay settings live in DeviceProperties on the same speaker.
+type LineInSettings struct {
+	SourceName                 string `json:"sourceName"`
+	Icon                       string `json:"icon,omitempty"`
+	LeftLevel                  int    `json:"leftLevel"`
+	RightLevel                 int    `json:"rightLevel"`
+	AutoplayRoomUUID           string `json:"autoplayRoomUUID,omitempty"`
+	AutoplayIncludeLinkedZones bool   `json:"autoplayIncludeLinkedZones"`
+}
+
+// GetAudioInputAttributes returns the displayed line-in source name and icon.
+func (c *Client) GetAudioInputAttributes(ctx context.Context) (name, icon string, err error) {
+	resp, err := c.soapCall(ctx, controlAudioIn, urnAudioIn, "GetAudioInputAttributes", nil)
+	if err != nil {
+		return "", "", audioInError("GetAudioInputAttributes", err)
+	}
+	return resp["CurrentName"], resp["CurrentIcon"], nil
+}
+
+// SetAudioInputAttributes sets the displayed line-in source name and icon.
+// Both are sent; pass the current icon to change only the name.
+func (c *Client) SetAudioInputAttributes(ctx context.Context, name, icon string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("line-in source name is required")
+	}
+	_, err := c.soapCall(ctx, controlAudioIn, urnAudioIn, "SetAudioInputAttributes", map[string]string{
+		"DesiredName": name,
+		"DesiredIcon": icon,
+	})
+	return audioInError("SetAudioInputAttributes", err)
+}
+
+// GetLineInLevel returns the left and right line-in levels.
+func (c *Client) GetLineInLevel(ctx context.Context) (left, right int, err error) {
+	resp, err := c.soapCall(ctx, controlAudioIn, urnAudioIn, "GetLineInLevel", nil)
+	if err != nil {
+		return 0, 0, audioInError("GetLineInLevel", err)
+	}
+	left, err = strconv.Atoi(strings.TrimSpace(resp["CurrentLeftLineInLevel"]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse CurrentLeftLineInLevel: %w", err)
+	}
+	right, err = strconv.Atoi(strings.TrimSpace(resp["CurrentRightLineInLevel"]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse CurrentRightLineInLevel: %w", err)
+	}
+	return left, right, nil
+}
+
+// SetLineInLevel sets the left and right line-in levels (0-10).
+func (c *Client) SetLineInLevel(ctx context.Context, left, right int) error {
+	for _, v := range []int{left, right} {
+		if v < MinLineInLevel || v > MaxLineInLevel {
+			return fmt.Errorf("line-in level must be %d-%d, got %d", MinLineInLevel, MaxLineInLevel, v)
+		}
+	}
+	_, err := c.soapCall(ctx, controlAudioIn, urnAudioIn, "SetLineInLevel", map[string]string{
+		"DesiredLeftLineInLevel":  strconv.Itoa(left),
+		"DesiredRightLineInLevel": strconv.Itoa(right),
+	})
+	return audioInError("SetLineInLevel", err)
+}
+
+// GetAutoplayRoomUUID returns the room that starts playing line-in when a
+// signal is detected ("" when autoplay is off).
+func (c *Client) GetAutoplayRoomUUID(ctx context.Context) (string, error) {
+	resp, err := c.soapCall(ctx, controlDeviceProperties, urnDeviceProperties, "GetAutoplayRoomUUID", nil)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(resp["RoomUUID"]), nil
+}
+
+// SetAutoplayRoomUUID sets the autoplay room; "" turns autoplay off.
+func (c *Client) SetAutoplayRoomUUID(ctx context.Context, roomUUID string) error {
+	_, err := c.soapCall(ctx, controlDeviceProperties, urnDeviceProperties, "SetAutoplayRoomUUID", map[string]string{
+		"RoomUUID": strings.TrimSpace(roomUUID),
+	})
+	return err
+}
+
+// GetAutoplayLinkedZones reports whether autoplay also starts the rooms
+// grouped with the autoplay room.
+func (c *Client) GetAutoplayLinkedZones(ctx context.Context) (bool, error) {
+	resp, err := c.soapCall(ctx, controlDeviceProperties, urnDeviceProperties, "GetAutoplayLinkedZones", nil)
+	if err != nil {
+		return false, err
+	}
+	v := strings.TrimSpace(resp["IncludeLinkedZones"])
+	return v == "1" || strings.EqualFold(v, "true"), nil
+}
+
+func (c *Client) SetAutoplayLinkedZones(ctx context.Context, include bool) error {
+	v := "0"
+	if include {
+		v = "1"
+	}
+	_, err := c.soapCall(ctx, controlDeviceProperties, urnDeviceProperties, "SetAutoplayLinkedZones", map[string]string{
+		"IncludeLinkedZones": v,
+	})
+	return err
+}
+
+// GetLineInSettings reads all line-in settings. It fails with
+// ErrLineInNotSupported on speakers without a line-in port.
+func (c *Client) GetLineInSettings(ctx context.Context) (LineInSettings, error) {
+	var s LineInSettings
+	var err error
+	if s.SourceName, s.Icon, err = c.GetAudioInputAttributes(ctx); err != nil {
+		return LineInSettings{}, err
+	}
+	if s.LeftLevel, s.RightLevel, err = c.GetLineInLevel(ctx); err != nil {
+		return LineInSettings{}, err
+	}
+	if s.AutoplayRoomUUID, err = c.GetAutoplayRoomUUID(ctx); err != nil {
+		return LineInSettings{}, err
+	}
+	if s.AutoplayIncludeLinkedZones, err = c.GetAutoplayLinkedZones(ctx); err != nil {
+		return LineInSettings{}, err
+	}
+	return s, nil
+}
+
+// audioInError maps the responses of a speaker without line-in (no AudioIn
+// service: HTTP 404; invalid or unimplemented action: 401/602) to
+// ErrLineInNotSupported. Anything else, such as 402 for an argument the
+// speaker rejects, is returned wrapped with the action name.
+func audioInError(action string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var upnpErr *UPnPError
+	if errors.As(err, &upnpErr) {
+		switch strings.TrimSpace(upnpErr.Code) {
+		case "401", "602":
+			return fmt.Errorf("%s: %w (%s)", action, ErrLineInNotSupported, upnpErr.Error())
+		}
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s: %w (%s)", action, ErrLineInNotSupported, httpErr.Error())
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
@@ -0,0 +1,142 @@
+package sonos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLineInSettingsGetAndSet(t *testing.T) {
+	t.Parallel()
+
+	var bodies []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		bodies = append(bodies, readBody(t, r))
+		switch {
+		case strings.Contains(action, "AudioIn:1#GetAudioInputAttributes"):
+			if r.URL.Path != controlAudioIn {
+				t.Fatalf("path: %s", r.URL.Path)
+			}
+			return httpResponse(200, soapOK(urnAudioIn, "GetAudioInputAttributes", `<CurrentName>Turntable</CurrentName><CurrentIcon>linein</CurrentIcon>`)), nil
+		case strings.Contains(action, "AudioIn:1#GetLineInLevel"):
+			return httpResponse(200, soapOK(urnAudioIn, "GetLineInLevel", `<CurrentLeftLineInLevel>7</CurrentLeftLineInLevel><CurrentRightLineInLevel>6</CurrentRightLineInLevel>`)), nil
+		case strings.Contains(action, "DeviceProperties:1#GetAutoplayRoomUUID"):
+			return httpResponse(200, soapOK(urnDeviceProperties, "GetAutoplayRoomUUID", `<RoomUUID>RINCON_K1400</RoomUUID>`)), nil
+		case strings.Contains(action, "DeviceProperties:1#GetAutoplayLinkedZones"):
+			return httpResponse(200, soapOK(urnDeviceProperties, "GetAutoplayLinkedZones", `<IncludeLinkedZones>1</IncludeLinkedZones>`)), nil
+		case strings.Contains(action, "AudioIn:1#SetAudioInputAttributes"),
+			strings.Contains(action, "AudioIn:1#SetLineInLevel"),
+			strings.Contains(action, "DeviceProperties:1#SetAutoplayRoomUUID"),
+			strings.Contains(action, "DeviceProperties:1#SetAutoplayLinkedZones"):
+			name := action[strings.Index(action, "#")+1 : len(action)-1]
+			return httpResponse(200, soapOK(urnAudioIn, name, ``)), nil
+		default:
+			t.Fatalf("unexpected SOAPACTION: %q", action)
+			return nil, nil
+		}
+	})
+
+	c := &Client{
+		IP: "192.0.2.1",
+		HTTP: &http.Client{
+			Timeout:   time.Second,
+			Transport: rt,
+		},
+	}
+
+	got, err := c.GetLineInSettings(context.Background())
+	if err != nil {
+		t.Fatalf("GetLineInSettings: %v", err)
+	}
+	want := LineInSettings{SourceName: "Turntable", Icon: "linein", LeftLevel: 7, RightLevel: 6, AutoplayRoomUUID: "RINCON_K1400", AutoplayIncludeLinkedZones: true}
+	if got != want {
+		t.Fatalf("settings: %+v", got)
+	}
+
+	bodies = nil
+	if err := c.SetAudioInputAttributes(context.Background(), " Record Player ", "linein"); err != nil {
+		t.Fatalf("SetAudioInputAttributes: %v", err)
+	}
+	if err := c.SetLineInLevel(context.Background(), 8, 8); err != nil {
+		t.Fatalf("SetLineInLevel: %v", err)
+	}
+	if err := c.SetAutoplayRoomUUID(context.Background(), ""); err != nil {
+		t.Fatalf("SetAutoplayRoomUUID: %v", err)
+	}
+	if err := c.SetAutoplayLinkedZones(context.Background(), false); err != nil {
+		t.Fatalf("SetAutoplayLinkedZones: %v", err)
+	}
+	for i, want := range []string{
+		"<DesiredName>Record Player</DesiredName>",
+		"<DesiredLeftLineInLevel>8</DesiredLeftLineInLevel>",
+		"<RoomUUID></RoomUUID>",
+		"<IncludeLinkedZones>0</IncludeLinkedZones>",
+	} {
+		if !strings.Contains(bodies[i], want) {
+			t.Fatalf("body %d missing %s: %s", i, want, bodies[i])
+		}
+	}
+
+	if err := c.SetLineInLevel(context.Background(), 11, 5); err == nil {
+		t.Fatalf("expected range error")
+	}
+	if err := c.SetAudioInputAttributes(context.Background(), " ", ""); err == nil {
+		t.Fatalf("expected empty name error")
+	}
+}
+
+func TestLineInNotSupported(t *testing.T) {
+	t.Parallel()
+
+	for _, resp := range []func() *http.Response{
+		func() *http.Response {
+			resp := httpResponse(404, "")
+			resp.Status = "404 Not Found"
+			return resp
+		},
+		func() *http.Response { return httpResponse(500, soapFaultWithUPnPCode("401")) },
+	} {
+		resp := resp
+		c := &Client{
+			IP: "192.0.2.1",
+			HTTP: &http.Client{
+				Timeout: time.Second,
+				Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+					return resp(), nil
+				}),
+			},
+		}
+		_, err := c.GetLineInSettings(context.Background())
+		if !errors.Is(err, ErrLineInNotSupported) {
+			t.Fatalf("expected ErrLineInNotSupported, got %v", err)
+		}
+	}
+}
+
+func TestLineInOtherErrorsPassThrough(t *testing.T) {
+	t.Parallel()
+
+	for _, code := range []string{"402", "501"} {
+		c := &Client{
+			IP: "192.0.2.1",
+			HTTP: &http.Client{
+				Timeout: time.Second,
+				Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+					return httpResponse(500, soapFaultWithUPnPCode(code)), nil
+				}),
+			},
+		}
+		err := c.SetAudioInputAttributes(context.Background(), "Turntable", "linein")
+		if errors.Is(err, ErrLineInNotSupported) {
+			t.Fatalf("%s: should not be ErrLineInNotSupported: %v", code, err)
+		}
+		var upnpErr *UPnPError
+		if !errors.As(err, &upnpErr) || upnpErr.Code != code || !strings.HasPrefix(err.Error(), "SetAudioInputAttributes: ") {
+			t.Fatalf("%s: expected wrapped UPnPError, got %v", code, err)
+		}
+	}
+}
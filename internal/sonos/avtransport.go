@@ -3,7 +3,10 @@ package sonos
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
 )
 
 func (c *Client) Play(ctx context.Context) error {
@@ -89,6 +92,53 @@ func (c *Client) SeekRelTime(ctx context.Context, hhmmss string) error {
 	return err
 }
 
+// ErrSeekNotSupported is returned by SeekTo when the current source cannot seek
+// (radio streams, line-in, TV audio).
+var ErrSeekNotSupported = errors.New("current source does not support seeking")
+
+// SeekTo jumps to an absolute position within the current track.
+func (c *Client) SeekTo(ctx context.Context, pos time.Duration) error {
+	err := c.SeekRelTime(ctx, FormatHHMMSS(pos))
+	var upnpErr *UPnPError
+	if errors.As(err, &upnpErr) && (upnpErr.Code == "701" || upnpErr.Code == "710") {
+		// 701 = Transition not available, 710 = Seek mode not supported
+		return fmt.Errorf("%w (%s)", ErrSeekNotSupported, upnpErr.Error())
+	}
+	return err
+}
+
+// ConfigureSleepTimer stops playback after d. A zero duration cancels the timer.
+func (c *Client) ConfigureSleepTimer(ctx context.Context, d time.Duration) error {
+	if d < 0 || d >= 24*time.Hour {
+		return fmt.Errorf("sleep timer must be between 0 and 24h: %s", d)
+	}
+	target := ""
+	if d > 0 {
+		target = FormatHHMMSS(d)
+	}
+	_, err := c.soapCall(ctx, controlAVTransport, urnAVTransport, "ConfigureSleepTimer", map[string]string{
+		"InstanceID":            "0",
+		"NewSleepTimerDuration": target,
+	})
+	return err
+}
+
+// GetRemainingSleepTimerDuration returns the time left on the sleep timer,
+// or 0 when no timer is set.
+func (c *Client) GetRemainingSleepTimerDuration(ctx context.Context) (time.Duration, error) {
+	resp, err := c.soapCall(ctx, controlAVTransport, urnAVTransport, "GetRemainingSleepTimerDuration", map[string]string{
+		"InstanceID": "0",
+	})
+	if err != nil {
+		return 0, err
+	}
+	v := strings.TrimSpace(resp["RemainingSleepTimerDuration"])
+	if v == "" {
+		return 0, nil
+	}
+	return ParseHHMMSS(v)
+}
+
 func (c *Client) SeekTrackNumber(ctx context.Context, oneBasedTrackNumber int) error {
 	_, err := c.soapCall(ctx, controlAVTransport, urnAVTransport, "Seek", map[string]string{
 		"InstanceID": "0",
@@ -199,3 +249,54 @@ func (c *Client) GetTransportInfo(ctx context.Context) (TransportInfo, error) {
 		Speed:  resp["CurrentSpeed"],
 	}, nil
 }
+
+type TransportSettings struct {
+	PlayMode       PlayMode
+	RecQualityMode string
+}
+
+func (c *Client) GetTransportSettings(ctx context.Context) (TransportSettings, error) {
+	resp, err := c.soapCall(ctx, controlAVTransport, urnAVTransport, "GetTransportSettings", map[string]string{
+		"InstanceID": "0",
+	})
+	if err != nil {
+		return TransportSettings{}, err
+	}
+	return TransportSettings{
+		PlayMode:       PlayMode(resp["PlayMode"]),
+		RecQualityMode: resp["RecQualityMode"],
+	}, nil
+}
+
+func (c *Client) SetPlayMode(ctx context.Context, mode PlayMode) error {
+	if _, err := ParsePlayMode(string(mode)); err != nil {
+		return err
+	}
+	_, err := c.soapCall(ctx, controlAVTransport, urnAVTransport, "SetPlayMode", map[string]string{
+		"InstanceID":  "0",
+		"NewPlayMode": string(mode),
+	})
+	return err
+}
+
+func (c *Client) GetCrossfadeMode(ctx context.Context) (bool, error) {
+	resp, err := c.soapCall(ctx, controlAVTransport, urnAVTransport, "GetCrossfadeMode", map[string]string{
+		"InstanceID": "0",
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp["CrossfadeMode"] == "1", nil
+}
+
+func (c *Client) SetCrossfadeMode(ctx context.Context, enabled bool) error {
+	v := "0"
+	if enabled {
+		v = "1"
+	}
+	_, err := c.soapCall(ctx, controlAVTransport, urnAVTransport, "SetCrossfadeMode", map[string]string{
+		"InstanceID":    "0",
+		"CrossfadeMode": v,
+	})
+	return err
+}
@@ -0,0 +1,54 @@
+package sonos
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSeekToFormatsTarget(t *testing.T) {
+	t.Parallel()
+
+	var body string
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		raw, _ := io.ReadAll(req.Body)
+		body = string(raw)
+		return &http.Response{
+			StatusCode: 200,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(okSOAPResponse("Seek"))),
+			Header:     make(http.Header),
+		}, nil
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Transport: rt, Timeout: 2 * time.Second}}
+
+	if err := c.SeekTo(context.Background(), 83*time.Second); err != nil {
+		t.Fatalf("SeekTo: %v", err)
+	}
+	if !strings.Contains(body, "<Unit>REL_TIME</Unit>") || !strings.Contains(body, "<Target>00:01:23</Target>") {
+		t.Fatalf("unexpected seek body: %s", body)
+	}
+}
+
+func TestSeekToMapsUnsupportedSource(t *testing.T) {
+	t.Parallel()
+
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 500,
+			Status:     "500 Internal Server Error",
+			Body:       io.NopCloser(strings.NewReader(soapFaultWithUPnPCode("701"))),
+			Header:     make(http.Header),
+		}, nil
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Transport: rt, Timeout: 2 * time.Second}}
+
+	err := c.SeekTo(context.Background(), time.Minute)
+	if !errors.Is(err, ErrSeekNotSupported) {
+		t.Fatalf("expected ErrSeekNotSupported, got %v", err)
+	}
+}
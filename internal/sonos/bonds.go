@@ -0,0 +1,139 @@
+package sonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Bond types reported by Topology.Bonds.
+const (
+	BondStereoPair  = "stereo_pair"
+	BondHomeTheater = "home_theater"
+)
+
+// ChannelMapEntry is one device of a ChannelMapSet/HTSatChanMapSet.
+type ChannelMapEntry struct {
+	UUID     string   `json:"uuid"`
+	Channels []string `json:"channels"`
+}
+
+// ParseChannelMap parses "RINCON_A:LF,LF;RINCON_B:RF,RF" style channel maps.
+// Repeated channels of a device are collapsed.
+func ParseChannelMap(s string) []ChannelMapEntry {
+	var out []ChannelMapEntry
+	for _, entry := range strings.Split(s, ";") {
+		uuid, channels, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || strings.TrimSpace(uuid) == "" {
+			continue
+		}
+		e := ChannelMapEntry{UUID: strings.TrimSpace(uuid)}
+		for _, ch := range strings.Split(channels, ",") {
+			ch = strings.TrimSpace(ch)
+			if ch == "" || (len(e.Channels) > 0 && e.Channels[len(e.Channels)-1] == ch) {
+				continue
+			}
+			e.Channels = append(e.Channels, ch)
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// BondMember is one device of a bond.
+type BondMember struct {
+	UUID     string   `json:"uuid"`
+	IP       string   `json:"ip,omitempty"`
+	Channels []string `json:"channels"`
+}
+
+// Bond is a set of devices that play as one room: a stereo pair or a
+// soundbar with sub and/or surrounds. Primary is the visible device.
+type Bond struct {
+	Type    string       `json:"type"`
+	Room    string       `json:"room"`
+	Primary string       `json:"primary"`
+	Members []BondMember `json:"members"`
+}
+
+// Bonds returns the stereo pairs and home-theater bonds of the household,
+// sorted by room.
+func (t Topology) Bonds() []Bond {
+	var out []Bond
+	seen := map[string]bool{}
+	for _, g := range t.Groups {
+		for _, m := range g.Members {
+			if !m.IsVisible || seen[m.UUID] {
+				continue
+			}
+			seen[m.UUID] = true
+			if m.ChannelMapSet != "" {
+				out = append(out, t.bond(BondStereoPair, m, m.ChannelMapSet))
+			}
+			if m.HTSatChanMapSet != "" {
+				out = append(out, t.bond(BondHomeTheater, m, m.HTSatChanMapSet))
+			}
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Room < out[j].Room })
+	return out
+}
+
+// BondFor returns the bond a device (visible or not) belongs to.
+func (t Topology) BondFor(uuid string) (Bond, bool) {
+	for _, b := range t.Bonds() {
+		for _, m := range b.Members {
+			if m.UUID == uuid {
+				return b, true
+			}
+		}
+	}
+	return Bond{}, false
+}
+
+func (t Topology) bond(kind string, primary Member, channelMap string) Bond {
+	b := Bond{Type: kind, Room: primary.Name, Primary: primary.UUID}
+	for _, e := range ParseChannelMap(channelMap) {
+		bm := BondMember{UUID: e.UUID, Channels: e.Channels}
+		if mem, ok := t.FindByUUID(e.UUID); ok {
+			bm.IP = mem.IP
+		}
+		b.Members = append(b.Members, bm)
+	}
+	return b
+}
+
+// StereoPairChannelMap builds the ChannelMapSet for CreateStereoPair.
+func StereoPairChannelMap(leftUUID, rightUUID string) string {
+	return fmt.Sprintf("%s:LF,LF;%s:RF,RF", leftUUID, rightUUID)
+}
+
+// CreateStereoPair bonds two speakers of the same model into a stereo pair.
+// Send it to the left speaker, which becomes the visible room.
+func (c *Client) CreateStereoPair(ctx context.Context, leftUUID, rightUUID string) error {
+	leftUUID, rightUUID = strings.TrimSpace(leftUUID), strings.TrimSpace(rightUUID)
+	if leftUUID == "" || rightUUID == "" {
+		return errors.New("left and right speaker UUIDs are required")
+	}
+	if leftUUID == rightUUID {
+		return errors.New("left and right speaker must differ")
+	}
+	_, err := c.soapCall(ctx, controlDeviceProperties, urnDeviceProperties, "CreateStereoPair", map[string]string{
+		"ChannelMapSet": StereoPairChannelMap(leftUUID, rightUUID),
+	})
+	return err
+}
+
+// SeparateStereoPair splits a stereo pair. Send it to the visible (left)
+// speaker with the pair's current ChannelMapSet.
+func (c *Client) SeparateStereoPair(ctx context.Context, channelMapSet string) error {
+	if strings.TrimSpace(channelMapSet) == "" {
+		return errors.New("channel map is required")
+	}
+	_, err := c.soapCall(ctx, controlDeviceProperties, urnDeviceProperties, "SeparateStereoPair", map[string]string{
+		"ChannelMapSet": strings.TrimSpace(channelMapSet),
+	})
+	return err
+}
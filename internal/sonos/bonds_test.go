@@ -0,0 +1,95 @@
+package sonos
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const bondedTopologyXML = `
+<ZoneGroupState>
+  <ZoneGroups>
+    <ZoneGroup Coordinator="RINCON_L1400" ID="RINCON_L1400:7">
+      <ZoneGroupMember ZoneName="Shelf" UUID="RINCON_L1400" Location="http://192.168.1.40:1400/xml/device_description.xml" ChannelMapSet="RINCON_L1400:LF,LF;RINCON_R1400:RF,RF" />
+      <ZoneGroupMember ZoneName="Shelf" UUID="RINCON_R1400" Location="http://192.168.1.41:1400/xml/device_description.xml" Invisible="1" ChannelMapSet="RINCON_L1400:LF,LF;RINCON_R1400:RF,RF" />
+    </ZoneGroup>
+    <ZoneGroup Coordinator="RINCON_ARC1400" ID="RINCON_ARC1400:3">
+      <ZoneGroupMember ZoneName="Living Room" UUID="RINCON_ARC1400" Location="http://192.168.1.50:1400/xml/device_description.xml" HTSatChanMapSet="RINCON_ARC1400:LF,RF;RINCON_SUB1400:SW">
+        <Satellite ZoneName="Living Room" UUID="RINCON_SUB1400" Location="http://192.168.1.51:1400/xml/device_description.xml" Invisible="1" HTSatChanMapSet="RINCON_ARC1400:LF,RF;RINCON_SUB1400:SW" />
+      </ZoneGroupMember>
+    </ZoneGroup>
+  </ZoneGroups>
+</ZoneGroupState>`
+
+func TestTopologyBonds(t *testing.T) {
+	t.Parallel()
+
+	top, err := parseZoneGroupStateXML(bondedTopologyXML)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []Bond{
+		{Type: BondHomeTheater, Room: "Living Room", Primary: "RINCON_ARC1400", Members: []BondMember{
+			{UUID: "RINCON_ARC1400", IP: "192.168.1.50", Channels: []string{"LF", "RF"}},
+			{UUID: "RINCON_SUB1400", IP: "192.168.1.51", Channels: []string{"SW"}},
+		}},
+		{Type: BondStereoPair, Room: "Shelf", Primary: "RINCON_L1400", Members: []BondMember{
+			{UUID: "RINCON_L1400", IP: "192.168.1.40", Channels: []string{"LF"}},
+			{UUID: "RINCON_R1400", IP: "192.168.1.41", Channels: []string{"RF"}},
+		}},
+	}
+	if got := top.Bonds(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("bonds:\n got %+v\nwant %+v", got, want)
+	}
+	if b, ok := top.BondFor("RINCON_R1400"); !ok || b.Room != "Shelf" {
+		t.Fatalf("BondFor: %v %+v", ok, b)
+	}
+	if _, ok := top.BondFor("RINCON_NONE"); ok {
+		t.Fatalf("expected no bond")
+	}
+
+	devices := topologyDevices(top, true)
+	right := devices["192.168.1.41"]
+	if right.Bond == nil || right.Bond.Primary || !reflect.DeepEqual(right.Bond.Channels, []string{"RF"}) {
+		t.Fatalf("right speaker bond: %+v", right.Bond)
+	}
+	if left := devices["192.168.1.40"]; left.Bond == nil || !left.Bond.Primary {
+		t.Fatalf("left speaker bond: %+v", left.Bond)
+	}
+}
+
+func TestCreateAndSeparateStereoPair(t *testing.T) {
+	t.Parallel()
+
+	var actions, bodies []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		actions = append(actions, action)
+		bodies = append(bodies, readBody(t, r))
+		name := action[strings.LastIndex(action, "#")+1 : len(action)-1]
+		return httpResponse(200, soapOK(urnDeviceProperties, name, ``)), nil
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+
+	if err := c.CreateStereoPair(context.Background(), "RINCON_L1400", "RINCON_R1400"); err != nil {
+		t.Fatalf("CreateStereoPair: %v", err)
+	}
+	if err := c.SeparateStereoPair(context.Background(), "RINCON_L1400:LF,LF;RINCON_R1400:RF,RF"); err != nil {
+		t.Fatalf("SeparateStereoPair: %v", err)
+	}
+	if !strings.Contains(actions[0], "DeviceProperties:1#CreateStereoPair") || !strings.Contains(actions[1], "DeviceProperties:1#SeparateStereoPair") {
+		t.Fatalf("actions: %v", actions)
+	}
+	for i, b := range bodies {
+		if !strings.Contains(b, "<ChannelMapSet>RINCON_L1400:LF,LF;RINCON_R1400:RF,RF</ChannelMapSet>") {
+			t.Fatalf("body %d: %s", i, b)
+		}
+	}
+
+	if err := c.CreateStereoPair(context.Background(), "RINCON_L1400", "RINCON_L1400"); err == nil {
+		t.Fatalf("expected error for identical speakers")
+	}
+}
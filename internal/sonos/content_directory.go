@@ -37,3 +37,36 @@ func (c *Client) Browse(ctx context.Context, objectID string, start, count int)
 	}
 	return out, nil
 }
+
+// DestroyObject deletes a ContentDirectory object such as a Sonos playlist (SQ:n)
+// or a Sonos Favorite (FV:2/n).
+func (c *Client) DestroyObject(ctx context.Context, objectID string) error {
+	_, err := c.soapCall(ctx, controlContentDirectory, urnContentDirectory, "DestroyObject", map[string]string{
+		"ObjectID": objectID,
+	})
+	return err
+}
+
+// CreateObject creates an object below containerID (e.g. a Sonos Favorite in
+// FV:2) from a DIDL-Lite document and returns the new object ID.
+func (c *Client) CreateObject(ctx context.Context, containerID, elements string) (string, error) {
+	resp, err := c.soapCall(ctx, controlContentDirectory, urnContentDirectory, "CreateObject", map[string]string{
+		"ContainerID": containerID,
+		"Elements":    elements,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp["ObjectID"], nil
+}
+
+// UpdateObject replaces tag values of an object, e.g. currentTagValue
+// "<dc:title>Old</dc:title>" with newTagValue "<dc:title>New</dc:title>".
+func (c *Client) UpdateObject(ctx context.Context, objectID, currentTagValue, newTagValue string) error {
+	_, err := c.soapCall(ctx, controlContentDirectory, urnContentDirectory, "UpdateObject", map[string]string{
+		"ObjectID":        objectID,
+		"CurrentTagValue": currentTagValue,
+		"NewTagValue":     newTagValue,
+	})
+	return err
+}
@@ -17,46 +17,79 @@ type Device struct {
 	Name     string `json:"name"`
 	UDN      string `json:"udn"`
 	Location string `json:"location"`
+	// Bond is set for devices of a stereo pair or home-theater bond
+	// (topology-based discovery only).
+	Bond *DeviceBond `json:"bond,omitempty"`
+}
+
+// DeviceBond says which bond a device belongs to and which channels it plays.
+type DeviceBond struct {
+	Type     string   `json:"type"`
+	Room     string   `json:"room"`
+	Channels []string `json:"channels"`
+	Primary  bool     `json:"primary"`
 }
 
 type deviceDescription struct {
 	Device struct {
-		DeviceType   string `xml:"deviceType"`
-		RoomName     string `xml:"roomName"`
-		Manufacturer string `xml:"manufacturer"`
-		UDN          string `xml:"UDN"`
+		DeviceType       string `xml:"deviceType"`
+		RoomName         string `xml:"roomName"`
+		DisplayName      string `xml:"displayName"`
+		Manufacturer     string `xml:"manufacturer"`
+		ModelName        string `xml:"modelName"`
+		ModelNumber      string `xml:"modelNumber"`
+		ModelDescription string `xml:"modelDescription"`
+		SerialNum        string `xml:"serialNum"`
+		UDN              string `xml:"UDN"`
+		SoftwareVersion  string `xml:"softwareVersion"`
+		SoftwareGen      string `xml:"swGen"`
+		DisplayVersion   string `xml:"displayVersion"`
+		HardwareVersion  string `xml:"hardwareVersion"`
+		MACAddress       string `xml:"MACAddress"`
+		SeriesID         string `xml:"seriesid"`
+		ZoneType         string `xml:"zoneType"`
 	} `xml:"device"`
 }
 
-func fetchDeviceDescription(ctx context.Context, httpClient *http.Client, locationURL string) (name, udn, ip string, err error) {
+// getDeviceDescription fetches and parses device_description.xml, rejecting
+// UPnP devices that are not Sonos ZonePlayers.
+func getDeviceDescription(ctx context.Context, httpClient *http.Client, locationURL string) (deviceDescription, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, locationURL, nil)
 	if err != nil {
-		return "", "", "", err
+		return deviceDescription{}, err
 	}
 	resp, err := doRequest(ctx, httpClient, req)
 	if err != nil {
-		return "", "", "", err
+		return deviceDescription{}, err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
-		return "", "", "", fmt.Errorf("device description: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+		return deviceDescription{}, fmt.Errorf("device description: %s: %s", resp.Status, strings.TrimSpace(string(body)))
 	}
 	b, err := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
 	if err != nil {
-		return "", "", "", err
+		return deviceDescription{}, err
 	}
 
 	var dd deviceDescription
 	if err := xml.Unmarshal(b, &dd); err != nil {
-		return "", "", "", err
+		return deviceDescription{}, err
 	}
 
 	// Filter out non-Sonos UPnP devices that might respond to our SSDP search.
 	deviceType := strings.TrimSpace(dd.Device.DeviceType)
 	manufacturer := strings.TrimSpace(dd.Device.Manufacturer)
 	if deviceType != "urn:schemas-upnp-org:device:ZonePlayer:1" && !strings.Contains(strings.ToLower(manufacturer), "sonos") {
-		return "", "", "", fmt.Errorf("not a sonos ZonePlayer (deviceType=%q manufacturer=%q)", deviceType, manufacturer)
+		return deviceDescription{}, fmt.Errorf("not a sonos ZonePlayer (deviceType=%q manufacturer=%q)", deviceType, manufacturer)
+	}
+	return dd, nil
+}
+
+func fetchDeviceDescription(ctx context.Context, httpClient *http.Client, locationURL string) (name, udn, ip string, err error) {
+	dd, err := getDeviceDescription(ctx, httpClient, locationURL)
+	if err != nil {
+		return "", "", "", err
 	}
 
 	name = strings.TrimSpace(dd.Device.RoomName)
@@ -0,0 +1,109 @@
+package sonos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DeviceInfo describes one physical device: model and versions from
+// device_description.xml, completed by DeviceProperties GetZoneInfo and
+// GetZoneAttributes.
+type DeviceInfo struct {
+	Room            string `json:"room"`
+	IP              string `json:"ip"`
+	UDN             string `json:"udn"`
+	Manufacturer    string `json:"manufacturer,omitempty"`
+	ModelName       string `json:"modelName"`
+	ModelNumber     string `json:"modelNumber"`
+	DisplayName     string `json:"displayName,omitempty"`
+	SerialNumber    string `json:"serialNumber"`
+	SoftwareVersion string `json:"softwareVersion"`
+	DisplayVersion  string `json:"displayVersion,omitempty"`
+	HardwareVersion string `json:"hardwareVersion"`
+	MACAddress      string `json:"macAddress"`
+	SeriesID        string `json:"seriesId,omitempty"`
+	// Generation is "S1" or "S2" (from swGen); empty when the description
+	// does not say.
+	Generation    string `json:"generation,omitempty"`
+	Icon          string `json:"icon,omitempty"`
+	Configuration string `json:"configuration,omitempty"`
+}
+
+// GetDeviceInfo reads the device description and zone info/attributes of the
+// speaker.
+func (c *Client) GetDeviceInfo(ctx context.Context) (DeviceInfo, error) {
+	dd, err := getDeviceDescription(ctx, c.HTTP, c.baseURL()+"/xml/device_description.xml")
+	if err != nil {
+		return DeviceInfo{}, err
+	}
+	zi, err := c.GetZoneInfo(ctx)
+	if err != nil {
+		return DeviceInfo{}, fmt.Errorf("zone info: %w", err)
+	}
+	za, err := c.GetZoneAttributes(ctx)
+	if err != nil {
+		return DeviceInfo{}, fmt.Errorf("zone attributes: %w", err)
+	}
+
+	d := dd.Device
+	info := DeviceInfo{
+		Room:            firstNonEmpty(za.Name, d.RoomName),
+		IP:              firstNonEmpty(zi.IPAddress, c.IP),
+		UDN:             strings.TrimPrefix(strings.TrimSpace(d.UDN), "uuid:"),
+		Manufacturer:    strings.TrimSpace(d.Manufacturer),
+		ModelName:       strings.TrimSpace(d.ModelName),
+		ModelNumber:     strings.TrimSpace(d.ModelNumber),
+		DisplayName:     strings.TrimSpace(d.DisplayName),
+		SerialNumber:    firstNonEmpty(zi.SerialNumber, d.SerialNum),
+		SoftwareVersion: firstNonEmpty(zi.SoftwareVersion, d.SoftwareVersion),
+		DisplayVersion:  firstNonEmpty(zi.DisplaySoftwareVersion, d.DisplayVersion),
+		HardwareVersion: firstNonEmpty(zi.HardwareVersion, d.HardwareVersion),
+		MACAddress:      firstNonEmpty(zi.MACAddress, d.MACAddress),
+		SeriesID:        strings.TrimSpace(d.SeriesID),
+		Generation:      softwareGeneration(d.SoftwareGen),
+		Icon:            za.Icon,
+		Configuration:   za.Configuration,
+	}
+	return info, nil
+}
+
+// GetDeviceModel reads only the device description (identity, model and
+// generation), skipping the zone calls GetDeviceInfo makes.
+func (c *Client) GetDeviceModel(ctx context.Context) (DeviceInfo, error) {
+	dd, err := getDeviceDescription(ctx, c.HTTP, c.baseURL()+"/xml/device_description.xml")
+	if err != nil {
+		return DeviceInfo{}, err
+	}
+	d := dd.Device
+	return DeviceInfo{
+		IP:           c.IP,
+		UDN:          strings.TrimPrefix(strings.TrimSpace(d.UDN), "uuid:"),
+		Manufacturer: strings.TrimSpace(d.Manufacturer),
+		ModelName:    strings.TrimSpace(d.ModelName),
+		ModelNumber:  strings.TrimSpace(d.ModelNumber),
+		DisplayName:  strings.TrimSpace(d.DisplayName),
+		SeriesID:     strings.TrimSpace(d.SeriesID),
+		Generation:   softwareGeneration(d.SoftwareGen),
+	}, nil
+}
+
+func softwareGeneration(swGen string) string {
+	switch strings.TrimSpace(swGen) {
+	case "1":
+		return "S1"
+	case "2":
+		return "S2"
+	default:
+		return ""
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,131 @@
+package sonos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const oneSLDeviceDescription = `<?xml version="1.0" encoding="utf-8" ?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+  <device>
+    <deviceType>urn:schemas-upnp-org:device:ZonePlayer:1</deviceType>
+    <friendlyName>192.168.1.40 - Sonos One SL</friendlyName>
+    <manufacturer>Sonos, Inc.</manufacturer>
+    <modelNumber>S38</modelNumber>
+    <modelDescription>Sonos One SL</modelDescription>
+    <modelName>Sonos One SL</modelName>
+    <softwareVersion>79.1-56030</softwareVersion>
+    <swGen>2</swGen>
+    <hardwareVersion>1.38.1.2-2.0</hardwareVersion>
+    <serialNum>48-A6-B8-00-11-22:F</serialNum>
+    <MACAddress>48:A6:B8:00:11:22</MACAddress>
+    <UDN>uuid:RINCON_48A6B8001122001400</UDN>
+    <roomName>Office</roomName>
+    <displayName>One SL</displayName>
+    <displayVersion>16.4</displayVersion>
+    <seriesid>A100</seriesid>
+  </device>
+</root>`
+
+func TestGetDeviceInfo(t *testing.T) {
+	t.Parallel()
+
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method == http.MethodGet {
+			if r.URL.Path != "/xml/device_description.xml" {
+				t.Fatalf("unexpected GET %s", r.URL.Path)
+			}
+			return httpResponse(200, oneSLDeviceDescription), nil
+		}
+		action := r.Header.Get("SOAPACTION")
+		switch {
+		case strings.Contains(action, "DeviceProperties:1#GetZoneInfo"):
+			return httpResponse(200, soapOK(urnDeviceProperties, "GetZoneInfo",
+				`<SerialNumber>48-A6-B8-00-11-22:F</SerialNumber><SoftwareVersion>79.1-56030</SoftwareVersion>`+
+					`<DisplaySoftwareVersion>16.4</DisplaySoftwareVersion><HardwareVersion>1.38.1.2-2.0</HardwareVersion>`+
+					`<IPAddress>192.168.1.40</IPAddress><MACAddress>48:A6:B8:00:11:22</MACAddress>`)), nil
+		case strings.Contains(action, "DeviceProperties:1#GetZoneAttributes"):
+			return httpResponse(200, soapOK(urnDeviceProperties, "GetZoneAttributes",
+				`<CurrentZoneName>Office</CurrentZoneName><CurrentIcon>x-rincon-roomicon:office</CurrentIcon><CurrentConfiguration>1</CurrentConfiguration>`)), nil
+		default:
+			t.Fatalf("unexpected SOAPACTION %q", action)
+			return nil, nil
+		}
+	})
+	c := &Client{IP: "192.168.1.40", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+
+	info, err := c.GetDeviceInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetDeviceInfo: %v", err)
+	}
+	want := DeviceInfo{
+		Room:            "Office",
+		IP:              "192.168.1.40",
+		UDN:             "RINCON_48A6B8001122001400",
+		Manufacturer:    "Sonos, Inc.",
+		ModelName:       "Sonos One SL",
+		ModelNumber:     "S38",
+		DisplayName:     "One SL",
+		SerialNumber:    "48-A6-B8-00-11-22:F",
+		SoftwareVersion: "79.1-56030",
+		DisplayVersion:  "16.4",
+		HardwareVersion: "1.38.1.2-2.0",
+		MACAddress:      "48:A6:B8:00:11:22",
+		SeriesID:        "A100",
+		Generation:      "S2",
+		Icon:            "x-rincon-roomicon:office",
+		Configuration:   "1",
+	}
+	if info != want {
+		t.Fatalf("info:\n got %+v\nwant %+v", info, want)
+	}
+}
+
+func TestGetDeviceInfo_ZoneInfoError(t *testing.T) {
+	t.Parallel()
+
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method == http.MethodGet {
+			return httpResponse(200, oneSLDeviceDescription), nil
+		}
+		return httpResponse(500, soapFaultWithUPnPCode("401")), nil
+	})
+	c := &Client{IP: "192.168.1.40", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+
+	if _, err := c.GetDeviceInfo(context.Background()); err == nil || !strings.Contains(err.Error(), "zone info") {
+		t.Fatalf("expected zone info error, got %v", err)
+	}
+}
+
+func TestGetDeviceModelReadsOnlyDescription(t *testing.T) {
+	t.Parallel()
+
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method != http.MethodGet || r.URL.Path != "/xml/device_description.xml" {
+			t.Fatalf("unexpected request %s %s (%s)", r.Method, r.URL.Path, r.Header.Get("SOAPACTION"))
+		}
+		return httpResponse(200, oneSLDeviceDescription), nil
+	})
+	c := &Client{IP: "192.168.1.40", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+
+	info, err := c.GetDeviceModel(context.Background())
+	if err != nil {
+		t.Fatalf("GetDeviceModel: %v", err)
+	}
+	want := DeviceInfo{
+		IP:           "192.168.1.40",
+		UDN:          "RINCON_48A6B8001122001400",
+		Manufacturer: "Sonos, Inc.",
+		ModelName:    "Sonos One SL",
+		ModelNumber:  "S38",
+		DisplayName:  "One SL",
+		SeriesID:     "A100",
+		Generation:   "S2",
+	}
+	if info != want {
+		t.Fatalf("info:\n got %+v\nwant %+v", info, want)
+	}
+}
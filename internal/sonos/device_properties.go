@@ -17,3 +17,106 @@ func (c *Client) GetHouseholdID(ctx context.Context) (string, error) {
 	}
 	return hh, nil
 }
+
+// GetLEDState reports whether the status light is on.
+func (c *Client) GetLEDState(ctx context.Context) (bool, error) {
+	resp, err := c.soapCall(ctx, controlDeviceProperties, urnDeviceProperties, "GetLEDState", nil)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(strings.TrimSpace(resp["CurrentLEDState"]), "On"), nil
+}
+
+// SetLEDState turns the status light on or off.
+func (c *Client) SetLEDState(ctx context.Context, on bool) error {
+	_, err := c.soapCall(ctx, controlDeviceProperties, urnDeviceProperties, "SetLEDState", map[string]string{
+		"DesiredLEDState": onOffState(on),
+	})
+	return err
+}
+
+// GetButtonLockState reports whether the physical buttons are locked.
+func (c *Client) GetButtonLockState(ctx context.Context) (bool, error) {
+	resp, err := c.soapCall(ctx, controlDeviceProperties, urnDeviceProperties, "GetButtonLockState", nil)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(strings.TrimSpace(resp["CurrentButtonLockState"]), "On"), nil
+}
+
+// SetButtonLockState locks or unlocks the physical buttons.
+func (c *Client) SetButtonLockState(ctx context.Context, locked bool) error {
+	_, err := c.soapCall(ctx, controlDeviceProperties, urnDeviceProperties, "SetButtonLockState", map[string]string{
+		"DesiredButtonLockState": onOffState(locked),
+	})
+	return err
+}
+
+func onOffState(on bool) string {
+	if on {
+		return "On"
+	}
+	return "Off"
+}
+
+// ZoneInfo is the DeviceProperties GetZoneInfo response.
+type ZoneInfo struct {
+	SerialNumber           string `json:"serialNumber"`
+	SoftwareVersion        string `json:"softwareVersion"`
+	DisplaySoftwareVersion string `json:"displaySoftwareVersion"`
+	HardwareVersion        string `json:"hardwareVersion"`
+	IPAddress              string `json:"ipAddress"`
+	MACAddress             string `json:"macAddress"`
+	ExtraInfo              string `json:"extraInfo,omitempty"`
+}
+
+func (c *Client) GetZoneInfo(ctx context.Context) (ZoneInfo, error) {
+	resp, err := c.soapCall(ctx, controlDeviceProperties, urnDeviceProperties, "GetZoneInfo", nil)
+	if err != nil {
+		return ZoneInfo{}, err
+	}
+	return ZoneInfo{
+		SerialNumber:           strings.TrimSpace(resp["SerialNumber"]),
+		SoftwareVersion:        strings.TrimSpace(resp["SoftwareVersion"]),
+		DisplaySoftwareVersion: strings.TrimSpace(resp["DisplaySoftwareVersion"]),
+		HardwareVersion:        strings.TrimSpace(resp["HardwareVersion"]),
+		IPAddress:              strings.TrimSpace(resp["IPAddress"]),
+		MACAddress:             strings.TrimSpace(resp["MACAddress"]),
+		ExtraInfo:              strings.TrimSpace(resp["ExtraInfo"]),
+	}, nil
+}
+
+// ZoneAttributes is the room name, icon and configuration of a device
+// (DeviceProperties GetZoneAttributes).
+type ZoneAttributes struct {
+	Name          string `json:"name"`
+	Icon          string `json:"icon,omitempty"`
+	Configuration string `json:"configuration,omitempty"`
+}
+
+func (c *Client) GetZoneAttributes(ctx context.Context) (ZoneAttributes, error) {
+	resp, err := c.soapCall(ctx, controlDeviceProperties, urnDeviceProperties, "GetZoneAttributes", nil)
+	if err != nil {
+		return ZoneAttributes{}, err
+	}
+	return ZoneAttributes{
+		Name:          strings.TrimSpace(resp["CurrentZoneName"]),
+		Icon:          strings.TrimSpace(resp["CurrentIcon"]),
+		Configuration: strings.TrimSpace(resp["CurrentConfiguration"]),
+	}, nil
+}
+
+// SetZoneAttributes sets the room name, icon and configuration. Pass the
+// values from GetZoneAttributes for the fields that should stay unchanged.
+func (c *Client) SetZoneAttributes(ctx context.Context, attrs ZoneAttributes) error {
+	name := strings.TrimSpace(attrs.Name)
+	if name == "" {
+		return errors.New("room name is required")
+	}
+	_, err := c.soapCall(ctx, controlDeviceProperties, urnDeviceProperties, "SetZoneAttributes", map[string]string{
+		"DesiredZoneName":      name,
+		"DesiredIcon":          attrs.Icon,
+		"DesiredConfiguration": attrs.Configuration,
+	})
+	return err
+}
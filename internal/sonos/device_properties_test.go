@@ -67,3 +67,85 @@ func TestGetHouseholdID_MissingErrors(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestLEDAndButtonLockState(t *testing.T) {
+	t.Parallel()
+
+	var bodies []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		bodies = append(bodies, readBody(t, r))
+		switch {
+		case strings.Contains(action, "DeviceProperties:1#GetLEDState"):
+			return httpResponse(200, soapOK(urnDeviceProperties, "GetLEDState", `<CurrentLEDState>On</CurrentLEDState>`)), nil
+		case strings.Contains(action, "DeviceProperties:1#GetButtonLockState"):
+			return httpResponse(200, soapOK(urnDeviceProperties, "GetButtonLockState", `<CurrentButtonLockState>Off</CurrentButtonLockState>`)), nil
+		case strings.Contains(action, "DeviceProperties:1#SetLEDState"):
+			return httpResponse(200, soapOK(urnDeviceProperties, "SetLEDState", ``)), nil
+		case strings.Contains(action, "DeviceProperties:1#SetButtonLockState"):
+			return httpResponse(200, soapOK(urnDeviceProperties, "SetButtonLockState", ``)), nil
+		default:
+			t.Fatalf("unexpected SOAPACTION: %q", action)
+			return nil, nil
+		}
+	})
+
+	c := &Client{
+		IP: "192.0.2.1",
+		HTTP: &http.Client{
+			Timeout:   time.Second,
+			Transport: rt,
+		},
+	}
+
+	on, err := c.GetLEDState(context.Background())
+	if err != nil || !on {
+		t.Fatalf("GetLEDState: %v, %v", on, err)
+	}
+	locked, err := c.GetButtonLockState(context.Background())
+	if err != nil || locked {
+		t.Fatalf("GetButtonLockState: %v, %v", locked, err)
+	}
+	if err := c.SetLEDState(context.Background(), false); err != nil {
+		t.Fatalf("SetLEDState: %v", err)
+	}
+	if err := c.SetButtonLockState(context.Background(), true); err != nil {
+		t.Fatalf("SetButtonLockState: %v", err)
+	}
+	if !strings.Contains(bodies[2], "<DesiredLEDState>Off</DesiredLEDState>") {
+		t.Fatalf("SetLEDState body: %s", bodies[2])
+	}
+	if !strings.Contains(bodies[3], "<DesiredButtonLockState>On</DesiredButtonLockState>") {
+		t.Fatalf("SetButtonLockState body: %s", bodies[3])
+	}
+}
+
+func TestSetZoneAttributes(t *testing.T) {
+	t.Parallel()
+
+	var body string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if !strings.Contains(r.Header.Get("SOAPACTION"), "DeviceProperties:1#SetZoneAttributes") {
+			t.Fatalf("SOAPACTION: %q", r.Header.Get("SOAPACTION"))
+		}
+		body = readBody(t, r)
+		return httpResponse(200, soapOK(urnDeviceProperties, "SetZoneAttributes", ``)), nil
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+
+	if err := c.SetZoneAttributes(context.Background(), ZoneAttributes{Name: " Studio ", Icon: "x-rincon-roomicon:office", Configuration: "1"}); err != nil {
+		t.Fatalf("SetZoneAttributes: %v", err)
+	}
+	for _, want := range []string{
+		"<DesiredConfiguration>1</DesiredConfiguration>",
+		"<DesiredIcon>x-rincon-roomicon:office</DesiredIcon>",
+		"<DesiredZoneName>Studio</DesiredZoneName>",
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("body missing %s: %s", want, body)
+		}
+	}
+	if err := c.SetZoneAttributes(context.Background(), ZoneAttributes{Name: " "}); err == nil {
+		t.Fatalf("expected error for empty name")
+	}
+}
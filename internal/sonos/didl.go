@@ -4,18 +4,102 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 )
 
+// DIDL-Lite namespaces as written by Sonos.
+const (
+	nsDIDL   = "urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/"
+	nsDC     = "http://purl.org/dc/elements/1.1/"
+	nsUPnP   = "urn:schemas-upnp-org:metadata-1-0/upnp/"
+	nsRincon = "urn:schemas-rinconnetworks-com:metadata-1-0/"
+	nsXML    = "http://www.w3.org/XML/1998/namespace"
+)
+
+// didlPrefixes are the prefixes BuildDIDL declares on the DIDL-Lite root.
+var didlPrefixes = map[string]string{nsDC: "dc", nsUPnP: "upnp", nsRincon: "r", nsXML: "xml"}
+
+// DIDLItem is one DIDL-Lite item or container. ParseDIDLItems and BuildDIDL
+// round-trip it: single-valued fields hold the first matching element, and
+// anything not modeled (repeated, or with attributes or child elements the
+// field cannot hold) is kept in Extra.
+//
+// URI/ProtocolInfo mirror the first res and Artist the first dc:creator (or
+// upnp:artist), so simple callers can ignore Resources, Creators and Artists.
 type DIDLItem struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	URI         string `json:"uri"`
-	Class       string `json:"class,omitempty"`
-	Artist      string `json:"artist,omitempty"`
-	Album       string `json:"album,omitempty"`
-	AlbumArtURI string `json:"albumArtURI,omitempty"`
-	ResMD       string `json:"resMD,omitempty"`
+	ID           string `json:"id"`
+	ParentID     string `json:"parentID,omitempty"`
+	Restricted   string `json:"restricted,omitempty"` // restricted attribute as written ("true", "false", "1", ...)
+	Container    bool   `json:"container,omitempty"`  // <container> rather than <item>
+	Title        string `json:"title"`
+	URI          string `json:"uri"`
+	ProtocolInfo string `json:"protocolInfo,omitempty"` // res@protocolInfo
+	Class        string `json:"class,omitempty"`
+	Artist       string `json:"artist,omitempty"`
+	Album        string `json:"album,omitempty"`
+	AlbumArtURI  string `json:"albumArtURI,omitempty"`
+	Description  string `json:"description,omitempty"` // r:description (favorites)
+	Type         string `json:"type,omitempty"`        // r:type (favorites)
+	ResMD        string `json:"resMD,omitempty"`
+
+	Creators            []string      `json:"creators,omitempty"`            // dc:creator
+	Artists             []DIDLPerson  `json:"artists,omitempty"`             // upnp:artist
+	Contributors        []string      `json:"contributors,omitempty"`        // dc:contributor
+	AlbumArtist         string        `json:"albumArtist,omitempty"`         // r:albumArtist
+	Genre               string        `json:"genre,omitempty"`               // upnp:genre
+	Date                string        `json:"date,omitempty"`                // dc:date
+	OriginalTrackNumber int           `json:"originalTrackNumber,omitempty"` // upnp:originalTrackNumber
+	Ordinal             string        `json:"ordinal,omitempty"`             // r:ordinal (favorites)
+	StreamContent       string        `json:"streamContent,omitempty"`       // r:streamContent (radio "now playing")
+	RadioShowMD         string        `json:"radioShowMd,omitempty"`         // r:radioShowMd
+	Resources           []DIDLRes     `json:"res,omitempty"`
+	Desc                []DIDLDesc    `json:"desc,omitempty"`
+	Extra               []DIDLElement `json:"extra,omitempty"`
+
+	// Attrs holds any other attributes of the item/container element.
+	Attrs map[string]string `json:"attrs,omitempty"`
+}
+
+// DIDLPerson is a upnp:artist, optionally with a role (AlbumArtist,
+// Performer, Composer, ...).
+type DIDLPerson struct {
+	Name string `json:"name"`
+	Role string `json:"role,omitempty"`
+}
+
+// DIDLRes is one res element. Attributes other than protocolInfo and
+// duration (size, bitrate, sampleFrequency, ...) are kept in Attrs.
+type DIDLRes struct {
+	URI          string            `json:"uri"`
+	ProtocolInfo string            `json:"protocolInfo,omitempty"`
+	Duration     string            `json:"duration,omitempty"`
+	Attrs        map[string]string `json:"attrs,omitempty"`
+}
+
+// DIDLDesc is a desc element; Sonos uses id="cdudn" to carry the music
+// service account token (e.g. SA_RINCON2311_X_#Svc2311-0-Token).
+type DIDLDesc struct {
+	ID        string `json:"id,omitempty"`
+	NameSpace string `json:"nameSpace,omitempty"`
+	Value     string `json:"value"`
+}
+
+// DIDLElement is a child element DIDLItem does not model. Namespace is empty
+// for the default DIDL-Lite namespace.
+//
+// Attribute keys (here and in DIDLRes/DIDLItem Attrs) are the local name for
+// attributes without a namespace and "{namespace}name" otherwise, e.g.
+// "{urn:schemas-rinconnetworks-com:metadata-1-0/}tiid". Value is the element's
+// own text; nested elements are kept in Children.
+type DIDLElement struct {
+	Namespace string            `json:"namespace,omitempty"`
+	Name      string            `json:"name"`
+	Attrs     map[string]string `json:"attrs,omitempty"`
+	Value     string            `json:"value,omitempty"`
+	Children  []DIDLElement     `json:"children,omitempty"`
 }
 
 func ParseDIDLItems(didlXML string) ([]DIDLItem, error) {
@@ -51,14 +135,27 @@ func ParseDIDLItems(didlXML string) ([]DIDLItem, error) {
 }
 
 func parseDIDLItem(dec *xml.Decoder, start xml.StartElement) (DIDLItem, error) {
-	var it DIDLItem
+	it := DIDLItem{Container: start.Name.Local == "container"}
 	for _, a := range start.Attr {
-		if strings.EqualFold(a.Name.Local, "id") {
+		key, ok := didlAttrKey(a)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "id":
 			it.ID = strings.TrimSpace(a.Value)
+		case "parentID":
+			it.ParentID = strings.TrimSpace(a.Value)
+		case "restricted":
+			it.Restricted = a.Value
+		default:
+			if it.Attrs == nil {
+				it.Attrs = map[string]string{}
+			}
+			it.Attrs[key] = a.Value
 		}
 	}
 
-	var current string
 	for {
 		tok, err := dec.Token()
 		if err != nil {
@@ -66,50 +163,392 @@ func parseDIDLItem(dec *xml.Decoder, start xml.StartElement) (DIDLItem, error) {
 		}
 		switch t := tok.(type) {
 		case xml.StartElement:
-			current = strings.ToLower(t.Name.Local)
+			el, err := readDIDLElement(dec, t)
+			if err != nil {
+				return it, err
+			}
+			if !it.setElement(el) {
+				it.Extra = append(it.Extra, el)
+			}
 		case xml.EndElement:
-			if t.Name.Local == start.Name.Local {
-				return it, nil
+			if len(it.Resources) > 0 {
+				it.URI = it.Resources[0].URI
+				it.ProtocolInfo = it.Resources[0].ProtocolInfo
 			}
-			current = ""
-		case xml.CharData:
-			if current == "" {
-				continue
+			switch {
+			case len(it.Creators) > 0:
+				it.Artist = it.Creators[0]
+			case len(it.Artists) > 0:
+				it.Artist = it.Artists[0].Name
+			}
+			return it, nil
+		}
+	}
+}
+
+// didlAttrKey returns the Attrs key for a, or false for namespace declarations.
+func didlAttrKey(a xml.Attr) (string, bool) {
+	switch {
+	case a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns"):
+		return "", false
+	case a.Name.Space == "":
+		return a.Name.Local, true
+	default:
+		return "{" + a.Name.Space + "}" + a.Name.Local, true
+	}
+}
+
+// readDIDLElement reads an element with its attributes, own text and nested
+// elements.
+func readDIDLElement(dec *xml.Decoder, start xml.StartElement) (DIDLElement, error) {
+	el := DIDLElement{Name: start.Name.Local}
+	if start.Name.Space != nsDIDL {
+		el.Namespace = start.Name.Space
+	}
+	for _, a := range start.Attr {
+		key, ok := didlAttrKey(a)
+		if !ok {
+			continue
+		}
+		if el.Attrs == nil {
+			el.Attrs = map[string]string{}
+		}
+		el.Attrs[key] = a.Value
+	}
+	var text strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return el, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child, err := readDIDLElement(dec, t)
+			if err != nil {
+				return el, err
 			}
-			val := strings.TrimSpace(string(t))
-			if val == "" {
-				continue
+			el.Children = append(el.Children, child)
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			el.Value = strings.TrimSpace(text.String())
+			return el, nil
+		}
+	}
+}
+
+// setElement stores el in the matching field and reports whether it did.
+// Single-valued fields only take the first occurrence; elements with nested
+// elements or attributes the field cannot hold are left for Extra.
+func (it *DIDLItem) setElement(el DIDLElement) bool {
+	if len(el.Children) > 0 {
+		return false
+	}
+	onlyAttrs := func(names ...string) bool {
+		for k := range el.Attrs {
+			if !slices.Contains(names, k) {
+				return false
 			}
-			switch current {
-			case "title":
-				if it.Title == "" {
-					it.Title = val
-				}
-			case "res":
-				if it.URI == "" {
-					it.URI = val
-				}
-			case "resmd":
-				if it.ResMD == "" {
-					it.ResMD = val
-				}
-			case "class":
-				if it.Class == "" {
-					it.Class = val
-				}
-			case "artist", "creator":
-				if it.Artist == "" {
-					it.Artist = val
-				}
-			case "album":
-				if it.Album == "" {
-					it.Album = val
+		}
+		return true
+	}
+	name := strings.ToLower(el.Name)
+	switch name {
+	case "res":
+	case "artist":
+		if !onlyAttrs("role") {
+			return false
+		}
+	case "desc":
+		if !onlyAttrs("id", "nameSpace") {
+			return false
+		}
+	default:
+		if !onlyAttrs() {
+			return false
+		}
+	}
+
+	setOnce := func(dst *string) bool {
+		if *dst != "" {
+			return false
+		}
+		*dst = el.Value
+		return true
+	}
+	switch name {
+	case "title":
+		return setOnce(&it.Title)
+	case "class":
+		return setOnce(&it.Class)
+	case "album":
+		return setOnce(&it.Album)
+	case "albumarturi":
+		return setOnce(&it.AlbumArtURI)
+	case "description":
+		return setOnce(&it.Description)
+	case "type":
+		return setOnce(&it.Type)
+	case "resmd":
+		return setOnce(&it.ResMD)
+	case "albumartist":
+		return setOnce(&it.AlbumArtist)
+	case "genre":
+		return setOnce(&it.Genre)
+	case "date":
+		return setOnce(&it.Date)
+	case "ordinal":
+		return setOnce(&it.Ordinal)
+	case "streamcontent":
+		return setOnce(&it.StreamContent)
+	case "radioshowmd":
+		return setOnce(&it.RadioShowMD)
+	case "creator":
+		it.Creators = append(it.Creators, el.Value)
+	case "contributor":
+		it.Contributors = append(it.Contributors, el.Value)
+	case "artist":
+		it.Artists = append(it.Artists, DIDLPerson{Name: el.Value, Role: el.Attrs["role"]})
+	case "originaltracknumber":
+		n, err := strconv.Atoi(el.Value)
+		if err != nil || n <= 0 || it.OriginalTrackNumber != 0 {
+			return false
+		}
+		it.OriginalTrackNumber = n
+	case "res":
+		res := DIDLRes{URI: el.Value}
+		for k, v := range el.Attrs {
+			switch k {
+			case "protocolInfo":
+				res.ProtocolInfo = v
+			case "duration":
+				res.Duration = v
+			default:
+				if res.Attrs == nil {
+					res.Attrs = map[string]string{}
 				}
-			case "albumarturi":
-				if it.AlbumArtURI == "" {
-					it.AlbumArtURI = val
+				res.Attrs[k] = v
+			}
+		}
+		it.Resources = append(it.Resources, res)
+	case "desc":
+		it.Desc = append(it.Desc, DIDLDesc{ID: el.Attrs["id"], NameSpace: el.Attrs["nameSpace"], Value: el.Value})
+	default:
+		return false
+	}
+	return true
+}
+
+// BuildDIDL serializes items into a DIDL-Lite document. URI/ProtocolInfo
+// override the first entry of Resources, and Artist is written as dc:creator
+// when neither Creators nor Artists already carry it.
+func BuildDIDL(items ...DIDLItem) string {
+	var b strings.Builder
+	b.WriteString(`<DIDL-Lite xmlns:dc="` + nsDC + `" xmlns:upnp="` + nsUPnP + `" xmlns:r="` + nsRincon + `" xmlns="` + nsDIDL + `">`)
+	for _, it := range items {
+		writeDIDLItem(&b, it)
+	}
+	b.WriteString(`</DIDL-Lite>`)
+	return b.String()
+}
+
+func writeDIDLItem(b *strings.Builder, it DIDLItem) {
+	tag := "item"
+	if it.Container {
+		tag = "container"
+	}
+	b.WriteString("<" + tag)
+	if it.ID != "" {
+		b.WriteString(` id="` + xmlEscapeAttr(it.ID) + `"`)
+	}
+	if it.ParentID != "" {
+		b.WriteString(` parentID="` + xmlEscapeAttr(it.ParentID) + `"`)
+	}
+	if it.Restricted != "" {
+		b.WriteString(` restricted="` + xmlEscapeAttr(it.Restricted) + `"`)
+	}
+	writeDIDLAttrs(b, it.Attrs)
+	b.WriteString(">")
+
+	leaf := func(name, value string) {
+		if value != "" {
+			b.WriteString("<" + name + ">" + xmlEscapeText(value) + "</" + name + ">")
+		}
+	}
+	b.WriteString("<dc:title>" + xmlEscapeText(it.Title) + "</dc:title>")
+	creators := it.Creators
+	if len(creators) == 0 && it.Artist != "" && (len(it.Artists) == 0 || it.Artists[0].Name != it.Artist) {
+		creators = []string{it.Artist}
+	}
+	for _, v := range creators {
+		b.WriteString("<dc:creator>" + xmlEscapeText(v) + "</dc:creator>")
+	}
+	for _, a := range it.Artists {
+		b.WriteString("<upnp:artist")
+		if a.Role != "" {
+			b.WriteString(` role="` + xmlEscapeAttr(a.Role) + `"`)
+		}
+		b.WriteString(">" + xmlEscapeText(a.Name) + "</upnp:artist>")
+	}
+	for _, v := range it.Contributors {
+		b.WriteString("<dc:contributor>" + xmlEscapeText(v) + "</dc:contributor>")
+	}
+	leaf("upnp:album", it.Album)
+	leaf("r:albumArtist", it.AlbumArtist)
+	leaf("upnp:genre", it.Genre)
+	leaf("dc:date", it.Date)
+	if it.OriginalTrackNumber > 0 {
+		leaf("upnp:originalTrackNumber", strconv.Itoa(it.OriginalTrackNumber))
+	}
+	leaf("upnp:albumArtURI", it.AlbumArtURI)
+	leaf("upnp:class", it.Class)
+	leaf("r:ordinal", it.Ordinal)
+
+	resources := append([]DIDLRes(nil), it.Resources...)
+	if it.URI != "" || it.ProtocolInfo != "" {
+		if len(resources) == 0 {
+			resources = append(resources, DIDLRes{})
+		}
+		if it.URI != "" {
+			resources[0].URI = it.URI
+		}
+		if it.ProtocolInfo != "" {
+			resources[0].ProtocolInfo = it.ProtocolInfo
+		}
+	}
+	for _, r := range resources {
+		b.WriteString("<res")
+		if r.ProtocolInfo != "" {
+			b.WriteString(` protocolInfo="` + xmlEscapeAttr(r.ProtocolInfo) + `"`)
+		}
+		if r.Duration != "" {
+			b.WriteString(` duration="` + xmlEscapeAttr(r.Duration) + `"`)
+		}
+		writeDIDLAttrs(b, r.Attrs)
+		b.WriteString(">" + xmlEscapeText(r.URI) + "</res>")
+	}
+
+	leaf("r:streamContent", it.StreamContent)
+	leaf("r:radioShowMd", it.RadioShowMD)
+	leaf("r:type", it.Type)
+	leaf("r:description", it.Description)
+	leaf("r:resMD", it.ResMD)
+
+	for _, el := range it.Extra {
+		writeDIDLElement(b, el, nsDIDL)
+	}
+
+	for _, d := range it.Desc {
+		b.WriteString("<desc")
+		if d.ID != "" {
+			b.WriteString(` id="` + xmlEscapeAttr(d.ID) + `"`)
+		}
+		if d.NameSpace != "" {
+			b.WriteString(` nameSpace="` + xmlEscapeAttr(d.NameSpace) + `"`)
+		}
+		b.WriteString(">" + xmlEscapeText(d.Value) + "</desc>")
+	}
+	b.WriteString("</" + tag + ">")
+}
+
+// writeDIDLElement writes el and its children. defaultNS is the default
+// namespace in effect, so elements outside the prefixed namespaces only
+// declare xmlns when it changes.
+func writeDIDLElement(b *strings.Builder, el DIDLElement, defaultNS string) {
+	ns := el.Namespace
+	if ns == "" {
+		ns = nsDIDL
+	}
+	name := el.Name
+	var nsAttr string
+	if prefix, ok := didlPrefixes[ns]; ok {
+		name = prefix + ":" + name
+	} else if ns != defaultNS {
+		nsAttr = ` xmlns="` + xmlEscapeAttr(ns) + `"`
+		defaultNS = ns
+	}
+	b.WriteString("<" + name + nsAttr)
+	writeDIDLAttrs(b, el.Attrs)
+	b.WriteString(">" + xmlEscapeText(el.Value))
+	for _, child := range el.Children {
+		writeDIDLElement(b, child, defaultNS)
+	}
+	b.WriteString("</" + name + ">")
+}
+
+// writeDIDLAttrs writes attrs sorted by key, so output is deterministic.
+// "{namespace}name" keys use the root prefixes (dc, upnp, r, xml) or declare
+// a local ns0, ns1, ... prefix.
+func writeDIDLAttrs(b *strings.Builder, attrs map[string]string) {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	declared := map[string]string{}
+	for _, k := range keys {
+		name := k
+		if ns, local, ok := strings.Cut(strings.TrimPrefix(k, "{"), "}"); ok && strings.HasPrefix(k, "{") {
+			prefix, known := didlPrefixes[ns]
+			if !known {
+				if prefix = declared[ns]; prefix == "" {
+					prefix = "ns" + strconv.Itoa(len(declared))
+					declared[ns] = prefix
+					b.WriteString(" xmlns:" + prefix + `="` + xmlEscapeAttr(ns) + `"`)
 				}
 			}
+			name = prefix + ":" + local
+		}
+		b.WriteString(" " + name + `="` + xmlEscapeAttr(attrs[k]) + `"`)
+	}
+}
+
+// SplitDIDLItems returns every item/container of a DIDL-Lite document as a
+// standalone DIDL-Lite document, keeping the original root element (and its
+// namespace declarations) and the item markup byte for byte.
+func SplitDIDLItems(didlXML string) ([]string, error) {
+	didlXML = strings.TrimSpace(didlXML)
+	if didlXML == "" {
+		return nil, nil
+	}
+
+	dec := xml.NewDecoder(strings.NewReader(didlXML))
+	root := ""
+	rootEnd := ""
+	var out []string
+	for {
+		off := dec.InputOffset()
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				return out, nil
+			}
+			return nil, err
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root == "" {
+			root = didlXML[off:dec.InputOffset()]
+			rootEnd = "</" + rawTagName(root) + ">"
+			continue
 		}
+		if se.Name.Local != "item" && se.Name.Local != "container" {
+			continue
+		}
+		if err := dec.Skip(); err != nil {
+			return nil, err
+		}
+		out = append(out, root+didlXML[off:dec.InputOffset()]+rootEnd)
+	}
+}
+
+// rawTagName returns the (possibly prefixed) element name of a raw start tag.
+func rawTagName(startTag string) string {
+	name := strings.TrimPrefix(startTag, "<")
+	if i := strings.IndexAny(name, " \t\r\n/>"); i >= 0 {
+		name = name[:i]
 	}
+	return name
 }
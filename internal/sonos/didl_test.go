@@ -1,6 +1,13 @@
 package sonos
 
-import "testing"
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestParseDIDLItems_Minimal(t *testing.T) {
 	t.Parallel()
@@ -62,3 +69,280 @@ func TestParseDIDLItems_ResMD(t *testing.T) {
 		t.Fatalf("expected unescaped resMD, got: %q", items[0].ResMD)
 	}
 }
+
+func TestSplitDIDLItems(t *testing.T) {
+	t.Parallel()
+
+	const root = `<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/">`
+	item1 := `<item id="Q:0/1" parentID="Q:0"><res protocolInfo="sonos.com-spotify:*:audio/x-spotify:*">x-sonos-spotify:spotify%3atrack%3a1?sid=9&amp;sn=2</res><dc:title>One &amp; Two</dc:title><desc id="cdudn" nameSpace="urn:schemas-rinconnetworks-com:metadata-1-0/">SA_RINCON2311_X_#Svc2311-0-Token</desc></item>`
+	item2 := `<item id="Q:0/2" parentID="Q:0"><dc:title>Three</dc:title></item>`
+	parts, err := SplitDIDLItems(root + item1 + "\n" + item2 + `</DIDL-Lite>`)
+	if err != nil {
+		t.Fatalf("SplitDIDLItems: %v", err)
+	}
+	if len(parts) != 2 {
+		t.Fatalf("expected 2 parts, got %d", len(parts))
+	}
+	if parts[0] != root+item1+`</DIDL-Lite>` {
+		t.Fatalf("unexpected first part: %s", parts[0])
+	}
+	items, err := ParseDIDLItems(parts[1])
+	if err != nil || len(items) != 1 || items[0].Title != "Three" {
+		t.Fatalf("unexpected reparse: %+v err=%v", items, err)
+	}
+}
+
+func loadDIDLFixtures(t *testing.T) map[string]string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join("testdata", "didl", "*.xml"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no DIDL fixtures: %v", err)
+	}
+	out := map[string]string{}
+	for _, p := range paths {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatalf("read %s: %v", p, err)
+		}
+		out[filepath.Base(p)] = string(b)
+	}
+	return out
+}
+
+func TestDIDLFixturesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for name, didl := range loadDIDLFixtures(t) {
+		items, err := ParseDIDLItems(didl)
+		if err != nil {
+			t.Fatalf("%s: parse: %v", name, err)
+		}
+		parts, err := SplitDIDLItems(didl)
+		if err != nil || len(parts) != len(items) || len(items) == 0 {
+			t.Fatalf("%s: got %d items, %d parts (err=%v)", name, len(items), len(parts), err)
+		}
+
+		built := BuildDIDL(items...)
+		again, err := ParseDIDLItems(built)
+		if err != nil {
+			t.Fatalf("%s: reparse: %v\n%s", name, err, built)
+		}
+		if !reflect.DeepEqual(again, items) {
+			t.Fatalf("%s: round trip mismatch:\n got %+v\nwant %+v", name, again, items)
+		}
+		if rebuilt := BuildDIDL(again...); rebuilt != built {
+			t.Fatalf("%s: serializer not stable:\n%s\n%s", name, built, rebuilt)
+		}
+		// Each item also survives on its own (as it does in r:resMD or a queue export).
+		for i, part := range parts {
+			single, err := ParseDIDLItems(part)
+			if err != nil || len(single) != 1 || !reflect.DeepEqual(single[0], items[i]) {
+				t.Fatalf("%s: item %d differs when split: %+v err=%v", name, i, single, err)
+			}
+		}
+	}
+}
+
+func TestParseDIDLItemsFullModel(t *testing.T) {
+	t.Parallel()
+
+	fixtures := loadDIDLFixtures(t)
+	items, err := ParseDIDLItems(fixtures["library_tracks.xml"])
+	if err != nil || len(items) != 2 {
+		t.Fatalf("parse: %v %+v", err, items)
+	}
+	track, album := items[0], items[1]
+	if track.Container || !album.Container {
+		t.Fatalf("unexpected container flags: %v %v", track.Container, album.Container)
+	}
+	if track.ParentID != "A:ALBUMARTIST/Miles%20Davis/Kind%20of%20Blue" || track.OriginalTrackNumber != 3 ||
+		track.Genre != "Jazz" || track.Date != "1959-08-17" || track.AlbumArtist != "Miles Davis" || track.Artist != "Miles Davis" {
+		t.Fatalf("unexpected track: %+v", track)
+	}
+	if !reflect.DeepEqual(track.Contributors, []string{"John Coltrane", "Bill Evans"}) {
+		t.Fatalf("unexpected contributors: %v", track.Contributors)
+	}
+	if !reflect.DeepEqual(track.Artists, []DIDLPerson{{Name: "Miles Davis", Role: "AlbumArtist"}, {Name: "Bill Evans", Role: "Composer"}}) {
+		t.Fatalf("unexpected artists: %v", track.Artists)
+	}
+	if len(track.Resources) != 1 || track.Resources[0].Duration != "0:05:37" || track.Resources[0].Attrs["sampleFrequency"] != "44100" ||
+		track.ProtocolInfo != "x-file-cifs:*:audio/flac:*" {
+		t.Fatalf("unexpected res: %+v", track.Resources)
+	}
+	wantExtra := []DIDLElement{
+		{Namespace: nsUPnP, Name: "genre", Value: "Modal Jazz"},
+		{Namespace: nsRincon, Name: "tiid", Value: "1234"},
+		{Namespace: "urn:example:ratings", Name: "rating", Attrs: map[string]string{"stars": "4"}, Value: "80"},
+	}
+	if !reflect.DeepEqual(track.Extra, wantExtra) {
+		t.Fatalf("unexpected extra: %+v", track.Extra)
+	}
+
+	items, err = ParseDIDLItems(fixtures["radio_position.xml"])
+	if err != nil || len(items) != 1 {
+		t.Fatalf("parse radio: %v %+v", err, items)
+	}
+	if items[0].StreamContent != "Khruangbin - Maria También" || items[0].RadioShowMD != "Lauren Laverne,p0hkx4kr" {
+		t.Fatalf("unexpected radio item: %+v", items[0])
+	}
+
+	items, err = ParseDIDLItems(fixtures["queue_spotify.xml"])
+	if err != nil || len(items) != 2 {
+		t.Fatalf("parse queue: %v %+v", err, items)
+	}
+	if !reflect.DeepEqual(items[1].Creators, []string{"Miles Davis", "Bill Evans"}) ||
+		!reflect.DeepEqual(items[1].Desc, []DIDLDesc{{ID: "cdudn", NameSpace: nsRincon, Value: "SA_RINCON3079_X_#Svc3079-0-Token"}}) {
+		t.Fatalf("unexpected queue item: %+v", items[1])
+	}
+}
+
+func TestParseDIDLItemsKeepsNamespacedAttrsAndNesting(t *testing.T) {
+	t.Parallel()
+
+	const nsDLNA = "urn:schemas-dlna-org:metadata-1-0/"
+	items, err := ParseDIDLItems(loadDIDLFixtures(t)["namespaced.xml"])
+	if err != nil || len(items) != 1 {
+		t.Fatalf("parse: %v %+v", err, items)
+	}
+	it := items[0]
+	if it.Restricted != "false" || !reflect.DeepEqual(it.Attrs, map[string]string{"{" + nsRincon + "}favoriteOf": "household"}) {
+		t.Fatalf("unexpected item attributes: restricted=%q attrs=%v", it.Restricted, it.Attrs)
+	}
+	if it.Resources[0].Attrs["{"+nsDLNA+"}ifoFileURI"] != "http://example.com/ifo" {
+		t.Fatalf("unexpected res attrs: %v", it.Resources[0].Attrs)
+	}
+	// An albumArtURI with an attribute the field cannot hold stays in Extra.
+	wantExtra := []DIDLElement{
+		{Namespace: nsUPnP, Name: "albumArtURI", Attrs: map[string]string{"{" + nsDLNA + "}profileID": "JPEG_TN"}, Value: "http://example.com/art.jpg"},
+		{Namespace: nsRincon, Name: "podcast", Attrs: map[string]string{"{" + nsRincon + "}kind": "episodic"}, Children: []DIDLElement{
+			{Namespace: nsRincon, Name: "episode", Attrs: map[string]string{"number": "7"}, Value: "Pilot"},
+			{Namespace: nsDC, Name: "title", Attrs: map[string]string{"{" + nsXML + "}lang": "en"}, Value: "Morning Show Podcast"},
+		}},
+	}
+	if it.AlbumArtURI != "" || !reflect.DeepEqual(it.Extra, wantExtra) {
+		t.Fatalf("unexpected extra:\n got %+v\nwant %+v", it.Extra, wantExtra)
+	}
+
+	built := BuildDIDL(it)
+	for _, want := range []string{`restricted="false"`, `r:favoriteOf="household"`, `xmlns:ns0="` + nsDLNA + `" ns0:ifoFileURI=`, `<r:episode number="7">Pilot</r:episode>`, `xml:lang="en"`} {
+		if !strings.Contains(built, want) {
+			t.Fatalf("built DIDL missing %q:\n%s", want, built)
+		}
+	}
+	if strings.Contains(BuildDIDL(DIDLItem{ID: "x", Title: "t"}), "restricted") {
+		t.Fatalf("restricted written although unset")
+	}
+}
+
+// TestBuildDIDLRoundTripProperty checks parse(build(item)) == item for
+// generated items, including XML special characters in every field.
+func TestBuildDIDLRoundTripProperty(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(1))
+	const alphabet = "abcXYZ019 &<>\"'#%:/?=;-_.éü☃"
+	str := func() string {
+		n := rng.Intn(12)
+		r := []rune(alphabet)
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			b.WriteRune(r[rng.Intn(len(r))])
+		}
+		return strings.TrimSpace(b.String())
+	}
+	nonEmpty := func() string {
+		for {
+			if s := str(); s != "" {
+				return s
+			}
+		}
+	}
+	attrKeys := []string{"size", "bitrate", "nrAudioChannels", "x-extra", "{" + nsRincon + "}tiid", "{urn:example:a}x", "{urn:example:b}x", "{" + nsXML + "}lang"}
+	attrs := func() map[string]string {
+		n := rng.Intn(3)
+		if n == 0 {
+			return nil
+		}
+		m := map[string]string{}
+		for i := 0; i < n; i++ {
+			m[attrKeys[rng.Intn(len(attrKeys))]] = str()
+		}
+		return m
+	}
+	namespaces := []string{"", nsDC, nsUPnP, nsRincon, "urn:example:test"}
+	var element func(depth int) DIDLElement
+	element = func(depth int) DIDLElement {
+		el := DIDLElement{
+			Namespace: namespaces[rng.Intn(len(namespaces))],
+			Name:      []string{"rating", "tiid", "x1", "foo-bar"}[rng.Intn(4)],
+			Attrs:     attrs(),
+			Value:     str(),
+		}
+		for n := rng.Intn(3 - depth); depth < 2 && n > 0; n-- {
+			el.Children = append(el.Children, element(depth+1))
+		}
+		return el
+	}
+
+	for i := 0; i < 500; i++ {
+		it := DIDLItem{
+			ID:                  str(),
+			ParentID:            str(),
+			Restricted:          []string{"", "true", "false", "1"}[rng.Intn(4)],
+			Attrs:               attrs(),
+			Container:           rng.Intn(2) == 0,
+			Title:               str(),
+			Class:               str(),
+			Album:               str(),
+			AlbumArtURI:         str(),
+			Description:         str(),
+			Type:                str(),
+			AlbumArtist:         str(),
+			Genre:               str(),
+			Date:                str(),
+			Ordinal:             str(),
+			StreamContent:       str(),
+			RadioShowMD:         str(),
+			OriginalTrackNumber: rng.Intn(3) * rng.Intn(30),
+		}
+		if rng.Intn(2) == 0 {
+			it.ResMD = BuildDIDL(DIDLItem{ID: nonEmpty(), Title: str(), URI: str()})
+		}
+		for n := rng.Intn(3); n > 0; n-- {
+			it.Creators = append(it.Creators, str())
+		}
+		for n := rng.Intn(3); n > 0; n-- {
+			it.Artists = append(it.Artists, DIDLPerson{Name: str(), Role: str()})
+		}
+		for n := rng.Intn(3); n > 0; n-- {
+			it.Contributors = append(it.Contributors, str())
+		}
+		for n := rng.Intn(3); n > 0; n-- {
+			it.Resources = append(it.Resources, DIDLRes{URI: str(), ProtocolInfo: str(), Duration: str(), Attrs: attrs()})
+		}
+		for n := rng.Intn(3); n > 0; n-- {
+			it.Desc = append(it.Desc, DIDLDesc{ID: str(), NameSpace: str(), Value: str()})
+		}
+		for n := rng.Intn(3); n > 0; n-- {
+			it.Extra = append(it.Extra, element(0))
+		}
+		// Mirrored fields, as ParseDIDLItems fills them.
+		if len(it.Resources) > 0 {
+			it.URI, it.ProtocolInfo = it.Resources[0].URI, it.Resources[0].ProtocolInfo
+		}
+		if len(it.Creators) > 0 {
+			it.Artist = it.Creators[0]
+		} else if len(it.Artists) > 0 {
+			it.Artist = it.Artists[0].Name
+		}
+
+		built := BuildDIDL(it)
+		got, err := ParseDIDLItems(built)
+		if err != nil || len(got) != 1 {
+			t.Fatalf("case %d: parse: %v\n%s", i, err, built)
+		}
+		if !reflect.DeepEqual(got[0], it) {
+			t.Fatalf("case %d: round trip mismatch:\n got %+v\nwant %+v\n%s", i, got[0], it, built)
+		}
+	}
+}
@@ -121,6 +121,19 @@ func discoverViaTopologyFromIP(ctx context.Context, timeout time.Duration, ip st
 		return nil, err
 	}
 
+	return sortDevices(topologyDevices(top, includeInvisible)), nil
+}
+
+// topologyDevices lists the members of top by IP. Bonded devices carry their
+// bond, so invisible pair partners and satellites can be told apart.
+func topologyDevices(top Topology, includeInvisible bool) map[string]Device {
+	bonds := map[string]DeviceBond{}
+	for _, b := range top.Bonds() {
+		for _, bm := range b.Members {
+			bonds[bm.UUID] = DeviceBond{Type: b.Type, Room: b.Room, Channels: bm.Channels, Primary: bm.UUID == b.Primary}
+		}
+	}
+
 	byIP := map[string]Device{}
 	for _, g := range top.Groups {
 		for _, m := range g.Members {
@@ -131,16 +144,19 @@ func discoverViaTopologyFromIP(ctx context.Context, timeout time.Duration, ip st
 			if name == "" {
 				name = m.IP
 			}
-			byIP[m.IP] = Device{
+			d := Device{
 				IP:       m.IP,
 				Name:     name,
 				UDN:      m.UUID,
 				Location: m.Location,
 			}
+			if b, ok := bonds[m.UUID]; ok {
+				d.Bond = &b
+			}
+			byIP[m.IP] = d
 		}
 	}
-
-	return sortDevices(byIP), nil
+	return byIP
 }
 
 func discoverViaTopology(ctx context.Context, timeout time.Duration, results []ssdpResult, includeInvisible bool) ([]Device, error) {
@@ -176,26 +192,7 @@ func discoverViaTopology(ctx context.Context, timeout time.Duration, results []s
 			continue
 		}
 
-		byIP := map[string]Device{}
-		for _, g := range top.Groups {
-			for _, m := range g.Members {
-				if !includeInvisible && !m.IsVisible {
-					continue
-				}
-				name := strings.TrimSpace(m.Name)
-				if name == "" {
-					name = m.IP
-				}
-				byIP[m.IP] = Device{
-					IP:       m.IP,
-					Name:     name,
-					UDN:      m.UUID,
-					Location: m.Location,
-				}
-			}
-		}
-
-		bestByIP = preferDeviceSet(bestByIP, byIP)
+		bestByIP = preferDeviceSet(bestByIP, topologyDevices(top, includeInvisible))
 	}
 
 	if len(bestByIP) > 0 {
@@ -0,0 +1,49 @@
+package sonos
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatHHMMSS formats a duration the way AVTransport expects time values
+// (Seek REL_TIME targets, sleep timer durations): HH:MM:SS, truncated to seconds.
+func FormatHHMMSS(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	secs := int64(d / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", secs/3600, (secs/60)%60, secs%60)
+}
+
+// ParseHHMMSS parses AVTransport time values such as RelTime/TrackDuration
+// ("0:03:21"), and also accepts the shorter "M:SS" form.
+// Sonos reports "NOT_IMPLEMENTED" for streams without a position; that is an error.
+func ParseHHMMSS(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty time value")
+	}
+	// Some firmwares append fractional seconds (e.g. "0:00:10.000").
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		s = s[:dot]
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid time value: %q (expected [h:]mm:ss)", s)
+	}
+	var total int64
+	for i, p := range parts {
+		n, err := strconv.ParseInt(p, 10, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid time value: %q (expected [h:]mm:ss)", s)
+		}
+		// Minutes and seconds (but not a leading hour/minute field) must be < 60.
+		if i > 0 && n >= 60 {
+			return 0, fmt.Errorf("invalid time value: %q (expected [h:]mm:ss)", s)
+		}
+		total = total*60 + n
+	}
+	return time.Duration(total) * time.Second, nil
+}
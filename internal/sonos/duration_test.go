@@ -0,0 +1,44 @@
+package sonos
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseHHMMSS(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]time.Duration{
+		"0:03:21":      3*time.Minute + 21*time.Second,
+		"01:00:00":     time.Hour,
+		"1:23":         time.Minute + 23*time.Second,
+		" 0:00:10.000": 10 * time.Second,
+		"90:00":        90 * time.Minute,
+	}
+	for in, want := range cases {
+		got, err := ParseHHMMSS(in)
+		if err != nil {
+			t.Fatalf("ParseHHMMSS(%q): %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("ParseHHMMSS(%q) = %s, want %s", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"", "NOT_IMPLEMENTED", "10", "0:61:00", "1:2:3:4", "-1:00"} {
+		if _, err := ParseHHMMSS(in); err == nil {
+			t.Fatalf("ParseHHMMSS(%q): expected error", in)
+		}
+	}
+}
+
+func TestFormatHHMMSS(t *testing.T) {
+	t.Parallel()
+
+	if got := FormatHHMMSS(90*time.Minute + 5*time.Second + 400*time.Millisecond); got != "01:30:05" {
+		t.Fatalf("FormatHHMMSS: %q", got)
+	}
+	if got := FormatHHMMSS(-time.Second); got != "00:00:00" {
+		t.Fatalf("FormatHHMMSS negative: %q", got)
+	}
+}
@@ -0,0 +1,168 @@
+package sonos
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Speaker tone controls (RenderingControl). These are per speaker, not per
+// group: send them to each member rather than the coordinator.
+
+const (
+	MinToneLevel = -10
+	MaxToneLevel = 10
+	MinBalance   = -100 // fully left
+	MaxBalance   = 100  // fully right
+)
+
+// EQSettings is a speaker's bass, treble, loudness and left/right balance.
+type EQSettings struct {
+	Bass     int  `json:"bass"`
+	Treble   int  `json:"treble"`
+	Loudness bool `json:"loudness"`
+	Balance  int  `json:"balance"`
+}
+
+func (c *Client) GetBass(ctx context.Context) (int, error) {
+	resp, err := c.soapCall(ctx, controlRenderingControl, urnRenderingControl, "GetBass", map[string]string{
+		"InstanceID": "0",
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(resp["CurrentBass"])
+}
+
+func (c *Client) SetBass(ctx context.Context, level int) error {
+	if err := checkToneLevel("bass", level); err != nil {
+		return err
+	}
+	_, err := c.soapCall(ctx, controlRenderingControl, urnRenderingControl, "SetBass", map[string]string{
+		"InstanceID":  "0",
+		"DesiredBass": strconv.Itoa(level),
+	})
+	return err
+}
+
+func (c *Client) GetTreble(ctx context.Context) (int, error) {
+	resp, err := c.soapCall(ctx, controlRenderingControl, urnRenderingControl, "GetTreble", map[string]string{
+		"InstanceID": "0",
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(resp["CurrentTreble"])
+}
+
+func (c *Client) SetTreble(ctx context.Context, level int) error {
+	if err := checkToneLevel("treble", level); err != nil {
+		return err
+	}
+	_, err := c.soapCall(ctx, controlRenderingControl, urnRenderingControl, "SetTreble", map[string]string{
+		"InstanceID":    "0",
+		"DesiredTreble": strconv.Itoa(level),
+	})
+	return err
+}
+
+func (c *Client) GetLoudness(ctx context.Context) (bool, error) {
+	resp, err := c.soapCall(ctx, controlRenderingControl, urnRenderingControl, "GetLoudness", map[string]string{
+		"InstanceID": "0",
+		"Channel":    "Master",
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp["CurrentLoudness"] == "1", nil
+}
+
+func (c *Client) SetLoudness(ctx context.Context, on bool) error {
+	v := "0"
+	if on {
+		v = "1"
+	}
+	_, err := c.soapCall(ctx, controlRenderingControl, urnRenderingControl, "SetLoudness", map[string]string{
+		"InstanceID":      "0",
+		"Channel":         "Master",
+		"DesiredLoudness": v,
+	})
+	return err
+}
+
+// GetBalance derives the balance from the LF/RF channel volumes:
+// -100 is fully left, 0 centered, 100 fully right.
+func (c *Client) GetBalance(ctx context.Context) (int, error) {
+	lf, err := c.getChannelVolume(ctx, "LF")
+	if err != nil {
+		return 0, err
+	}
+	rf, err := c.getChannelVolume(ctx, "RF")
+	if err != nil {
+		return 0, err
+	}
+	return rf - lf, nil
+}
+
+// SetBalance keeps the favored channel at 100 and lowers the other one.
+func (c *Client) SetBalance(ctx context.Context, balance int) error {
+	if balance < MinBalance || balance > MaxBalance {
+		return fmt.Errorf("balance must be between %d and %d", MinBalance, MaxBalance)
+	}
+	lf, rf := 100, 100
+	if balance > 0 {
+		lf -= balance
+	} else {
+		rf += balance
+	}
+	if err := c.setChannelVolume(ctx, "LF", lf); err != nil {
+		return err
+	}
+	return c.setChannelVolume(ctx, "RF", rf)
+}
+
+// GetEQSettings reads bass, treble, loudness and balance in one go.
+func (c *Client) GetEQSettings(ctx context.Context) (EQSettings, error) {
+	var s EQSettings
+	var err error
+	if s.Bass, err = c.GetBass(ctx); err != nil {
+		return EQSettings{}, err
+	}
+	if s.Treble, err = c.GetTreble(ctx); err != nil {
+		return EQSettings{}, err
+	}
+	if s.Loudness, err = c.GetLoudness(ctx); err != nil {
+		return EQSettings{}, err
+	}
+	if s.Balance, err = c.GetBalance(ctx); err != nil {
+		return EQSettings{}, err
+	}
+	return s, nil
+}
+
+func (c *Client) getChannelVolume(ctx context.Context, channel string) (int, error) {
+	resp, err := c.soapCall(ctx, controlRenderingControl, urnRenderingControl, "GetVolume", map[string]string{
+		"InstanceID": "0",
+		"Channel":    channel,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(resp["CurrentVolume"])
+}
+
+func (c *Client) setChannelVolume(ctx context.Context, channel string, volume int) error {
+	_, err := c.soapCall(ctx, controlRenderingControl, urnRenderingControl, "SetVolume", map[string]string{
+		"InstanceID":    "0",
+		"Channel":       channel,
+		"DesiredVolume": strconv.Itoa(volume),
+	})
+	return err
+}
+
+func checkToneLevel(name string, level int) error {
+	if level < MinToneLevel || level > MaxToneLevel {
+		return fmt.Errorf("%s must be between %d and %d", name, MinToneLevel, MaxToneLevel)
+	}
+	return nil
+}
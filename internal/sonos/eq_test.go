@@ -0,0 +1,69 @@
+package sonos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEQSettingsAndBalance(t *testing.T) {
+	t.Parallel()
+
+	var sets []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		action = strings.Trim(action[strings.LastIndex(action, "#")+1:], `"`)
+		body := readBody(t, r)
+		switch action {
+		case "GetBass":
+			return httpResponse(200, soapOK(urnRenderingControl, action, "<CurrentBass>3</CurrentBass>")), nil
+		case "GetTreble":
+			return httpResponse(200, soapOK(urnRenderingControl, action, "<CurrentTreble>-2</CurrentTreble>")), nil
+		case "GetLoudness":
+			return httpResponse(200, soapOK(urnRenderingControl, action, "<CurrentLoudness>1</CurrentLoudness>")), nil
+		case "GetVolume":
+			v := "100"
+			if strings.Contains(body, "<Channel>RF</Channel>") {
+				v = "80"
+			}
+			return httpResponse(200, soapOK(urnRenderingControl, action, "<CurrentVolume>"+v+"</CurrentVolume>")), nil
+		default:
+			sets = append(sets, action+" "+body)
+			return httpResponse(200, soapOK(urnRenderingControl, action, "")), nil
+		}
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+	ctx := context.Background()
+
+	got, err := c.GetEQSettings(ctx)
+	if err != nil {
+		t.Fatalf("GetEQSettings: %v", err)
+	}
+	if got != (EQSettings{Bass: 3, Treble: -2, Loudness: true, Balance: -20}) {
+		t.Fatalf("unexpected settings: %+v", got)
+	}
+
+	if err := c.SetBass(ctx, 11); err == nil {
+		t.Fatalf("expected range error")
+	}
+	if err := c.SetTreble(ctx, -4); err != nil {
+		t.Fatalf("SetTreble: %v", err)
+	}
+	if err := c.SetBalance(ctx, 30); err != nil {
+		t.Fatalf("SetBalance: %v", err)
+	}
+	if len(sets) != 3 {
+		t.Fatalf("unexpected calls: %v", sets)
+	}
+	if !strings.Contains(sets[0], "<DesiredTreble>-4</DesiredTreble>") {
+		t.Fatalf("unexpected SetTreble: %s", sets[0])
+	}
+	if !strings.Contains(sets[1], "<Channel>LF</Channel>") || !strings.Contains(sets[1], "<DesiredVolume>70</DesiredVolume>") {
+		t.Fatalf("unexpected LF volume: %s", sets[1])
+	}
+	if !strings.Contains(sets[2], "<Channel>RF</Channel>") || !strings.Contains(sets[2], "<DesiredVolume>100</DesiredVolume>") {
+		t.Fatalf("unexpected RF volume: %s", sets[2])
+	}
+}
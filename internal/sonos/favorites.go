@@ -2,7 +2,9 @@ package sonos
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 )
 
 type FavoriteItem struct {
@@ -68,3 +70,105 @@ func favoriteURI(favorite DIDLItem) string {
 	}
 	return items[0].URI
 }
+
+const favoriteClass = "object.itemobject.item.sonos-favorite"
+
+// BuildFavoriteDIDL builds the DIDL-Lite document CreateObject expects for a
+// new FV:2 entry. fav.URI is what gets played, fav.ResMD the metadata passed
+// along with it (stored as r:resMD).
+func BuildFavoriteDIDL(fav DIDLItem) string {
+	protocolInfo := fav.ProtocolInfo
+	if protocolInfo == "" {
+		protocolInfo = defaultProtocolInfo(fav.URI)
+	}
+	favType := fav.Type
+	if favType == "" {
+		favType = "instantPlay"
+	}
+	ordinal := fav.Ordinal
+	if ordinal == "" {
+		ordinal = "0"
+	}
+	return BuildDIDL(DIDLItem{
+		Title:        fav.Title,
+		Class:        favoriteClass,
+		Ordinal:      ordinal,
+		URI:          fav.URI,
+		ProtocolInfo: protocolInfo,
+		AlbumArtURI:  fav.AlbumArtURI,
+		Type:         favType,
+		Description:  fav.Description,
+		ResMD:        fav.ResMD,
+	})
+}
+
+// defaultProtocolInfo derives "<scheme>:*:*:*" from a URI, which is what the
+// Sonos apps write for most favorites.
+func defaultProtocolInfo(uri string) string {
+	if i := strings.IndexByte(uri, ':'); i > 0 {
+		switch scheme := uri[:i]; scheme {
+		case "x-sonos-spotify":
+			return "sonos.com-spotify:*:audio/x-spotify:*"
+		case "http", "https":
+			return "http-get:*:*:*"
+		default:
+			return scheme + ":*:*:*"
+		}
+	}
+	return "*:*:*:*"
+}
+
+// CreateFavorite adds fav to Sonos Favorites and returns the new FV:2/n ID.
+func (c *Client) CreateFavorite(ctx context.Context, fav DIDLItem) (string, error) {
+	if strings.TrimSpace(fav.Title) == "" {
+		return "", errors.New("favorite title is required")
+	}
+	if strings.TrimSpace(fav.URI) == "" {
+		return "", errors.New("favorite URI is required")
+	}
+	return c.CreateObject(ctx, "FV:2", BuildFavoriteDIDL(fav))
+}
+
+// RenameFavorite changes the title of an existing favorite.
+func (c *Client) RenameFavorite(ctx context.Context, fav DIDLItem, title string) error {
+	if !strings.HasPrefix(fav.ID, "FV:2/") {
+		return fmt.Errorf("invalid favorite id: %q", fav.ID)
+	}
+	if strings.TrimSpace(title) == "" {
+		return errors.New("favorite title is required")
+	}
+	return c.UpdateObject(ctx, fav.ID,
+		"<dc:title>"+xmlEscapeText(fav.Title)+"</dc:title>",
+		"<dc:title>"+xmlEscapeText(title)+"</dc:title>")
+}
+
+// DeleteFavorite removes a favorite by its FV:2/n ID.
+func (c *Client) DeleteFavorite(ctx context.Context, id string) error {
+	if !strings.HasPrefix(id, "FV:2/") {
+		return fmt.Errorf("invalid favorite id: %q", id)
+	}
+	return c.DestroyObject(ctx, id)
+}
+
+// SpotifyFavorite builds a favorite for a Spotify URI/share link, using the
+// same URI and metadata forms as EnqueueSpotify.
+func SpotifyFavorite(input, title string) (DIDLItem, error) {
+	ref, ok := ParseSpotifyRef(input)
+	if !ok {
+		return DIDLItem{}, fmt.Errorf("not a Spotify URI/link: %q", input)
+	}
+	itemClass, itemIDKey, uriPrefixes := spotifySonosMagic(ref.Kind)
+	if itemClass == "" || len(uriPrefixes) == 0 {
+		return DIDLItem{}, fmt.Errorf("unsupported Spotify kind: %s", ref.Kind)
+	}
+	fav := DIDLItem{
+		Title:       title,
+		URI:         uriPrefixes[0] + ref.EncodedID,
+		Description: "Spotify",
+		ResMD:       buildShareDIDL(itemIDKey+ref.EncodedID, title, itemClass, ref.ServiceNums[0]),
+	}
+	if !strings.HasPrefix(fav.URI, "x-sonos-spotify:") {
+		fav.ProtocolInfo = "x-rincon-cpcontainer:*:*:*"
+	}
+	return fav, nil
+}
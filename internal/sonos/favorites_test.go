@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -88,3 +89,80 @@ func TestFavoriteURIFromResMD(t *testing.T) {
 		t.Fatalf("favoriteURI: %q", got)
 	}
 }
+
+func TestFavoriteDIDLRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	resMD := `<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns:r="urn:schemas-rinconnetworks-com:metadata-1-0/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/"><item id="F00092020s24861" parentID="L" restricted="true"><dc:title>Radio &amp; Co</dc:title><upnp:class>object.item.audioItem.audioBroadcast</upnp:class><desc id="cdudn" nameSpace="urn:schemas-rinconnetworks-com:metadata-1-0/">SA_RINCON65031_</desc></item></DIDL-Lite>`
+	browsed := `<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns:r="urn:schemas-rinconnetworks-com:metadata-1-0/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/">` +
+		`<item id="FV:2/7" parentID="FV:2" restricted="false"><dc:title>Radio &amp; Co</dc:title><upnp:class>object.itemobject.item.sonos-favorite</upnp:class><r:ordinal>3</r:ordinal>` +
+		`<res protocolInfo="x-sonosapi-stream:*:*:*">x-sonosapi-stream:s24861?sid=254&amp;flags=8224&amp;sn=0</res><upnp:albumArtURI>http://cdn-radiotime-logos.tunein.com/s24861q.png</upnp:albumArtURI>` +
+		`<r:type>instantPlay</r:type><r:description>TuneIn Station</r:description><r:resMD>` + xmlEscapeText(resMD) + `</r:resMD></item></DIDL-Lite>`
+
+	items, err := ParseDIDLItems(browsed)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("parse: %v %+v", err, items)
+	}
+	fav := items[0]
+	if fav.ID != "FV:2/7" || fav.ParentID != "FV:2" || fav.ProtocolInfo != "x-sonosapi-stream:*:*:*" ||
+		fav.Description != "TuneIn Station" || fav.Type != "instantPlay" || fav.ResMD != resMD {
+		t.Fatalf("unexpected parse: %+v", fav)
+	}
+
+	again, err := ParseDIDLItems(BuildFavoriteDIDL(fav))
+	if err != nil || len(again) != 1 {
+		t.Fatalf("reparse: %v %+v", err, again)
+	}
+	got := again[0]
+	got.ID, got.ParentID, got.Restricted = fav.ID, fav.ParentID, fav.Restricted
+	if !reflect.DeepEqual(got, fav) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, fav)
+	}
+}
+
+func TestFavoriteEditActions(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		action = strings.Trim(action[strings.LastIndex(action, "#")+1:], `"`)
+		calls = append(calls, action+" "+readBody(t, r))
+		if action == "CreateObject" {
+			return httpResponse(200, soapOK(urnContentDirectory, action, "<ObjectID>FV:2/12</ObjectID><Result></Result>")), nil
+		}
+		return httpResponse(200, soapOK(urnContentDirectory, action, "")), nil
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+	ctx := context.Background()
+
+	fav, err := SpotifyFavorite("https://open.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy", "Kind of Blue")
+	if err != nil {
+		t.Fatalf("SpotifyFavorite: %v", err)
+	}
+	id, err := c.CreateFavorite(ctx, fav)
+	if err != nil || id != "FV:2/12" {
+		t.Fatalf("CreateFavorite: id=%q err=%v", id, err)
+	}
+	if !strings.Contains(calls[0], "<ContainerID>FV:2</ContainerID>") ||
+		!strings.Contains(calls[0], "x-rincon-cpcontainer:1004206cspotify%3aalbum%3a4aawyAB9vmqN3uQ7FjRGTy") ||
+		!strings.Contains(calls[0], "r:resMD&gt;&amp;lt;DIDL-Lite") {
+		t.Fatalf("unexpected CreateObject: %s", calls[0])
+	}
+
+	if err := c.RenameFavorite(ctx, DIDLItem{ID: "FV:2/12", Title: "Old & Blue"}, "New"); err != nil {
+		t.Fatalf("RenameFavorite: %v", err)
+	}
+	if !strings.Contains(calls[1], "<CurrentTagValue>&lt;dc:title&gt;Old &amp;amp; Blue&lt;/dc:title&gt;</CurrentTagValue>") {
+		t.Fatalf("unexpected UpdateObject: %s", calls[1])
+	}
+	if err := c.DeleteFavorite(ctx, "FV:2/12"); err != nil {
+		t.Fatalf("DeleteFavorite: %v", err)
+	}
+	if err := c.DeleteFavorite(ctx, "SQ:1"); err == nil {
+		t.Fatalf("expected error for non-favorite id")
+	}
+	if len(calls) != 3 || !strings.HasPrefix(calls[2], "DestroyObject") {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+}
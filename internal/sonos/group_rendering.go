@@ -63,3 +63,18 @@ func (c *Client) SetGroupMute(ctx context.Context, mute bool) error {
 	})
 	return err
 }
+
+// SetRelativeGroupVolume changes the group volume by adjustment (may be
+// negative), keeping the members' relative levels, and returns the new group
+// volume.
+func (c *Client) SetRelativeGroupVolume(ctx context.Context, adjustment int) (int, error) {
+	_ = c.SnapshotGroupVolume(ctx)
+	resp, err := c.soapCall(ctx, controlGroupRendering, urnGroupRenderingControl, "SetRelativeGroupVolume", map[string]string{
+		"InstanceID": "0",
+		"Adjustment": strconv.Itoa(adjustment),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(resp["NewVolume"])
+}
@@ -0,0 +1,133 @@
+package sonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrEQNotSupported is returned by GetEQ/SetEQ when the speaker does not
+// have the requested setting (e.g. NightMode on a speaker that is not a
+// soundbar).
+var ErrEQNotSupported = errors.New("setting not supported by this speaker")
+
+// HTSetting describes one home-theater EQ type.
+type HTSetting struct {
+	Name   string // CLI name, e.g. "night"
+	EQType string // RenderingControl EQType, e.g. "NightMode"
+	Bool   bool   // on/off rather than a level
+	Min    int
+	Max    int
+	// Needs is "sub" or "surround" when the setting only exists with those
+	// speakers bonded to the soundbar.
+	Needs string
+}
+
+var htSettings = []HTSetting{
+	{Name: "night", EQType: "NightMode", Bool: true, Max: 1},
+	{Name: "dialog", EQType: "DialogLevel", Bool: true, Max: 1},
+	{Name: "sub", EQType: "SubEnable", Bool: true, Max: 1, Needs: "sub"},
+	{Name: "sub-gain", EQType: "SubGain", Min: -15, Max: 15, Needs: "sub"},
+	{Name: "surround", EQType: "SurroundEnable", Bool: true, Max: 1, Needs: "surround"},
+	{Name: "surround-level", EQType: "SurroundLevel", Min: -15, Max: 15, Needs: "surround"},
+	{Name: "music-surround-level", EQType: "MusicSurroundLevel", Min: -15, Max: 15, Needs: "surround"},
+	{Name: "height-level", EQType: "HeightChannelLevel", Min: -10, Max: 10},
+}
+
+// HTSettings returns the home-theater settings in display order.
+func HTSettings() []HTSetting {
+	return append([]HTSetting(nil), htSettings...)
+}
+
+// LookupHTSetting finds a setting by CLI name or EQType (case-insensitive).
+func LookupHTSetting(name string) (HTSetting, bool) {
+	name = strings.TrimSpace(name)
+	for _, s := range htSettings {
+		if strings.EqualFold(s.Name, name) || strings.EqualFold(s.EQType, name) {
+			return s, true
+		}
+	}
+	return HTSetting{}, false
+}
+
+// HTBond is what a soundbar's HTSatChanMapSet says is bonded to it.
+type HTBond struct {
+	Sub       bool `json:"sub"`
+	Surrounds bool `json:"surrounds"`
+}
+
+// HomeTheater reports whether m is the primary of a home-theater bond and
+// which speakers are bonded to it. A soundbar without sub or surrounds has no
+// bond in the topology.
+func (m Member) HomeTheater() (HTBond, bool) {
+	if strings.TrimSpace(m.HTSatChanMapSet) == "" {
+		return HTBond{}, false
+	}
+	var b HTBond
+	for _, entry := range strings.Split(m.HTSatChanMapSet, ";") {
+		_, channels, ok := strings.Cut(entry, ":")
+		if !ok {
+			continue
+		}
+		for _, ch := range strings.Split(channels, ",") {
+			switch strings.TrimSpace(ch) {
+			case "SW":
+				b.Sub = true
+			case "LR", "RR":
+				b.Surrounds = true
+			}
+		}
+	}
+	return b, true
+}
+
+// Supports reports whether bond has the speakers s needs; settings without
+// requirements are assumed supported until the speaker says otherwise.
+func (b HTBond) Supports(s HTSetting) bool {
+	switch s.Needs {
+	case "sub":
+		return b.Sub
+	case "surround":
+		return b.Surrounds
+	default:
+		return true
+	}
+}
+
+func (c *Client) GetEQ(ctx context.Context, eqType string) (int, error) {
+	resp, err := c.soapCall(ctx, controlRenderingControl, urnRenderingControl, "GetEQ", map[string]string{
+		"InstanceID": "0",
+		"EQType":     eqType,
+	})
+	if err != nil {
+		return 0, eqError(eqType, err)
+	}
+	return strconv.Atoi(strings.TrimSpace(resp["CurrentValue"]))
+}
+
+func (c *Client) SetEQ(ctx context.Context, eqType string, value int) error {
+	_, err := c.soapCall(ctx, controlRenderingControl, urnRenderingControl, "SetEQ", map[string]string{
+		"InstanceID":   "0",
+		"EQType":       eqType,
+		"DesiredValue": strconv.Itoa(value),
+	})
+	return eqError(eqType, err)
+}
+
+// eqError maps the UPnP errors that mean the speaker lacks the setting to
+// ErrEQNotSupported: 401 (invalid action) and the 800 range Sonos uses for
+// "not supported". Everything else (402 invalid args, 501 action failed, ...)
+// is a real failure and passed through.
+func eqError(eqType string, err error) error {
+	var upnpErr *UPnPError
+	if !errors.As(err, &upnpErr) {
+		return err
+	}
+	code, convErr := strconv.Atoi(strings.TrimSpace(upnpErr.Code))
+	if convErr == nil && (code == 401 || (code >= 800 && code < 900)) {
+		return fmt.Errorf("%s: %w (%s)", eqType, ErrEQNotSupported, upnpErr.Error())
+	}
+	return fmt.Errorf("%s: %w", eqType, err)
+}
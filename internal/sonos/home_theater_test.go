@@ -0,0 +1,103 @@
+package sonos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseZoneGroupStateXML_HomeTheaterBond(t *testing.T) {
+	t.Parallel()
+
+	payload := `
+<ZoneGroupState>
+  <ZoneGroups>
+    <ZoneGroup Coordinator="RINCON_ARC1400" ID="RINCON_ARC1400:1">
+      <ZoneGroupMember ZoneName="Living Room" UUID="RINCON_ARC1400" Location="http://192.168.1.30:1400/xml/device_description.xml" HTSatChanMapSet="RINCON_ARC1400:LF,RF;RINCON_SUB1400:SW;RINCON_SLL1400:LR;RINCON_SLR1400:RR">
+        <Satellite ZoneName="Living Room" UUID="RINCON_SUB1400" Location="http://192.168.1.31:1400/xml/device_description.xml" HTSatChanMapSet="RINCON_ARC1400:LF,RF;RINCON_SUB1400:SW;RINCON_SLL1400:LR;RINCON_SLR1400:RR" Invisible="1" />
+      </ZoneGroupMember>
+    </ZoneGroup>
+    <ZoneGroup Coordinator="RINCON_BEAM1400" ID="RINCON_BEAM1400:1">
+      <ZoneGroupMember ZoneName="Den" UUID="RINCON_BEAM1400" Location="http://192.168.1.40:1400/xml/device_description.xml" HTSatChanMapSet="RINCON_BEAM1400:LF,RF;RINCON_SUB2400:SW" />
+    </ZoneGroup>
+    <ZoneGroup Coordinator="RINCON_K1400" ID="RINCON_K1400:1">
+      <ZoneGroupMember ZoneName="Kitchen" UUID="RINCON_K1400" Location="http://192.168.1.10:1400/xml/device_description.xml" />
+    </ZoneGroup>
+  </ZoneGroups>
+</ZoneGroupState>`
+
+	top, err := parseZoneGroupStateXML(payload)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	lr, _ := top.FindByName("Living Room")
+	if b, ok := lr.HomeTheater(); !ok || !b.Sub || !b.Surrounds || lr.IP != "192.168.1.30" {
+		t.Fatalf("living room: %v %+v %+v", ok, b, lr)
+	}
+	den, _ := top.FindByName("Den")
+	if b, ok := den.HomeTheater(); !ok || !b.Sub || b.Surrounds {
+		t.Fatalf("den: %v %+v", ok, b)
+	}
+	surround, _ := LookupHTSetting("SurroundLevel")
+	if b, _ := den.HomeTheater(); b.Supports(surround) {
+		t.Fatalf("den should not support %s", surround.EQType)
+	}
+	kitchen, _ := top.FindByName("Kitchen")
+	if _, ok := kitchen.HomeTheater(); ok {
+		t.Fatalf("kitchen should not be a home theater")
+	}
+}
+
+func TestGetSetEQ(t *testing.T) {
+	t.Parallel()
+
+	var setBody string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		action = strings.Trim(action[strings.LastIndex(action, "#")+1:], `"`)
+		body := readBody(t, r)
+		switch {
+		case action == "GetEQ" && strings.Contains(body, "<EQType>NightMode</EQType>"):
+			return httpResponse(200, soapOK(urnRenderingControl, action, "<CurrentValue>1</CurrentValue>")), nil
+		case action == "GetEQ" && strings.Contains(body, "<EQType>SubGain</EQType>"):
+			return httpResponse(500, soapFaultWithUPnPCode("402")), nil
+		case action == "GetEQ" && strings.Contains(body, "<EQType>SurroundLevel</EQType>"):
+			return httpResponse(500, soapFaultWithUPnPCode("501")), nil
+		case action == "GetEQ" && strings.Contains(body, "<EQType>DialogLevel</EQType>"):
+			return httpResponse(500, soapFaultWithUPnPCode("800")), nil
+		case action == "GetEQ":
+			return httpResponse(500, soapFaultWithUPnPCode("401")), nil
+		default:
+			setBody = body
+			return httpResponse(200, soapOK(urnRenderingControl, action, "")), nil
+		}
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+	ctx := context.Background()
+
+	if v, err := c.GetEQ(ctx, "NightMode"); err != nil || v != 1 {
+		t.Fatalf("GetEQ NightMode: %d %v", v, err)
+	}
+	for _, eqType := range []string{"HeightChannelLevel", "DialogLevel"} {
+		if _, err := c.GetEQ(ctx, eqType); !errors.Is(err, ErrEQNotSupported) {
+			t.Fatalf("%s: expected ErrEQNotSupported, got %v", eqType, err)
+		}
+	}
+	// Invalid arguments and failed actions are real errors, not "unsupported".
+	for _, eqType := range []string{"SubGain", "SurroundLevel"} {
+		_, err := c.GetEQ(ctx, eqType)
+		var upnpErr *UPnPError
+		if errors.Is(err, ErrEQNotSupported) || !errors.As(err, &upnpErr) {
+			t.Fatalf("%s: expected UPnP error, got %v", eqType, err)
+		}
+	}
+	if err := c.SetEQ(ctx, "SubGain", -3); err != nil {
+		t.Fatalf("SetEQ: %v", err)
+	}
+	if !strings.Contains(setBody, "<EQType>SubGain</EQType>") || !strings.Contains(setBody, "<DesiredValue>-3</DesiredValue>") {
+		t.Fatalf("unexpected SetEQ body: %s", setBody)
+	}
+}
@@ -0,0 +1,214 @@
+package sonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Local music library (indexed shares) below the A: containers, plus the
+// share list below S:.
+
+var libraryContainers = map[string]string{
+	"artists":      "A:ARTIST",
+	"albumartists": "A:ALBUMARTIST",
+	"albums":       "A:ALBUM",
+	"tracks":       "A:TRACKS",
+	"genres":       "A:GENRE",
+	"composers":    "A:COMPOSER",
+	"playlists":    "A:PLAYLISTS",
+	"shares":       "S:",
+}
+
+// LibraryCategories returns the category names accepted by
+// LibraryContainerID, sorted.
+func LibraryCategories() []string {
+	out := make([]string, 0, len(libraryContainers))
+	for k := range libraryContainers {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// LibraryContainerID maps a category name (artists, albums, ...) to its
+// ContentDirectory container.
+func LibraryContainerID(category string) (string, error) {
+	id, ok := libraryContainers[strings.ToLower(strings.TrimSpace(category))]
+	if !ok {
+		return "", fmt.Errorf("unknown library category %q (use %s)", category, strings.Join(LibraryCategories(), ", "))
+	}
+	return id, nil
+}
+
+// LibraryItem is a library entry plus its DIDL, which is what AddURIToQueue
+// needs to enqueue it.
+type LibraryItem struct {
+	Item DIDLItem `json:"item"`
+	Meta string   `json:"-"`
+}
+
+type LibraryPage struct {
+	ObjectID       string        `json:"objectID"`
+	Items          []LibraryItem `json:"items"`
+	NumberReturned int           `json:"numberReturned"`
+	TotalMatches   int           `json:"totalMatches"`
+	UpdateID       int           `json:"updateID"`
+}
+
+// BrowseLibrary lists the direct children of a library object (e.g. A:ALBUM or
+// A:ARTIST/Miles Davis). count <= 0 walks all pages from start.
+func (c *Client) BrowseLibrary(ctx context.Context, objectID string, start, count int) (LibraryPage, error) {
+	if start < 0 {
+		start = 0
+	}
+	page := LibraryPage{ObjectID: objectID}
+	const pageSize = 100
+	for {
+		want := pageSize
+		if count > 0 {
+			want = count - len(page.Items)
+			if want > pageSize {
+				want = pageSize
+			}
+		}
+		br, err := c.Browse(ctx, objectID, start, want)
+		if err != nil {
+			return LibraryPage{}, err
+		}
+		items, err := ParseDIDLItems(br.Result)
+		if err != nil {
+			return LibraryPage{}, err
+		}
+		metas, err := SplitDIDLItems(br.Result)
+		if err != nil {
+			return LibraryPage{}, err
+		}
+		for i, it := range items {
+			li := LibraryItem{Item: it}
+			if i < len(metas) {
+				li.Meta = metas[i]
+			}
+			page.Items = append(page.Items, li)
+		}
+		page.NumberReturned += br.NumberReturned
+		page.TotalMatches = br.TotalMatches
+		page.UpdateID = br.UpdateID
+		start += br.NumberReturned
+		if br.NumberReturned == 0 || start >= br.TotalMatches || (count > 0 && len(page.Items) >= count) {
+			return page, nil
+		}
+	}
+}
+
+// SearchLibrary searches a category using the "A:ARTIST:<term>" style object
+// IDs understood by Sonos.
+func (c *Client) SearchLibrary(ctx context.Context, category, term string, start, count int) (LibraryPage, error) {
+	id, err := LibraryContainerID(category)
+	if err != nil {
+		return LibraryPage{}, err
+	}
+	if !strings.HasPrefix(id, "A:") {
+		return LibraryPage{}, fmt.Errorf("library category %q is not searchable", category)
+	}
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return LibraryPage{}, errors.New("search term is required")
+	}
+	return c.BrowseLibrary(ctx, id+":"+url.PathEscape(term), start, count)
+}
+
+// EnqueueLibraryItem adds a library track or container (album, artist,
+// playlist, ...) to the queue and optionally starts playing it.
+func (c *Client) EnqueueLibraryItem(ctx context.Context, item LibraryItem, opts EnqueueOptions) (int, error) {
+	uri := strings.TrimSpace(item.Item.URI)
+	if uri == "" {
+		return 0, fmt.Errorf("library item %q is not playable", item.Item.ID)
+	}
+	desiredPos := opts.Position
+	if desiredPos < 0 {
+		desiredPos = 0
+	}
+	first, err := c.AddURIToQueue(ctx, uri, item.Meta, desiredPos, opts.AsNext)
+	if err != nil {
+		return 0, err
+	}
+	if opts.PlayNow {
+		if first > 0 {
+			return first, c.playFromQueueTrack(ctx, first)
+		}
+		return first, c.Play(ctx)
+	}
+	return first, nil
+}
+
+// RefreshShareIndex asks the household to rescan all music library shares.
+// albumArtistDisplayOption may be empty to keep the current setting.
+func (c *Client) RefreshShareIndex(ctx context.Context, albumArtistDisplayOption string) error {
+	_, err := c.soapCall(ctx, controlContentDirectory, urnContentDirectory, "RefreshShareIndex", map[string]string{
+		"AlbumArtistDisplayOption": albumArtistDisplayOption,
+	})
+	return err
+}
+
+// ShareIndexInProgress reports whether the music library is being indexed.
+func (c *Client) ShareIndexInProgress(ctx context.Context) (bool, error) {
+	resp, err := c.soapCall(ctx, controlContentDirectory, urnContentDirectory, "GetShareIndexInProgress", nil)
+	if err != nil {
+		return false, err
+	}
+	v := strings.TrimSpace(resp["IsIndexing"])
+	return v == "1" || strings.EqualFold(v, "true"), nil
+}
+
+// AddShare adds a music library share (ContentDirectory CreateObject in S:)
+// and returns its object ID, e.g. S://nas/music. path is a UNC path
+// (//nas/music, \\nas\music or smb://nas/music); the share must be readable
+// by the speakers without credentials. Speakers index it on the next rescan.
+func (c *Client) AddShare(ctx context.Context, path string) (string, error) {
+	p, err := normalizeSharePath(path)
+	if err != nil {
+		return "", err
+	}
+	id, err := c.CreateObject(ctx, "S:", BuildDIDL(DIDLItem{
+		ParentID:  "S:",
+		Container: true,
+		Title:     p,
+		Class:     "object.container",
+	}))
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		id = "S:" + p
+	}
+	return id, nil
+}
+
+// RemoveShare removes a music library share by object ID (S://nas/music)
+// with ContentDirectory DestroyObject.
+func (c *Client) RemoveShare(ctx context.Context, id string) error {
+	id = strings.TrimSpace(id)
+	if !strings.HasPrefix(id, "S:") || len(id) <= len("S:") {
+		return fmt.Errorf("not a music library share: %q", id)
+	}
+	return c.DestroyObject(ctx, id)
+}
+
+// normalizeSharePath turns \\host\share and smb://host/share into //host/share.
+func normalizeSharePath(path string) (string, error) {
+	p := strings.TrimSpace(path)
+	p = strings.ReplaceAll(p, `\`, "/")
+	if rest, ok := strings.CutPrefix(strings.ToLower(p), "smb:"); ok {
+		p = p[len(p)-len(rest):]
+	}
+	p = strings.TrimRight(p, "/")
+	host, share, ok := strings.Cut(strings.TrimPrefix(p, "//"), "/")
+	if !strings.HasPrefix(p, "//") || !ok || host == "" || share == "" {
+		return "", fmt.Errorf("invalid share path %q (use //host/share)", path)
+	}
+	return p, nil
+}
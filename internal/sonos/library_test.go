@@ -0,0 +1,172 @@
+package sonos
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func libraryBrowseResponse(start, total int) string {
+	id := strconv.Itoa(start + 1)
+	didl := `<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/">` +
+		`<container id="A:ALBUM/Album ` + id + `" parentID="A:ALBUM" restricted="true"><dc:title>Album ` + id + `</dc:title><upnp:class>object.container.album.musicAlbum</upnp:class>` +
+		`<res protocolInfo="x-rincon-playlist:*:*:*">x-rincon-playlist:RINCON_A1400#A:ALBUM/Album%20` + id + `</res></container></DIDL-Lite>`
+	return soapOK(urnContentDirectory, "Browse",
+		"<Result>"+xmlEscapeText(didl)+"</Result><NumberReturned>1</NumberReturned><TotalMatches>"+strconv.Itoa(total)+"</TotalMatches><UpdateID>1</UpdateID>")
+}
+
+func TestBrowseLibraryPages(t *testing.T) {
+	t.Parallel()
+
+	var objectIDs []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		body := readBody(t, r)
+		objectIDs = append(objectIDs, body[strings.Index(body, "<ObjectID>")+len("<ObjectID>"):strings.Index(body, "</ObjectID>")])
+		start, _ := strconv.Atoi(body[strings.Index(body, "<StartingIndex>")+len("<StartingIndex>") : strings.Index(body, "</StartingIndex>")])
+		return httpResponse(200, libraryBrowseResponse(start, 3)), nil
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+
+	page, err := c.BrowseLibrary(context.Background(), "A:ALBUM", 0, 0)
+	if err != nil {
+		t.Fatalf("BrowseLibrary: %v", err)
+	}
+	if len(page.Items) != 3 || page.TotalMatches != 3 || len(objectIDs) != 3 {
+		t.Fatalf("page=%+v calls=%d", page, len(objectIDs))
+	}
+	if page.Items[2].Item.Title != "Album 3" || !strings.Contains(page.Items[2].Meta, `id="A:ALBUM/Album 3"`) {
+		t.Fatalf("unexpected item: %+v", page.Items[2])
+	}
+
+	page, err = c.BrowseLibrary(context.Background(), "A:ALBUM", 0, 2)
+	if err != nil || len(page.Items) != 2 {
+		t.Fatalf("limited browse: %+v err=%v", page, err)
+	}
+}
+
+func TestSearchLibraryObjectID(t *testing.T) {
+	t.Parallel()
+
+	var body string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		body = readBody(t, r)
+		return httpResponse(200, libraryBrowseResponse(0, 1)), nil
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+
+	if _, err := c.SearchLibrary(context.Background(), "Artists", "Miles Davis", 0, 10); err != nil {
+		t.Fatalf("SearchLibrary: %v", err)
+	}
+	if !strings.Contains(body, "<ObjectID>A:ARTIST:Miles%20Davis</ObjectID>") {
+		t.Fatalf("unexpected request: %s", body)
+	}
+	if _, err := c.SearchLibrary(context.Background(), "shares", "x", 0, 10); err == nil {
+		t.Fatalf("expected shares to be rejected")
+	}
+	if _, err := c.SearchLibrary(context.Background(), "videos", "x", 0, 10); err == nil {
+		t.Fatalf("expected unknown category error")
+	}
+}
+
+func TestEnqueueLibraryItemUsesItemDIDL(t *testing.T) {
+	t.Parallel()
+
+	var enqueueBody string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		if !strings.Contains(action, "#AddURIToQueue") {
+			t.Fatalf("unexpected SOAPACTION: %q", action)
+		}
+		enqueueBody = readBody(t, r)
+		return httpResponse(200, soapOK(urnAVTransport, "AddURIToQueue", "<FirstTrackNumberEnqueued>5</FirstTrackNumberEnqueued>")), nil
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+
+	item := LibraryItem{
+		Item: DIDLItem{ID: "A:ALBUM/Kind of Blue", URI: "x-rincon-playlist:RINCON_A1400#A:ALBUM/Kind%20of%20Blue"},
+		Meta: `<DIDL-Lite><container id="A:ALBUM/Kind of Blue"></container></DIDL-Lite>`,
+	}
+	first, err := c.EnqueueLibraryItem(context.Background(), item, EnqueueOptions{})
+	if err != nil || first != 5 {
+		t.Fatalf("first=%d err=%v", first, err)
+	}
+	if !strings.Contains(enqueueBody, "x-rincon-playlist:RINCON_A1400#A:ALBUM/Kind%20of%20Blue") || !strings.Contains(enqueueBody, "&lt;container id=&#34;A:ALBUM/Kind of Blue&#34;") {
+		t.Fatalf("unexpected request: %s", enqueueBody)
+	}
+
+	if _, err := c.EnqueueLibraryItem(context.Background(), LibraryItem{Item: DIDLItem{ID: "S:"}}, EnqueueOptions{}); err == nil {
+		t.Fatalf("expected error for item without URI")
+	}
+}
+
+func TestShareIndexActions(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		action = strings.Trim(action[strings.LastIndex(action, "#")+1:], `"`)
+		calls = append(calls, action+" "+readBody(t, r))
+		if action == "GetShareIndexInProgress" {
+			return httpResponse(200, soapOK(urnContentDirectory, action, "<IsIndexing>1</IsIndexing>")), nil
+		}
+		return httpResponse(200, soapOK(urnContentDirectory, action, "")), nil
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+
+	if err := c.RefreshShareIndex(context.Background(), ""); err != nil {
+		t.Fatalf("RefreshShareIndex: %v", err)
+	}
+	indexing, err := c.ShareIndexInProgress(context.Background())
+	if err != nil || !indexing {
+		t.Fatalf("indexing=%v err=%v", indexing, err)
+	}
+	if len(calls) != 2 || !strings.HasPrefix(calls[0], "RefreshShareIndex ") || !strings.Contains(calls[0], "<AlbumArtistDisplayOption></AlbumArtistDisplayOption>") {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+}
+
+func TestAddAndRemoveShare(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		action = strings.Trim(action[strings.LastIndex(action, "#")+1:], `"`)
+		calls = append(calls, action+" "+readBody(t, r))
+		if action == "CreateObject" {
+			return httpResponse(200, soapOK(urnContentDirectory, action, "<ObjectID>S://nas/music</ObjectID><Result></Result>")), nil
+		}
+		return httpResponse(200, soapOK(urnContentDirectory, action, "")), nil
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+
+	id, err := c.AddShare(context.Background(), `\\nas\music\`)
+	if err != nil || id != "S://nas/music" {
+		t.Fatalf("AddShare = %q, %v", id, err)
+	}
+	if !strings.Contains(calls[0], "<ContainerID>S:</ContainerID>") || !strings.Contains(calls[0], "dc:title&gt;//nas/music&lt;/dc:title") {
+		t.Fatalf("unexpected CreateObject: %s", calls[0])
+	}
+	if err := c.RemoveShare(context.Background(), "S://nas/music"); err != nil {
+		t.Fatalf("RemoveShare: %v", err)
+	}
+	if len(calls) != 2 || !strings.Contains(calls[1], "<ObjectID>S://nas/music</ObjectID>") {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+
+	for _, bad := range []string{"nas/music", "//nas", "smb://", ""} {
+		if _, err := c.AddShare(context.Background(), bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+	if err := c.RemoveShare(context.Background(), "A:ALBUM"); err == nil {
+		t.Fatalf("expected error for non-share id")
+	}
+	if len(calls) != 2 {
+		t.Fatalf("invalid input reached the speaker: %v", calls)
+	}
+}
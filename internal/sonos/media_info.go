@@ -0,0 +1,95 @@
+package sonos
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+type MediaInfo struct {
+	NrTracks           int
+	MediaDuration      string
+	CurrentURI         string
+	CurrentURIMetaData string
+	NextURI            string
+	NextURIMetaData    string
+	PlayMedium         string
+	RecordMedium       string
+	WriteStatus        string
+}
+
+func (c *Client) GetMediaInfo(ctx context.Context) (MediaInfo, error) {
+	resp, err := c.soapCall(ctx, controlAVTransport, urnAVTransport, "GetMediaInfo", map[string]string{
+		"InstanceID": "0",
+	})
+	if err != nil {
+		return MediaInfo{}, err
+	}
+	nr, _ := strconv.Atoi(strings.TrimSpace(resp["NrTracks"]))
+	return MediaInfo{
+		NrTracks:           nr,
+		MediaDuration:      resp["MediaDuration"],
+		CurrentURI:         resp["CurrentURI"],
+		CurrentURIMetaData: resp["CurrentURIMetaData"],
+		NextURI:            resp["NextURI"],
+		NextURIMetaData:    resp["NextURIMetaData"],
+		PlayMedium:         resp["PlayMedium"],
+		RecordMedium:       resp["RecordMedium"],
+		WriteStatus:        resp["WriteStatus"],
+	}, nil
+}
+
+// Source describes what a group is playing from, derived from the transport's CurrentURI.
+type Source string
+
+const (
+	SourceNone           Source = "none"
+	SourceQueue          Source = "queue"
+	SourceLineIn         Source = "line_in"
+	SourceTV             Source = "tv"
+	SourceSpotifyConnect Source = "spotify_connect"
+	SourceAirPlay        Source = "airplay"
+	SourceRadio          Source = "radio"
+	SourceStream         Source = "stream"
+	SourceGrouped        Source = "grouped"
+	SourceUnknown        Source = "unknown"
+)
+
+// ClassifySource maps a transport CurrentURI (see GetMediaInfo) to a Source.
+func ClassifySource(uri string) Source {
+	uri = strings.TrimSpace(uri)
+	if uri == "" {
+		return SourceNone
+	}
+	lower := strings.ToLower(uri)
+	switch {
+	case strings.HasPrefix(lower, "x-rincon-queue:"):
+		return SourceQueue
+	case strings.HasPrefix(lower, "x-rincon-stream:"):
+		return SourceLineIn
+	case strings.HasPrefix(lower, "x-sonos-htastream:"):
+		return SourceTV
+	case strings.HasPrefix(lower, "x-sonos-vli:"):
+		// Virtual line-in: x-sonos-vli:RINCON_xxx:<n>,<protocol>:<id>
+		switch {
+		case strings.Contains(lower, ",spotify:"):
+			return SourceSpotifyConnect
+		case strings.Contains(lower, ",airplay:"):
+			return SourceAirPlay
+		}
+		return SourceUnknown
+	case strings.HasPrefix(lower, "x-rincon-mp3radio:"),
+		strings.HasPrefix(lower, "x-sonosapi-stream:"),
+		strings.HasPrefix(lower, "x-sonosapi-radio:"),
+		strings.HasPrefix(lower, "aac:"):
+		return SourceRadio
+	case strings.HasPrefix(lower, "x-sonosapi-hls:"),
+		strings.HasPrefix(lower, "x-sonosapi-hls-static:"),
+		strings.HasPrefix(lower, "http:"),
+		strings.HasPrefix(lower, "https:"):
+		return SourceStream
+	case strings.HasPrefix(lower, "x-rincon:"):
+		return SourceGrouped
+	}
+	return SourceUnknown
+}
@@ -0,0 +1,52 @@
+package sonos
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetMediaInfo(t *testing.T) {
+	t.Parallel()
+
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return httpResponse(200, `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><u:GetMediaInfoResponse xmlns:u="urn:schemas-upnp-org:service:AVTransport:1"><NrTracks>12</NrTracks><MediaDuration>NOT_IMPLEMENTED</MediaDuration><CurrentURI>x-rincon-queue:RINCON_ABC1400#0</CurrentURI><CurrentURIMetaData></CurrentURIMetaData><NextURI></NextURI><NextURIMetaData></NextURIMetaData><PlayMedium>NETWORK</PlayMedium><RecordMedium>NOT_IMPLEMENTED</RecordMedium><WriteStatus>NOT_IMPLEMENTED</WriteStatus></u:GetMediaInfoResponse></s:Body></s:Envelope>`), nil
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+
+	info, err := c.GetMediaInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetMediaInfo: %v", err)
+	}
+	if info.NrTracks != 12 || info.CurrentURI != "x-rincon-queue:RINCON_ABC1400#0" || info.PlayMedium != "NETWORK" {
+		t.Fatalf("unexpected media info: %+v", info)
+	}
+	if ClassifySource(info.CurrentURI) != SourceQueue {
+		t.Fatalf("expected queue source")
+	}
+}
+
+func TestClassifySource(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]Source{
+		"":                                       SourceNone,
+		"x-rincon-queue:RINCON_ABC1400#0":        SourceQueue,
+		"x-rincon-stream:RINCON_ABC1400":         SourceLineIn,
+		"x-sonos-htastream:RINCON_ABC1400:spdif": SourceTV,
+		"x-sonos-vli:RINCON_ABC1400:2,spotify:abcd":  SourceSpotifyConnect,
+		"x-sonos-vli:RINCON_ABC1400:1,airplay:1234":  SourceAirPlay,
+		"x-rincon-mp3radio://example.com/stream.mp3": SourceRadio,
+		"x-sonosapi-stream:s24896?sid=254&flags=32":  SourceRadio,
+		"x-sonosapi-hls:live%3a123?sid=204":          SourceStream,
+		"https://example.com/a.mp3":                  SourceStream,
+		"x-rincon:RINCON_COORD1400":                  SourceGrouped,
+		"x-file-cifs://nas/music/a.flac":             SourceUnknown,
+	}
+	for uri, want := range cases {
+		if got := ClassifySource(uri); got != want {
+			t.Fatalf("ClassifySource(%q) = %q, want %q", uri, got, want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package sonos
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PlayMode is the AVTransport CurrentPlayMode value. Sonos encodes shuffle and
+// repeat as a single enum, so toggling one has to preserve the other.
+type PlayMode string
+
+const (
+	PlayModeNormal           PlayMode = "NORMAL"
+	PlayModeRepeatAll        PlayMode = "REPEAT_ALL"
+	PlayModeRepeatOne        PlayMode = "REPEAT_ONE"
+	PlayModeShuffleNoRepeat  PlayMode = "SHUFFLE_NOREPEAT"
+	PlayModeShuffle          PlayMode = "SHUFFLE" // shuffle + repeat all
+	PlayModeShuffleRepeatOne PlayMode = "SHUFFLE_REPEAT_ONE"
+)
+
+type RepeatMode string
+
+const (
+	RepeatOff RepeatMode = "off"
+	RepeatAll RepeatMode = "all"
+	RepeatOne RepeatMode = "one"
+)
+
+func ParsePlayMode(s string) (PlayMode, error) {
+	m := PlayMode(strings.ToUpper(strings.TrimSpace(s)))
+	switch m {
+	case PlayModeNormal, PlayModeRepeatAll, PlayModeRepeatOne, PlayModeShuffleNoRepeat, PlayModeShuffle, PlayModeShuffleRepeatOne:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown play mode: %q", s)
+	}
+}
+
+func ParseRepeatMode(s string) (RepeatMode, error) {
+	switch RepeatMode(strings.ToLower(strings.TrimSpace(s))) {
+	case RepeatOff, "none", "":
+		return RepeatOff, nil
+	case RepeatAll:
+		return RepeatAll, nil
+	case RepeatOne:
+		return RepeatOne, nil
+	default:
+		return "", fmt.Errorf("unknown repeat mode: %q (expected off|all|one)", s)
+	}
+}
+
+// PlayModeFor combines shuffle and repeat into the Sonos play mode enum.
+func PlayModeFor(shuffle bool, repeat RepeatMode) PlayMode {
+	switch {
+	case shuffle && repeat == RepeatAll:
+		return PlayModeShuffle
+	case shuffle && repeat == RepeatOne:
+		return PlayModeShuffleRepeatOne
+	case shuffle:
+		return PlayModeShuffleNoRepeat
+	case repeat == RepeatAll:
+		return PlayModeRepeatAll
+	case repeat == RepeatOne:
+		return PlayModeRepeatOne
+	default:
+		return PlayModeNormal
+	}
+}
+
+func (m PlayMode) Shuffle() bool {
+	switch m {
+	case PlayModeShuffle, PlayModeShuffleNoRepeat, PlayModeShuffleRepeatOne:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m PlayMode) Repeat() RepeatMode {
+	switch m {
+	case PlayModeRepeatAll, PlayModeShuffle:
+		return RepeatAll
+	case PlayModeRepeatOne, PlayModeShuffleRepeatOne:
+		return RepeatOne
+	default:
+		return RepeatOff
+	}
+}
+
+func (m PlayMode) WithShuffle(shuffle bool) PlayMode {
+	return PlayModeFor(shuffle, m.Repeat())
+}
+
+func (m PlayMode) WithRepeat(repeat RepeatMode) PlayMode {
+	return PlayModeFor(m.Shuffle(), repeat)
+}
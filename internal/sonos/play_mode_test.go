@@ -0,0 +1,111 @@
+package sonos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlayModeShuffleRepeatRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		mode    PlayMode
+		shuffle bool
+		repeat  RepeatMode
+	}{
+		{PlayModeNormal, false, RepeatOff},
+		{PlayModeRepeatAll, false, RepeatAll},
+		{PlayModeRepeatOne, false, RepeatOne},
+		{PlayModeShuffleNoRepeat, true, RepeatOff},
+		{PlayModeShuffle, true, RepeatAll},
+		{PlayModeShuffleRepeatOne, true, RepeatOne},
+	}
+	for _, tc := range cases {
+		if tc.mode.Shuffle() != tc.shuffle || tc.mode.Repeat() != tc.repeat {
+			t.Fatalf("%s: shuffle=%v repeat=%s", tc.mode, tc.mode.Shuffle(), tc.mode.Repeat())
+		}
+		if got := PlayModeFor(tc.shuffle, tc.repeat); got != tc.mode {
+			t.Fatalf("PlayModeFor(%v, %s) = %s, want %s", tc.shuffle, tc.repeat, got, tc.mode)
+		}
+	}
+
+	if got := PlayModeRepeatOne.WithShuffle(true); got != PlayModeShuffleRepeatOne {
+		t.Fatalf("WithShuffle: %s", got)
+	}
+	if got := PlayModeShuffle.WithRepeat(RepeatOff); got != PlayModeShuffleNoRepeat {
+		t.Fatalf("WithRepeat: %s", got)
+	}
+	if _, err := ParsePlayMode("bogus"); err == nil {
+		t.Fatalf("expected error")
+	}
+	if m, err := ParsePlayMode(" shuffle_norepeat "); err != nil || m != PlayModeShuffleNoRepeat {
+		t.Fatalf("ParsePlayMode: %v %v", m, err)
+	}
+}
+
+func TestTransportSettingsAndCrossfade(t *testing.T) {
+	t.Parallel()
+
+	var gotPlayMode, gotCrossfade string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		body := readBody(t, r)
+		switch {
+		case strings.Contains(action, "#GetTransportSettings"):
+			return httpResponse(200, `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><u:GetTransportSettingsResponse xmlns:u="urn:schemas-upnp-org:service:AVTransport:1"><PlayMode>SHUFFLE</PlayMode><RecQualityMode>NOT_IMPLEMENTED</RecQualityMode></u:GetTransportSettingsResponse></s:Body></s:Envelope>`), nil
+		case strings.Contains(action, "#SetPlayMode"):
+			gotPlayMode = body
+			return httpResponse(200, `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><u:SetPlayModeResponse xmlns:u="urn:schemas-upnp-org:service:AVTransport:1"></u:SetPlayModeResponse></s:Body></s:Envelope>`), nil
+		case strings.Contains(action, "#GetCrossfadeMode"):
+			return httpResponse(200, `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><u:GetCrossfadeModeResponse xmlns:u="urn:schemas-upnp-org:service:AVTransport:1"><CrossfadeMode>1</CrossfadeMode></u:GetCrossfadeModeResponse></s:Body></s:Envelope>`), nil
+		case strings.Contains(action, "#SetCrossfadeMode"):
+			gotCrossfade = body
+			return httpResponse(200, `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><u:SetCrossfadeModeResponse xmlns:u="urn:schemas-upnp-org:service:AVTransport:1"></u:SetCrossfadeModeResponse></s:Body></s:Envelope>`), nil
+		default:
+			t.Fatalf("unexpected SOAPACTION: %q", action)
+			return nil, nil
+		}
+	})
+
+	c := &Client{
+		IP: "192.0.2.1",
+		HTTP: &http.Client{
+			Timeout:   time.Second,
+			Transport: rt,
+		},
+	}
+
+	ts, err := c.GetTransportSettings(context.Background())
+	if err != nil {
+		t.Fatalf("GetTransportSettings: %v", err)
+	}
+	if ts.PlayMode != PlayModeShuffle || ts.RecQualityMode != "NOT_IMPLEMENTED" {
+		t.Fatalf("unexpected settings: %+v", ts)
+	}
+	if err := c.SetPlayMode(context.Background(), PlayModeRepeatOne); err != nil {
+		t.Fatalf("SetPlayMode: %v", err)
+	}
+	if !strings.Contains(gotPlayMode, "<NewPlayMode>REPEAT_ONE</NewPlayMode>") {
+		t.Fatalf("SetPlayMode body: %s", gotPlayMode)
+	}
+	if err := c.SetPlayMode(context.Background(), PlayMode("LOOP")); err == nil {
+		t.Fatalf("expected error for invalid play mode")
+	}
+
+	on, err := c.GetCrossfadeMode(context.Background())
+	if err != nil {
+		t.Fatalf("GetCrossfadeMode: %v", err)
+	}
+	if !on {
+		t.Fatalf("expected crossfade on")
+	}
+	if err := c.SetCrossfadeMode(context.Background(), false); err != nil {
+		t.Fatalf("SetCrossfadeMode: %v", err)
+	}
+	if !strings.Contains(gotCrossfade, "<CrossfadeMode>0</CrossfadeMode>") {
+		t.Fatalf("SetCrossfadeMode body: %s", gotCrossfade)
+	}
+}
@@ -27,12 +27,42 @@ func BuildRadioMeta(title string) string {
 	}
 	// TuneIn service descriptor.
 	const tuneInService = "SA_RINCON65031_"
-	return `<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns:r="urn:schemas-rinconnetworks-com:metadata-1-0/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/">` +
-		`<item id="R:0/0/0" parentID="R:0/0" restricted="true">` +
-		`<dc:title>` + xmlEscapeText(title) + `</dc:title>` +
-		`<upnp:class>object.item.audioItem.audioBroadcast</upnp:class>` +
-		`<desc id="cdudn" nameSpace="urn:schemas-rinconnetworks-com:metadata-1-0/">` + tuneInService + `</desc>` +
-		`</item></DIDL-Lite>`
+	return BuildDIDL(DIDLItem{
+		ID:         "R:0/0/0",
+		ParentID:   "R:0/0",
+		Restricted: "true",
+		Title:      title,
+		Class:      "object.item.audioItem.audioBroadcast",
+		Desc:       []DIDLDesc{{ID: "cdudn", NameSpace: nsRincon, Value: tuneInService}},
+	})
+}
+
+// BuildTrackMeta builds DIDL metadata for a music track served over plain HTTP
+// (e.g. a local file). mimeType ends up in the res protocolInfo.
+func BuildTrackMeta(item DIDLItem, mimeType string) string {
+	title := strings.TrimSpace(item.Title)
+	if title == "" {
+		title = item.URI
+	}
+	if strings.TrimSpace(mimeType) == "" {
+		mimeType = "audio/mpeg"
+	}
+	id := item.ID
+	if id == "" {
+		id = "-1"
+	}
+	return BuildDIDL(DIDLItem{
+		ID:           id,
+		ParentID:     "-1",
+		Restricted:   "true",
+		Title:        title,
+		Artist:       item.Artist,
+		Album:        item.Album,
+		AlbumArtURI:  item.AlbumArtURI,
+		Class:        "object.item.audioItem.musicTrack",
+		URI:          item.URI,
+		ProtocolInfo: "http-get:*:" + mimeType + ":*",
+	})
 }
 
 func (c *Client) PlayURI(ctx context.Context, uri, meta string) error {
@@ -34,6 +34,88 @@ func TestBuildRadioMeta(t *testing.T) {
 	}
 }
 
+func TestBuildTrackMetaRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	in := DIDLItem{Title: "Rock & Roll", Artist: "Led Zeppelin", Album: "IV", URI: "http://192.168.1.5:8080/media/IV/02.mp3"}
+	meta := BuildTrackMeta(in, "audio/mpeg")
+	if !strings.Contains(meta, `protocolInfo="http-get:*:audio/mpeg:*"`) {
+		t.Fatalf("unexpected meta: %s", meta)
+	}
+	items, err := ParseDIDLItems(meta)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("parse: %v %v", items, err)
+	}
+	got := items[0]
+	if got.Title != in.Title || got.Artist != in.Artist || got.Album != in.Album || got.URI != in.URI || got.Class != "object.item.audioItem.musicTrack" {
+		t.Fatalf("unexpected item: %+v", got)
+	}
+}
+
+// The metadata builders used to be hand-written strings; speakers must keep
+// receiving exactly the same bytes now that they go through BuildDIDL.
+func TestMetaBuildersMatchHandBuiltDIDL(t *testing.T) {
+	t.Parallel()
+
+	const head = `<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns:r="urn:schemas-rinconnetworks-com:metadata-1-0/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/">`
+	cases := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "radio",
+			got:  BuildRadioMeta("Rock & <Roll> FM"),
+			want: head + `<item id="R:0/0/0" parentID="R:0/0" restricted="true">` +
+				`<dc:title>Rock &amp; &lt;Roll&gt; FM</dc:title>` +
+				`<upnp:class>object.item.audioItem.audioBroadcast</upnp:class>` +
+				`<desc id="cdudn" nameSpace="urn:schemas-rinconnetworks-com:metadata-1-0/">SA_RINCON65031_</desc>` +
+				`</item></DIDL-Lite>`,
+		},
+		{
+			name: "share",
+			got:  buildShareDIDL("10032020spotify%3atrack%3a6NmXV4o6bmp704aPGyTVVG", "Spotify \"Track\"", "object.item.audioItem.musicTrack", 2311),
+			want: head + `<item id="10032020spotify%3atrack%3a6NmXV4o6bmp704aPGyTVVG" parentID="-1" restricted="true">` +
+				`<dc:title>Spotify &#34;Track&#34;</dc:title>` +
+				`<upnp:class>object.item.audioItem.musicTrack</upnp:class>` +
+				`<desc id="cdudn" nameSpace="urn:schemas-rinconnetworks-com:metadata-1-0/">SA_RINCON2311_X_#Svc2311-0-Token</desc>` +
+				`</item></DIDL-Lite>`,
+		},
+		{
+			name: "track",
+			got: BuildTrackMeta(DIDLItem{
+				Title:       "Rock & Roll",
+				Artist:      "Led Zeppelin",
+				Album:       "IV",
+				AlbumArtURI: "http://192.168.1.5:8080/art?a=1&b=2",
+				URI:         "http://192.168.1.5:8080/media/IV/02%20Rock.mp3",
+			}, "audio/mpeg"),
+			want: head + `<item id="-1" parentID="-1" restricted="true">` +
+				`<dc:title>Rock &amp; Roll</dc:title>` +
+				`<dc:creator>Led Zeppelin</dc:creator>` +
+				`<upnp:album>IV</upnp:album>` +
+				`<upnp:albumArtURI>http://192.168.1.5:8080/art?a=1&amp;b=2</upnp:albumArtURI>` +
+				`<upnp:class>object.item.audioItem.musicTrack</upnp:class>` +
+				`<res protocolInfo="http-get:*:audio/mpeg:*">http://192.168.1.5:8080/media/IV/02%20Rock.mp3</res>` +
+				`</item></DIDL-Lite>`,
+		},
+		{
+			name: "track without tags",
+			got:  BuildTrackMeta(DIDLItem{URI: "http://192.168.1.5:8080/media/a.flac"}, "audio/flac"),
+			want: head + `<item id="-1" parentID="-1" restricted="true">` +
+				`<dc:title>http://192.168.1.5:8080/media/a.flac</dc:title>` +
+				`<upnp:class>object.item.audioItem.musicTrack</upnp:class>` +
+				`<res protocolInfo="http-get:*:audio/flac:*">http://192.168.1.5:8080/media/a.flac</res>` +
+				`</item></DIDL-Lite>`,
+		},
+	}
+	for _, tc := range cases {
+		if tc.got != tc.want {
+			t.Fatalf("%s:\n got %s\nwant %s", tc.name, tc.got, tc.want)
+		}
+	}
+}
+
 func containsAll(s string, subs []string) bool {
 	for _, sub := range subs {
 		if !strings.Contains(s, sub) {
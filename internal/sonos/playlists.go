@@ -0,0 +1,222 @@
+package sonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Sonos playlists are "saved queues" below the SQ: container.
+
+type PlaylistsPage struct {
+	Items          []DIDLItem `json:"items"`
+	NumberReturned int        `json:"numberReturned"`
+	TotalMatches   int        `json:"totalMatches"`
+	UpdateID       int        `json:"updateID"`
+}
+
+func (c *Client) ListPlaylists(ctx context.Context, start, count int) (PlaylistsPage, error) {
+	if start < 0 {
+		start = 0
+	}
+	if count <= 0 {
+		count = 100
+	}
+	br, err := c.Browse(ctx, "SQ:", start, count)
+	if err != nil {
+		return PlaylistsPage{}, err
+	}
+	items, err := ParseDIDLItems(br.Result)
+	if err != nil {
+		return PlaylistsPage{}, err
+	}
+	return PlaylistsPage{
+		Items:          items,
+		NumberReturned: br.NumberReturned,
+		TotalMatches:   br.TotalMatches,
+		UpdateID:       br.UpdateID,
+	}, nil
+}
+
+// ListPlaylistTracks lists the tracks of playlist id (e.g. "SQ:3"). Positions are 1-based.
+func (c *Client) ListPlaylistTracks(ctx context.Context, id string, start, count int) (QueuePage, error) {
+	if !isPlaylistID(id) {
+		return QueuePage{}, fmt.Errorf("invalid playlist id: %q", id)
+	}
+	if start < 0 {
+		start = 0
+	}
+	if count <= 0 {
+		count = 100
+	}
+	br, err := c.Browse(ctx, id, start, count)
+	if err != nil {
+		return QueuePage{}, err
+	}
+	didlItems, err := ParseDIDLItems(br.Result)
+	if err != nil {
+		return QueuePage{}, err
+	}
+	items := make([]QueueItem, 0, len(didlItems))
+	for i, it := range didlItems {
+		items = append(items, QueueItem{Position: start + i + 1, Item: it})
+	}
+	return QueuePage{
+		Items:          items,
+		NumberReturned: br.NumberReturned,
+		TotalMatches:   br.TotalMatches,
+		UpdateID:       br.UpdateID,
+	}, nil
+}
+
+// SaveQueue stores the current queue as a new playlist and returns its id.
+func (c *Client) SaveQueue(ctx context.Context, title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", errors.New("playlist title is required")
+	}
+	resp, err := c.soapCall(ctx, controlAVTransport, urnAVTransport, "SaveQueue", map[string]string{
+		"InstanceID": "0",
+		"Title":      title,
+		"ObjectID":   "",
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp["AssignedObjectID"], nil
+}
+
+// CreatePlaylist creates an empty playlist and returns its id.
+func (c *Client) CreatePlaylist(ctx context.Context, title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", errors.New("playlist title is required")
+	}
+	resp, err := c.soapCall(ctx, controlAVTransport, urnAVTransport, "CreateSavedQueue", map[string]string{
+		"InstanceID":          "0",
+		"Title":               title,
+		"EnqueuedURI":         "",
+		"EnqueuedURIMetaData": "",
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp["AssignedObjectID"], nil
+}
+
+func (c *Client) DeletePlaylist(ctx context.Context, id string) error {
+	if !isPlaylistID(id) {
+		return fmt.Errorf("invalid playlist id: %q", id)
+	}
+	return c.DestroyObject(ctx, id)
+}
+
+// AddURIToPlaylist appends uri to playlist id and returns the new playlist length.
+func (c *Client) AddURIToPlaylist(ctx context.Context, id, uri, meta string) (int, error) {
+	updateID, err := c.playlistUpdateID(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.soapCall(ctx, controlAVTransport, urnAVTransport, "AddURIToSavedQueue", map[string]string{
+		"InstanceID":          "0",
+		"ObjectID":            id,
+		"UpdateID":            strconv.Itoa(updateID),
+		"EnqueuedURI":         uri,
+		"EnqueuedURIMetaData": meta,
+		"AddAtIndex":          "4294967295", // append
+	})
+	if err != nil {
+		return 0, err
+	}
+	n, _ := strconv.Atoi(resp["NewQueueLength"])
+	return n, nil
+}
+
+// RemovePlaylistTracks removes the given 1-based positions from playlist id.
+func (c *Client) RemovePlaylistTracks(ctx context.Context, id string, positions []int) error {
+	if len(positions) == 0 {
+		return errors.New("no positions given")
+	}
+	sorted := append([]int(nil), positions...)
+	sort.Ints(sorted)
+	indices := make([]string, 0, len(sorted))
+	for i, p := range sorted {
+		if p <= 0 {
+			return fmt.Errorf("position must be >= 1")
+		}
+		if i > 0 && sorted[i-1] == p {
+			continue
+		}
+		indices = append(indices, strconv.Itoa(p-1))
+	}
+	return c.reorderSavedQueue(ctx, id, strings.Join(indices, ","), "")
+}
+
+// ReorderPlaylistTrack moves the track at 1-based position from to position to.
+func (c *Client) ReorderPlaylistTrack(ctx context.Context, id string, from, to int) error {
+	if from <= 0 || to <= 0 {
+		return fmt.Errorf("positions must be >= 1")
+	}
+	return c.reorderSavedQueue(ctx, id, strconv.Itoa(from-1), strconv.Itoa(to-1))
+}
+
+// reorderSavedQueue wraps ReorderTracksInSavedQueue. Tracks in trackList without
+// a matching entry in newPositions are removed.
+func (c *Client) reorderSavedQueue(ctx context.Context, id, trackList, newPositions string) error {
+	updateID, err := c.playlistUpdateID(ctx, id)
+	if err != nil {
+		return err
+	}
+	_, err = c.soapCall(ctx, controlAVTransport, urnAVTransport, "ReorderTracksInSavedQueue", map[string]string{
+		"InstanceID":      "0",
+		"ObjectID":        id,
+		"UpdateID":        strconv.Itoa(updateID),
+		"TrackList":       trackList,
+		"NewPositionList": newPositions,
+	})
+	return err
+}
+
+func (c *Client) playlistUpdateID(ctx context.Context, id string) (int, error) {
+	if !isPlaylistID(id) {
+		return 0, fmt.Errorf("invalid playlist id: %q", id)
+	}
+	br, err := c.Browse(ctx, id, 0, 1)
+	if err != nil {
+		return 0, err
+	}
+	return br.UpdateID, nil
+}
+
+// EnqueuePlaylist appends all tracks of playlist to the queue and returns the
+// first new queue position (1-based).
+func (c *Client) EnqueuePlaylist(ctx context.Context, playlist DIDLItem) (int, error) {
+	if !isPlaylistID(playlist.ID) {
+		return 0, fmt.Errorf("invalid playlist id: %q", playlist.ID)
+	}
+	uri := playlist.URI
+	if uri == "" {
+		uri = "file:///jffs/settings/savedqueues.rsq#" + strings.TrimPrefix(playlist.ID, "SQ:")
+	}
+	meta := BuildDIDL(DIDLItem{
+		ID:         playlist.ID,
+		ParentID:   "SQ:",
+		Restricted: "true",
+		Title:      playlist.Title,
+		Class:      "object.container.playlistContainer",
+		Desc:       []DIDLDesc{{ID: "cdudn", NameSpace: nsRincon, Value: "RINCON_AssociatedZPUDN"}},
+	})
+	return c.AddURIToQueue(ctx, uri, meta, 0, false)
+}
+
+func isPlaylistID(id string) bool {
+	n := strings.TrimPrefix(id, "SQ:")
+	if n == id || n == "" {
+		return false
+	}
+	_, err := strconv.Atoi(n)
+	return err == nil
+}
@@ -0,0 +1,80 @@
+package sonos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlaylistActions(t *testing.T) {
+	t.Parallel()
+
+	bodies := map[string][]string{}
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		action = strings.Trim(action[strings.LastIndex(action, "#")+1:], `"`)
+		bodies[action] = append(bodies[action], readBody(t, r))
+		switch action {
+		case "Browse":
+			return httpResponse(200, soapOK(urnContentDirectory, action, `<Result>&lt;DIDL-Lite xmlns:dc=&quot;http://purl.org/dc/elements/1.1/&quot; xmlns=&quot;urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/&quot;&gt;&lt;container id=&quot;SQ:3&quot; parentID=&quot;SQ:&quot; restricted=&quot;true&quot;&gt;&lt;dc:title&gt;Dinner&lt;/dc:title&gt;&lt;res&gt;file:///jffs/settings/savedqueues.rsq#3&lt;/res&gt;&lt;/container&gt;&lt;/DIDL-Lite&gt;</Result><NumberReturned>1</NumberReturned><TotalMatches>1</TotalMatches><UpdateID>17</UpdateID>`)), nil
+		case "SaveQueue", "CreateSavedQueue":
+			return httpResponse(200, soapOK(urnAVTransport, action, `<AssignedObjectID>SQ:9</AssignedObjectID>`)), nil
+		case "AddURIToSavedQueue":
+			return httpResponse(200, soapOK(urnAVTransport, action, `<NumTracksAdded>1</NumTracksAdded><NewQueueLength>4</NewQueueLength><NewUpdateID>18</NewUpdateID>`)), nil
+		case "AddURIToQueue":
+			return httpResponse(200, soapOK(urnAVTransport, action, `<FirstTrackNumberEnqueued>6</FirstTrackNumberEnqueued><NumTracksAdded>3</NumTracksAdded><NewQueueLength>8</NewQueueLength>`)), nil
+		case "DestroyObject":
+			return httpResponse(200, soapOK(urnContentDirectory, action, ``)), nil
+		default:
+			return httpResponse(200, soapOK(urnAVTransport, action, ``)), nil
+		}
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+	ctx := context.Background()
+
+	page, err := c.ListPlaylists(ctx, 0, 0)
+	if err != nil || len(page.Items) != 1 || page.Items[0].ID != "SQ:3" || page.Items[0].Title != "Dinner" {
+		t.Fatalf("ListPlaylists: %+v %v", page, err)
+	}
+	if id, err := c.SaveQueue(ctx, "Party"); err != nil || id != "SQ:9" {
+		t.Fatalf("SaveQueue: %q %v", id, err)
+	}
+	if id, err := c.CreatePlaylist(ctx, "Empty"); err != nil || id != "SQ:9" {
+		t.Fatalf("CreatePlaylist: %q %v", id, err)
+	}
+	if n, err := c.AddURIToPlaylist(ctx, "SQ:3", "http://example.com/a.mp3", ""); err != nil || n != 4 {
+		t.Fatalf("AddURIToPlaylist: %d %v", n, err)
+	}
+	if !strings.Contains(bodies["AddURIToSavedQueue"][0], "<UpdateID>17</UpdateID>") {
+		t.Fatalf("expected current update id: %s", bodies["AddURIToSavedQueue"][0])
+	}
+	if err := c.RemovePlaylistTracks(ctx, "SQ:3", []int{3, 1, 3}); err != nil {
+		t.Fatalf("RemovePlaylistTracks: %v", err)
+	}
+	if b := bodies["ReorderTracksInSavedQueue"][0]; !strings.Contains(b, "<TrackList>0,2</TrackList>") || !strings.Contains(b, "<NewPositionList></NewPositionList>") {
+		t.Fatalf("unexpected remove body: %s", b)
+	}
+	if err := c.ReorderPlaylistTrack(ctx, "SQ:3", 4, 1); err != nil {
+		t.Fatalf("ReorderPlaylistTrack: %v", err)
+	}
+	if b := bodies["ReorderTracksInSavedQueue"][1]; !strings.Contains(b, "<TrackList>3</TrackList>") || !strings.Contains(b, "<NewPositionList>0</NewPositionList>") {
+		t.Fatalf("unexpected reorder body: %s", b)
+	}
+	if first, err := c.EnqueuePlaylist(ctx, page.Items[0]); err != nil || first != 6 {
+		t.Fatalf("EnqueuePlaylist: %d %v", first, err)
+	}
+	if b := bodies["AddURIToQueue"][0]; !strings.Contains(b, "savedqueues.rsq#3") || !strings.Contains(b, "playlistContainer") {
+		t.Fatalf("unexpected enqueue body: %s", b)
+	}
+	if err := c.DeletePlaylist(ctx, "SQ:3"); err != nil {
+		t.Fatalf("DeletePlaylist: %v", err)
+	}
+	if !strings.Contains(bodies["DestroyObject"][0], "<ObjectID>SQ:3</ObjectID>") {
+		t.Fatalf("unexpected destroy body: %s", bodies["DestroyObject"][0])
+	}
+	if err := c.DeletePlaylist(ctx, "FV:2"); err == nil {
+		t.Fatalf("expected error for non-playlist id")
+	}
+}
@@ -3,6 +3,8 @@ package sonos
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 type QueueItem struct {
@@ -64,3 +66,147 @@ func (c *Client) PlayQueuePosition(ctx context.Context, position int) error {
 	}
 	return c.playFromQueueTrack(ctx, position)
 }
+
+// MoveQueueTracks moves count tracks starting at from so that the first of them
+// ends up at position to. All positions are 1-based.
+func (c *Client) MoveQueueTracks(ctx context.Context, from, count, to int) error {
+	if from <= 0 || to <= 0 {
+		return fmt.Errorf("position must be >= 1")
+	}
+	if count <= 0 {
+		return fmt.Errorf("count must be >= 1")
+	}
+	if from == to {
+		return nil
+	}
+	// InsertBefore refers to positions before the move.
+	insertBefore := to
+	if to > from {
+		insertBefore = to + count
+	}
+	_, err := c.soapCall(ctx, controlAVTransport, urnAVTransport, "ReorderTracksInQueue", map[string]string{
+		"InstanceID":     "0",
+		"StartingIndex":  strconv.Itoa(from),
+		"NumberOfTracks": strconv.Itoa(count),
+		"InsertBefore":   strconv.Itoa(insertBefore),
+		"UpdateID":       "0",
+	})
+	return err
+}
+
+// QueueEntry is a URI plus its DIDL metadata, as enqueued by AddMultipleURIsToQueue.
+type QueueEntry struct {
+	URI  string `json:"uri"`
+	Meta string `json:"meta,omitempty"`
+}
+
+// MaxURIsPerAdd is the largest batch AddMultipleURIsToQueue accepts.
+const MaxURIsPerAdd = 16
+
+// AddMultipleURIsToQueue enqueues up to MaxURIsPerAdd entries in one call and
+// returns the first new queue position (1-based) and the number of tracks added.
+func (c *Client) AddMultipleURIsToQueue(ctx context.Context, entries []QueueEntry, desiredFirstTrackNumber int, enqueueAsNext bool) (first, added int, err error) {
+	if len(entries) == 0 {
+		return 0, 0, nil
+	}
+	if len(entries) > MaxURIsPerAdd {
+		return 0, 0, fmt.Errorf("too many URIs in one batch: %d (max %d)", len(entries), MaxURIsPerAdd)
+	}
+	uris := make([]string, 0, len(entries))
+	metas := make([]string, 0, len(entries))
+	for _, e := range entries {
+		// The lists are space-separated, so spaces inside a URI must be escaped.
+		uris = append(uris, strings.ReplaceAll(strings.TrimSpace(e.URI), " ", "%20"))
+		metas = append(metas, e.Meta)
+	}
+	asNext := "0"
+	if enqueueAsNext {
+		asNext = "1"
+	}
+	resp, err := c.soapCall(ctx, controlAVTransport, urnAVTransport, "AddMultipleURIsToQueue", map[string]string{
+		"InstanceID":                      "0",
+		"UpdateID":                        "0",
+		"NumberOfURIs":                    strconv.Itoa(len(entries)),
+		"EnqueuedURIs":                    strings.Join(uris, " "),
+		"EnqueuedURIsMetaData":            strings.Join(metas, " "),
+		"ContainerURI":                    "",
+		"ContainerMetaData":               "",
+		"DesiredFirstTrackNumberEnqueued": strconv.Itoa(desiredFirstTrackNumber),
+		"EnqueueAsNext":                   asNext,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	if v := resp["FirstTrackNumberEnqueued"]; v != "" {
+		if first, err = strconv.Atoi(v); err != nil {
+			return 0, 0, err
+		}
+	}
+	if v := resp["NumTracksAdded"]; v != "" {
+		if added, err = strconv.Atoi(v); err != nil {
+			return 0, 0, err
+		}
+	}
+	return first, added, nil
+}
+
+// EnqueueURIs appends entries to the end of the queue in batches of
+// MaxURIsPerAdd. It returns the first new queue position (1-based) and the
+// total number of tracks added.
+func (c *Client) EnqueueURIs(ctx context.Context, entries []QueueEntry) (first, added int, err error) {
+	for start := 0; start < len(entries); start += MaxURIsPerAdd {
+		end := start + MaxURIsPerAdd
+		if end > len(entries) {
+			end = len(entries)
+		}
+		n, k, err := c.AddMultipleURIsToQueue(ctx, entries[start:end], 0, false)
+		if err != nil {
+			return first, added, fmt.Errorf("enqueue batch %d-%d: %w", start+1, end, err)
+		}
+		if first == 0 {
+			first = n
+		}
+		added += k
+	}
+	return first, added, nil
+}
+
+// QueueTrack is a queue entry together with the DIDL needed to enqueue it
+// again (including service descriptors such as cdudn).
+type QueueTrack struct {
+	Position int      `json:"position"` // 1-based
+	Item     DIDLItem `json:"item"`
+	Meta     string   `json:"meta,omitempty"`
+}
+
+// ListQueueTracks walks all pages of the queue.
+func (c *Client) ListQueueTracks(ctx context.Context) ([]QueueTrack, error) {
+	const pageSize = 100
+	var out []QueueTrack
+	start := 0
+	for {
+		br, err := c.Browse(ctx, "Q:0", start, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		items, err := ParseDIDLItems(br.Result)
+		if err != nil {
+			return nil, err
+		}
+		metas, err := SplitDIDLItems(br.Result)
+		if err != nil {
+			return nil, err
+		}
+		for i, it := range items {
+			t := QueueTrack{Position: start + i + 1, Item: it}
+			if i < len(metas) {
+				t.Meta = metas[i]
+			}
+			out = append(out, t)
+		}
+		start += br.NumberReturned
+		if br.NumberReturned == 0 || start >= br.TotalMatches {
+			return out, nil
+		}
+	}
+}
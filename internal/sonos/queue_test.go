@@ -3,6 +3,7 @@ package sonos
 import (
 	"context"
 	"net/http"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -86,3 +87,123 @@ func TestQueueListAndActions(t *testing.T) {
 	// Not asserting network body here (covered by playFromQueueTrack tests).
 	_ = c.PlayQueuePosition(context.Background(), 1)
 }
+
+func TestMoveQueueTracksInsertBefore(t *testing.T) {
+	t.Parallel()
+
+	var bodies []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if !strings.Contains(r.Header.Get("SOAPACTION"), "#ReorderTracksInQueue") {
+			t.Fatalf("unexpected SOAPACTION: %q", r.Header.Get("SOAPACTION"))
+		}
+		bodies = append(bodies, readBody(t, r))
+		return httpResponse(200, soapOK(urnAVTransport, "ReorderTracksInQueue", "")), nil
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+	ctx := context.Background()
+
+	cases := []struct {
+		from, count, to int
+		insertBefore    int
+	}{
+		{5, 1, 2, 2},
+		{2, 1, 5, 6},
+		{1, 3, 4, 7},
+	}
+	for _, tc := range cases {
+		if err := c.MoveQueueTracks(ctx, tc.from, tc.count, tc.to); err != nil {
+			t.Fatalf("MoveQueueTracks(%d,%d,%d): %v", tc.from, tc.count, tc.to, err)
+		}
+		got := bodies[len(bodies)-1]
+		for _, want := range []string{
+			"<StartingIndex>" + strconv.Itoa(tc.from) + "</StartingIndex>",
+			"<NumberOfTracks>" + strconv.Itoa(tc.count) + "</NumberOfTracks>",
+			"<InsertBefore>" + strconv.Itoa(tc.insertBefore) + "</InsertBefore>",
+		} {
+			if !strings.Contains(got, want) {
+				t.Fatalf("MoveQueueTracks(%d,%d,%d) body missing %q: %s", tc.from, tc.count, tc.to, want, got)
+			}
+		}
+	}
+
+	if err := c.MoveQueueTracks(ctx, 3, 1, 3); err != nil || len(bodies) != len(cases) {
+		t.Fatalf("expected no-op move, err=%v calls=%d", err, len(bodies))
+	}
+	if err := c.MoveQueueTracks(ctx, 0, 1, 3); err == nil {
+		t.Fatalf("expected error for position 0")
+	}
+}
+
+func TestEnqueueURIsBatches(t *testing.T) {
+	t.Parallel()
+
+	var counts []string
+	next := 4
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if !strings.Contains(r.Header.Get("SOAPACTION"), "#AddMultipleURIsToQueue") {
+			t.Fatalf("unexpected SOAPACTION: %q", r.Header.Get("SOAPACTION"))
+		}
+		body := readBody(t, r)
+		n := body[strings.Index(body, "<NumberOfURIs>")+len("<NumberOfURIs>") : strings.Index(body, "</NumberOfURIs>")]
+		counts = append(counts, n)
+		if len(counts) == 1 && !strings.Contains(body, "<EnqueuedURIs>http://h/a%20b.mp3 http://h/1.mp3") {
+			t.Fatalf("unexpected EnqueuedURIs: %s", body)
+		}
+		first := next
+		added := 16
+		if n != "16" {
+			added = 2
+		}
+		next += added
+		return httpResponse(200, soapOK(urnAVTransport, "AddMultipleURIsToQueue",
+			"<FirstTrackNumberEnqueued>"+strconv.Itoa(first)+"</FirstTrackNumberEnqueued><NumTracksAdded>"+strconv.Itoa(added)+"</NumTracksAdded><NewQueueLength>"+strconv.Itoa(next-1)+"</NewQueueLength>")), nil
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+
+	entries := []QueueEntry{{URI: "http://h/a b.mp3"}}
+	for i := 1; i < 18; i++ {
+		entries = append(entries, QueueEntry{URI: "http://h/" + strconv.Itoa(i) + ".mp3"})
+	}
+	first, added, err := c.EnqueueURIs(context.Background(), entries)
+	if err != nil {
+		t.Fatalf("EnqueueURIs: %v", err)
+	}
+	if first != 4 || added != 18 {
+		t.Fatalf("first=%d added=%d", first, added)
+	}
+	if strings.Join(counts, ",") != "16,2" {
+		t.Fatalf("batches = %v", counts)
+	}
+}
+
+func TestListQueueTracksPagesAndKeepsMeta(t *testing.T) {
+	t.Parallel()
+
+	var starts []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		body := readBody(t, r)
+		start := body[strings.Index(body, "<StartingIndex>")+len("<StartingIndex>") : strings.Index(body, "</StartingIndex>")]
+		starts = append(starts, start)
+		n, _ := strconv.Atoi(start)
+		id := strconv.Itoa(n + 1)
+		didl := `<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/">` +
+			`<item id="Q:0/` + id + `"><dc:title>T` + id + `</dc:title><res>x-file-cifs://nas/` + id + `.mp3</res><desc id="cdudn">RINCON_AssociatedZPUDN</desc></item></DIDL-Lite>`
+		return httpResponse(200, soapOK(urnContentDirectory, "Browse",
+			"<Result>"+xmlEscapeText(didl)+"</Result><NumberReturned>1</NumberReturned><TotalMatches>2</TotalMatches><UpdateID>7</UpdateID>")), nil
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+
+	tracks, err := c.ListQueueTracks(context.Background())
+	if err != nil {
+		t.Fatalf("ListQueueTracks: %v", err)
+	}
+	if len(tracks) != 2 || strings.Join(starts, ",") != "0,1" {
+		t.Fatalf("tracks=%+v starts=%v", tracks, starts)
+	}
+	if tracks[1].Position != 2 || tracks[1].Item.URI != "x-file-cifs://nas/2.mp3" {
+		t.Fatalf("unexpected track: %+v", tracks[1])
+	}
+	if !strings.Contains(tracks[0].Meta, "RINCON_AssociatedZPUDN") || !strings.HasPrefix(tracks[0].Meta, "<DIDL-Lite") {
+		t.Fatalf("unexpected meta: %s", tracks[0].Meta)
+	}
+}
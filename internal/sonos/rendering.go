@@ -3,6 +3,7 @@ package sonos
 import (
 	"context"
 	"strconv"
+	"time"
 )
 
 func (c *Client) GetVolume(ctx context.Context) (int, error) {
@@ -55,3 +56,48 @@ func (c *Client) SetMute(ctx context.Context, mute bool) error {
 	})
 	return err
 }
+
+// SetRelativeVolume changes the volume by adjustment (may be negative) and
+// returns the new volume.
+func (c *Client) SetRelativeVolume(ctx context.Context, adjustment int) (int, error) {
+	resp, err := c.soapCall(ctx, controlRenderingControl, urnRenderingControl, "SetRelativeVolume", map[string]string{
+		"InstanceID": "0",
+		"Channel":    "Master",
+		"Adjustment": strconv.Itoa(adjustment),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(resp["NewVolume"])
+}
+
+// Ramp types understood by RampToVolume. The speaker picks the ramp speed.
+const (
+	RampTypeSleepTimer = "SLEEP_TIMER_RAMP_TYPE"
+	RampTypeAlarm      = "ALARM_RAMP_TYPE"
+	RampTypeAutoplay   = "AUTOPLAY_RAMP_TYPE"
+)
+
+// RampToVolume lets the speaker ramp to volume and returns how long the ramp
+// takes (as reported by the speaker).
+func (c *Client) RampToVolume(ctx context.Context, rampType string, volume int) (time.Duration, error) {
+	if volume < 0 {
+		volume = 0
+	}
+	if volume > 100 {
+		volume = 100
+	}
+	resp, err := c.soapCall(ctx, controlRenderingControl, urnRenderingControl, "RampToVolume", map[string]string{
+		"InstanceID":       "0",
+		"Channel":          "Master",
+		"RampType":         rampType,
+		"DesiredVolume":    strconv.Itoa(volume),
+		"ResetVolumeAfter": "0",
+		"ProgramURI":       "",
+	})
+	if err != nil {
+		return 0, err
+	}
+	secs, _ := strconv.Atoi(resp["RampTime"])
+	return time.Duration(secs) * time.Second, nil
+}
@@ -58,3 +58,69 @@ func TestRenderingVolumeAndMute(t *testing.T) {
 		t.Fatalf("SetMute: %v", err)
 	}
 }
+
+func TestRenderingRelativeVolumeAndRamp(t *testing.T) {
+	t.Parallel()
+
+	var bodies []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		bodies = append(bodies, readBody(t, r))
+		switch {
+		case strings.Contains(action, "RenderingControl:1#SetRelativeVolume"):
+			return httpResponse(200, soapOK(urnRenderingControl, "SetRelativeVolume", `<NewVolume>30</NewVolume>`)), nil
+		case strings.Contains(action, "RenderingControl:1#RampToVolume"):
+			return httpResponse(200, soapOK(urnRenderingControl, "RampToVolume", `<RampTime>12</RampTime>`)), nil
+		case strings.Contains(action, "GroupRenderingControl:1#SnapshotGroupVolume"):
+			return httpResponse(200, soapOK(urnGroupRenderingControl, "SnapshotGroupVolume", ``)), nil
+		case strings.Contains(action, "GroupRenderingControl:1#SetRelativeGroupVolume"):
+			return httpResponse(200, soapOK(urnGroupRenderingControl, "SetRelativeGroupVolume", `<NewVolume>18</NewVolume>`)), nil
+		default:
+			t.Fatalf("unexpected SOAPACTION: %q", action)
+			return nil, nil
+		}
+	})
+
+	c := &Client{
+		IP: "192.0.2.1",
+		HTTP: &http.Client{
+			Timeout:   time.Second,
+			Transport: rt,
+		},
+	}
+
+	v, err := c.SetRelativeVolume(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("SetRelativeVolume: %v", err)
+	}
+	if v != 30 {
+		t.Fatalf("new volume: %d", v)
+	}
+	if !strings.Contains(bodies[0], "<Adjustment>5</Adjustment>") || !strings.Contains(bodies[0], "<Channel>Master</Channel>") {
+		t.Fatalf("unexpected SetRelativeVolume body: %s", bodies[0])
+	}
+
+	d, err := c.RampToVolume(context.Background(), RampTypeAlarm, 140)
+	if err != nil {
+		t.Fatalf("RampToVolume: %v", err)
+	}
+	if d != 12*time.Second {
+		t.Fatalf("ramp time: %s", d)
+	}
+	for _, want := range []string{"<RampType>ALARM_RAMP_TYPE</RampType>", "<DesiredVolume>100</DesiredVolume>", "<ResetVolumeAfter>0</ResetVolumeAfter>"} {
+		if !strings.Contains(bodies[1], want) {
+			t.Fatalf("RampToVolume body missing %s: %s", want, bodies[1])
+		}
+	}
+
+	v, err = c.SetRelativeGroupVolume(context.Background(), -7)
+	if err != nil {
+		t.Fatalf("SetRelativeGroupVolume: %v", err)
+	}
+	if v != 18 {
+		t.Fatalf("new group volume: %d", v)
+	}
+	if len(bodies) != 4 || !strings.Contains(bodies[3], "<Adjustment>-7</Adjustment>") {
+		t.Fatalf("unexpected group calls: %v", bodies)
+	}
+}
@@ -9,6 +9,8 @@ const (
 	controlMusicServices     = "/MusicServices/Control"
 	controlDeviceProperties  = "/DeviceProperties/Control"
 	controlSystemProperties  = "/SystemProperties/Control"
+	controlAlarmClock        = "/AlarmClock/Control"
+	controlAudioIn           = "/AudioIn/Control"
 	eventAVTransport         = "/MediaRenderer/AVTransport/Event"
 	eventRenderingControl    = "/MediaRenderer/RenderingControl/Event"
 	urnAVTransport           = "urn:schemas-upnp-org:service:AVTransport:1"
@@ -19,4 +21,6 @@ const (
 	urnMusicServices         = "urn:schemas-upnp-org:service:MusicServices:1"
 	urnDeviceProperties      = "urn:schemas-upnp-org:service:DeviceProperties:1"
 	urnSystemProperties      = "urn:schemas-upnp-org:service:SystemProperties:1"
+	urnAlarmClock            = "urn:schemas-upnp-org:service:AlarmClock:1"
+	urnAudioIn               = "urn:schemas-upnp-org:service:AudioIn:1"
 )
@@ -0,0 +1,70 @@
+package sonos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSleepTimer(t *testing.T) {
+	t.Parallel()
+
+	remaining := "0:29:53"
+	var bodies []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		switch {
+		case strings.Contains(action, "#ConfigureSleepTimer"):
+			bodies = append(bodies, readBody(t, r))
+			return httpResponse(200, `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><u:ConfigureSleepTimerResponse xmlns:u="urn:schemas-upnp-org:service:AVTransport:1"></u:ConfigureSleepTimerResponse></s:Body></s:Envelope>`), nil
+		case strings.Contains(action, "#GetRemainingSleepTimerDuration"):
+			return httpResponse(200, `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><u:GetRemainingSleepTimerDurationResponse xmlns:u="urn:schemas-upnp-org:service:AVTransport:1"><RemainingSleepTimerDuration>`+remaining+`</RemainingSleepTimerDuration><CurrentSleepTimerGeneration>3</CurrentSleepTimerGeneration></u:GetRemainingSleepTimerDurationResponse></s:Body></s:Envelope>`), nil
+		default:
+			t.Fatalf("unexpected SOAPACTION: %q", action)
+			return nil, nil
+		}
+	})
+
+	c := &Client{
+		IP: "192.0.2.1",
+		HTTP: &http.Client{
+			Timeout:   time.Second,
+			Transport: rt,
+		},
+	}
+
+	if err := c.ConfigureSleepTimer(context.Background(), 30*time.Minute); err != nil {
+		t.Fatalf("ConfigureSleepTimer: %v", err)
+	}
+	if err := c.ConfigureSleepTimer(context.Background(), 0); err != nil {
+		t.Fatalf("ConfigureSleepTimer(0): %v", err)
+	}
+	if err := c.ConfigureSleepTimer(context.Background(), 25*time.Hour); err == nil {
+		t.Fatalf("expected error for >24h")
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("unexpected calls: %d", len(bodies))
+	}
+	if !strings.Contains(bodies[0], "<NewSleepTimerDuration>00:30:00</NewSleepTimerDuration>") {
+		t.Fatalf("set body: %s", bodies[0])
+	}
+	if !strings.Contains(bodies[1], "<NewSleepTimerDuration></NewSleepTimerDuration>") {
+		t.Fatalf("cancel body: %s", bodies[1])
+	}
+
+	d, err := c.GetRemainingSleepTimerDuration(context.Background())
+	if err != nil {
+		t.Fatalf("GetRemainingSleepTimerDuration: %v", err)
+	}
+	if d != 29*time.Minute+53*time.Second {
+		t.Fatalf("remaining: %s", d)
+	}
+
+	remaining = ""
+	d, err = c.GetRemainingSleepTimerDuration(context.Background())
+	if err != nil || d != 0 {
+		t.Fatalf("expected no timer, got %s err=%v", d, err)
+	}
+}
@@ -0,0 +1,171 @@
+package sonos
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Snapshot captures what a group is playing (source, position, play mode) plus
+// every visible member's volume and mute, so it can be put back later.
+type Snapshot struct {
+	CoordinatorUUID string           `json:"coordinatorUUID"`
+	CoordinatorName string           `json:"coordinatorName,omitempty"`
+	CoordinatorIP   string           `json:"coordinatorIP"`
+	URI             string           `json:"uri"`
+	Metadata        string           `json:"metadata,omitempty"`
+	Source          Source           `json:"source"`
+	Track           int              `json:"track,omitempty"` // 1-based queue position
+	RelTime         string           `json:"relTime,omitempty"`
+	TransportState  string           `json:"transportState"`
+	PlayMode        PlayMode         `json:"playMode,omitempty"`
+	Members         []SnapshotMember `json:"members"`
+}
+
+type SnapshotMember struct {
+	UUID   string `json:"uuid"`
+	Name   string `json:"name,omitempty"`
+	IP     string `json:"ip"`
+	Volume int    `json:"volume"`
+	Mute   bool   `json:"mute"`
+}
+
+// WasPlaying reports whether the group was playing when the snapshot was taken.
+func (s Snapshot) WasPlaying() bool {
+	return s.TransportState == "PLAYING" || s.TransportState == "TRANSITIONING"
+}
+
+// TakeSnapshot captures the state of group. c must talk to the group's coordinator.
+func (c *Client) TakeSnapshot(ctx context.Context, group Group) (Snapshot, error) {
+	media, err := c.GetMediaInfo(ctx)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	transport, err := c.GetTransportInfo(ctx)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	pos, err := c.GetPositionInfo(ctx)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	// Not every source reports transport settings (e.g. TV input).
+	settings, _ := c.GetTransportSettings(ctx)
+
+	snap := Snapshot{
+		CoordinatorUUID: group.Coordinator.UUID,
+		CoordinatorName: group.Coordinator.Name,
+		CoordinatorIP:   c.IP,
+		URI:             media.CurrentURI,
+		Metadata:        media.CurrentURIMetaData,
+		Source:          ClassifySource(media.CurrentURI),
+		TransportState:  transport.State,
+		PlayMode:        settings.PlayMode,
+	}
+	if snap.Source == SourceQueue {
+		snap.Track, _ = strconv.Atoi(strings.TrimSpace(pos.Track))
+		snap.RelTime = pos.RelTime
+	}
+
+	for _, m := range group.Members {
+		// Bonded satellites/subs follow their room; only visible zones have their own volume.
+		if !m.IsVisible || m.IP == "" {
+			continue
+		}
+		mc := c.forIP(m.IP)
+		vol, err := mc.GetVolume(ctx)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		mute, err := mc.GetMute(ctx)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		snap.Members = append(snap.Members, SnapshotMember{
+			UUID:   m.UUID,
+			Name:   m.Name,
+			IP:     m.IP,
+			Volume: vol,
+			Mute:   mute,
+		})
+	}
+	return snap, nil
+}
+
+// Restore puts the snapshot back: source, queue position, elapsed time, play mode,
+// member volume/mute, and resumes playback only if the group was playing.
+// Grouping itself is not changed (use scenes for that). c must talk to the
+// group's current coordinator.
+func (s Snapshot) Restore(ctx context.Context, c *Client) error {
+	if strings.TrimSpace(s.URI) != "" {
+		if err := c.SetAVTransportURI(ctx, s.URI, s.Metadata); err != nil {
+			return err
+		}
+	}
+	if s.Source == SourceQueue && s.Track > 0 {
+		if err := c.SeekTrackNumber(ctx, s.Track); err != nil {
+			return err
+		}
+		if d, err := ParseHHMMSS(s.RelTime); err == nil && d > time.Second {
+			if err := c.SeekTo(ctx, d); err != nil && !errors.Is(err, ErrSeekNotSupported) {
+				return err
+			}
+		}
+	}
+	if s.PlayMode != "" && s.Source == SourceQueue {
+		if err := c.SetPlayMode(ctx, s.PlayMode); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range s.Members {
+		if m.IP == "" {
+			continue
+		}
+		mc := c.forIP(m.IP)
+		if err := mc.SetMute(ctx, m.Mute); err != nil {
+			return err
+		}
+		if err := mc.SetVolume(ctx, m.Volume); err != nil {
+			return err
+		}
+	}
+
+	if s.WasPlaying() {
+		return c.Play(ctx)
+	}
+	return nil
+}
+
+// UpdateIPs replaces stored speaker IPs with the ones from top (matched by UUID),
+// since DHCP leases may have changed since the snapshot was taken.
+func (s Snapshot) UpdateIPs(top Topology) Snapshot {
+	ipByUUID := map[string]string{}
+	for _, m := range top.ByIP {
+		if m.UUID != "" && m.IP != "" {
+			ipByUUID[m.UUID] = m.IP
+		}
+	}
+	if ip := ipByUUID[s.CoordinatorUUID]; ip != "" {
+		s.CoordinatorIP = ip
+	}
+	members := make([]SnapshotMember, len(s.Members))
+	for i, m := range s.Members {
+		if ip := ipByUUID[m.UUID]; ip != "" {
+			m.IP = ip
+		}
+		members[i] = m
+	}
+	s.Members = members
+	return s
+}
+
+// forIP returns a client for another speaker that shares c's HTTP settings.
+func (c *Client) forIP(ip string) *Client {
+	if ip == c.IP {
+		return c
+	}
+	return &Client{IP: ip, Port: c.Port, HTTP: c.HTTP}
+}
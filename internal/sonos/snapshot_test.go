@@ -0,0 +1,122 @@
+package sonos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func soapOK(urn, action, inner string) string {
+	return `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body>` +
+		`<u:` + action + `Response xmlns:u="` + urn + `">` + inner + `</u:` + action + `Response></s:Body></s:Envelope>`
+}
+
+func TestSnapshotTakeAndRestore(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var calls []string
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		action := r.Header.Get("SOAPACTION")
+		action = strings.Trim(action[strings.LastIndex(action, "#")+1:], `"`)
+		body := readBody(t, r)
+		mu.Lock()
+		calls = append(calls, r.URL.Host+" "+action+" "+body)
+		mu.Unlock()
+
+		volume := "30"
+		if strings.HasPrefix(r.URL.Host, "192.0.2.2") {
+			volume = "12"
+		}
+		switch action {
+		case "GetMediaInfo":
+			return httpResponse(200, soapOK(urnAVTransport, action, `<NrTracks>5</NrTracks><CurrentURI>x-rincon-queue:RINCON_A1400#0</CurrentURI><CurrentURIMetaData></CurrentURIMetaData>`)), nil
+		case "GetTransportInfo":
+			return httpResponse(200, soapOK(urnAVTransport, action, `<CurrentTransportState>PLAYING</CurrentTransportState><CurrentTransportStatus>OK</CurrentTransportStatus><CurrentSpeed>1</CurrentSpeed>`)), nil
+		case "GetPositionInfo":
+			return httpResponse(200, soapOK(urnAVTransport, action, `<Track>3</Track><TrackDuration>0:04:00</TrackDuration><RelTime>0:01:10</RelTime>`)), nil
+		case "GetTransportSettings":
+			return httpResponse(200, soapOK(urnAVTransport, action, `<PlayMode>SHUFFLE</PlayMode><RecQualityMode>NOT_IMPLEMENTED</RecQualityMode>`)), nil
+		case "GetVolume":
+			return httpResponse(200, soapOK(urnRenderingControl, action, `<CurrentVolume>`+volume+`</CurrentVolume>`)), nil
+		case "GetMute":
+			return httpResponse(200, soapOK(urnRenderingControl, action, `<CurrentMute>0</CurrentMute>`)), nil
+		case "SetVolume", "SetMute":
+			return httpResponse(200, soapOK(urnRenderingControl, action, ``)), nil
+		default:
+			return httpResponse(200, soapOK(urnAVTransport, action, ``)), nil
+		}
+	})
+	c := &Client{IP: "192.0.2.1", HTTP: &http.Client{Timeout: time.Second, Transport: rt}}
+
+	group := Group{
+		Coordinator: Member{Name: "Kitchen", IP: "192.0.2.1", UUID: "RINCON_A1400", IsVisible: true},
+		Members: []Member{
+			{Name: "Kitchen", IP: "192.0.2.1", UUID: "RINCON_A1400", IsVisible: true},
+			{Name: "Dining", IP: "192.0.2.2", UUID: "RINCON_B1400", IsVisible: true},
+			{Name: "Sub", IP: "192.0.2.3", UUID: "RINCON_C1400", IsVisible: false},
+		},
+	}
+	snap, err := c.TakeSnapshot(context.Background(), group)
+	if err != nil {
+		t.Fatalf("TakeSnapshot: %v", err)
+	}
+	if snap.Source != SourceQueue || snap.Track != 3 || snap.RelTime != "0:01:10" || snap.PlayMode != PlayModeShuffle || !snap.WasPlaying() {
+		t.Fatalf("unexpected snapshot: %+v", snap)
+	}
+	if len(snap.Members) != 2 || snap.Members[1].Volume != 12 {
+		t.Fatalf("unexpected members: %+v", snap.Members)
+	}
+
+	mu.Lock()
+	calls = nil
+	mu.Unlock()
+	if err := snap.Restore(context.Background(), c); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+
+	var seq []string
+	for _, call := range calls {
+		parts := strings.SplitN(call, " ", 3)
+		seq = append(seq, parts[1])
+	}
+	want := "SetAVTransportURI,Seek,Seek,SetPlayMode,SetMute,SetVolume,SetMute,SetVolume,Play"
+	if got := strings.Join(seq, ","); got != want {
+		t.Fatalf("unexpected restore sequence:\n got %s\nwant %s", got, want)
+	}
+	if !strings.Contains(calls[1], "<Target>3</Target>") {
+		t.Fatalf("unexpected track seek: %s", calls[1])
+	}
+	if !strings.Contains(calls[2], "<Target>00:01:10</Target>") {
+		t.Fatalf("unexpected time seek: %s", calls[2])
+	}
+	if !strings.HasPrefix(calls[7], "192.0.2.2") || !strings.Contains(calls[7], "<DesiredVolume>12</DesiredVolume>") {
+		t.Fatalf("unexpected member volume restore: %s", calls[7])
+	}
+}
+
+func TestSnapshotUpdateIPs(t *testing.T) {
+	t.Parallel()
+
+	snap := Snapshot{
+		CoordinatorUUID: "RINCON_A1400",
+		CoordinatorIP:   "192.0.2.1",
+		Members: []SnapshotMember{
+			{UUID: "RINCON_A1400", IP: "192.0.2.1"},
+			{UUID: "RINCON_B1400", IP: "192.0.2.2"},
+		},
+	}
+	top := Topology{ByIP: map[string]Member{
+		"192.0.2.10": {UUID: "RINCON_A1400", IP: "192.0.2.10"},
+	}}
+	got := snap.UpdateIPs(top)
+	if got.CoordinatorIP != "192.0.2.10" || got.Members[0].IP != "192.0.2.10" || got.Members[1].IP != "192.0.2.2" {
+		t.Fatalf("unexpected snapshot: %+v", got)
+	}
+	if snap.Members[0].IP != "192.0.2.1" {
+		t.Fatalf("UpdateIPs must not modify the original snapshot")
+	}
+}
@@ -25,6 +25,17 @@ func (e *UPnPError) Error() string {
 	return fmt.Sprintf("upnp error %s: %s", e.Code, e.Description)
 }
 
+// HTTPError is a SOAP response with an unexpected HTTP status and no UPnP
+// error body, e.g. 404 when the speaker does not have the service.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *HTTPError) Error() string {
+	return "soap http " + e.Status
+}
+
 func soapCall(ctx context.Context, httpClient *http.Client, endpointURL, serviceURN, action string, args map[string]string) (map[string]string, error) {
 	body := buildSOAPEnvelope(serviceURN, action, args)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpointURL, bytes.NewReader(body))
@@ -57,7 +68,7 @@ func soapCall(ctx context.Context, httpClient *http.Client, endpointURL, service
 			return nil, upnpErr
 		}
 	}
-	return nil, fmt.Errorf("soap http %s", resp.Status)
+	return nil, &HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
 }
 
 func buildSOAPEnvelope(serviceURN, action string, args map[string]string) []byte {
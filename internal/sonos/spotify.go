@@ -157,13 +157,14 @@ func buildShareDIDL(itemID, title, itemClass string, serviceNum int) string {
 	// desc: SA_RINCON{sn}_X_#Svc{sn}-0-Token
 	desc := fmt.Sprintf("SA_RINCON%d_X_#Svc%d-0-Token", serviceNum, serviceNum)
 
-	return fmt.Sprintf(
-		`<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns:r="urn:schemas-rinconnetworks-com:metadata-1-0/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/"><item id="%s" parentID="-1" restricted="true"><dc:title>%s</dc:title><upnp:class>%s</upnp:class><desc id="cdudn" nameSpace="urn:schemas-rinconnetworks-com:metadata-1-0/">%s</desc></item></DIDL-Lite>`,
-		xmlEscapeText(itemID),
-		xmlEscapeText(title),
-		xmlEscapeText(itemClass),
-		xmlEscapeText(desc),
-	)
+	return BuildDIDL(DIDLItem{
+		ID:         itemID,
+		ParentID:   "-1",
+		Restricted: "true",
+		Title:      title,
+		Class:      itemClass,
+		Desc:       []DIDLDesc{{ID: "cdudn", NameSpace: nsRincon, Value: desc}},
+	})
 }
 
 func (c *Client) playFromQueueTrack(ctx context.Context, oneBasedTrackNumber int) error {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"net/http"
+	"testing"
 )
 
 type roundTripperFunc func(*http.Request) (*http.Response, error)
@@ -17,3 +18,15 @@ func httpResponse(status int, body string) *http.Response {
 		Header:     make(http.Header),
 	}
 }
+
+func readBody(t *testing.T, r *http.Request) string {
+	t.Helper()
+	if r.Body == nil {
+		return ""
+	}
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return string(b)
+}
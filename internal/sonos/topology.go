@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/xml"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -14,6 +15,20 @@ type Member struct {
 	Location      string `json:"location"`
 	IsVisible     bool   `json:"isVisible"`
 	IsCoordinator bool   `json:"isCoordinator"`
+	// ChannelMapSet maps the two speakers of a stereo pair to their channels,
+	// e.g. "RINCON_L:LF,LF;RINCON_R:RF,RF".
+	ChannelMapSet string `json:"channelMapSet,omitempty"`
+	// HTSatChanMapSet maps the devices of a home-theater bond to their
+	// channels, e.g. "RINCON_A:LF,RF;RINCON_B:SW;RINCON_C:LR;RINCON_D:RR".
+	HTSatChanMapSet string `json:"htSatChanMapSet,omitempty"`
+	// Satellites are the home-theater devices nested under this member in
+	// the ZoneGroupState. They are also listed as group members, so JSON
+	// output only shows them there.
+	Satellites      []Member `json:"-"`
+	SoftwareVersion string   `json:"softwareVersion,omitempty"`
+	BootSeq         int      `json:"bootSeq,omitempty"`
+	// WirelessMode is the raw ZoneGroupMember attribute.
+	WirelessMode int `json:"wirelessMode,omitempty"`
 }
 
 type Group struct {
@@ -56,10 +71,15 @@ type zgsGroup struct {
 }
 
 type zgsMember struct {
-	ZoneName  string `xml:"ZoneName,attr"`
-	Location  string `xml:"Location,attr"`
-	UUID      string `xml:"UUID,attr"`
-	Invisible string `xml:"Invisible,attr"`
+	ZoneName        string `xml:"ZoneName,attr"`
+	Location        string `xml:"Location,attr"`
+	UUID            string `xml:"UUID,attr"`
+	Invisible       string `xml:"Invisible,attr"`
+	HTSatChanMapSet string `xml:"HTSatChanMapSet,attr"`
+	ChannelMapSet   string `xml:"ChannelMapSet,attr"`
+	SoftwareVersion string `xml:"SoftwareVersion,attr"`
+	BootSeq         string `xml:"BootSeq,attr"`
+	WirelessMode    string `xml:"WirelessMode,attr"`
 	// Home-theater satellites appear nested under a ZoneGroupMember.
 	// Some firmwares also use nested members for bonded devices.
 	Satellites []zgsMember `xml:"Satellite"`
@@ -110,8 +130,21 @@ func parseZoneGroupStateXML(payload string) (Topology, error) {
 		members := make([]Member, 0, len(g.Members))
 		var coordinator Member
 		for _, m := range g.Members {
+			// Include nested satellites (and other nested members) if present.
+			var sats []Member
+			for _, sat := range m.Satellites {
+				smem, ok := toMember("", sat)
+				if !ok {
+					continue
+				}
+				// Satellites cannot be coordinators.
+				smem.IsCoordinator = false
+				sats = append(sats, smem)
+			}
+
 			mem, ok := toMember(g.Coordinator, m)
 			if ok {
+				mem.Satellites = sats
 				if mem.IsCoordinator {
 					coordinator = mem
 				}
@@ -123,14 +156,7 @@ func parseZoneGroupStateXML(payload string) (Topology, error) {
 				}
 			}
 
-			// Include nested satellites (and other nested members) if present.
-			for _, sat := range m.Satellites {
-				smem, ok := toMember("", sat)
-				if !ok {
-					continue
-				}
-				// Satellites cannot be coordinators.
-				smem.IsCoordinator = false
+			for _, smem := range sats {
 				members = append(members, smem)
 				setByName(smem)
 				t.ByIP[smem.IP] = smem
@@ -165,12 +191,17 @@ func toMember(groupCoordinatorUUID string, m zgsMember) (Member, bool) {
 		return Member{}, false
 	}
 	mem := Member{
-		Name:      m.ZoneName,
-		IP:        ip,
-		UUID:      m.UUID,
-		Location:  m.Location,
-		IsVisible: m.Invisible != "1",
+		Name:            m.ZoneName,
+		IP:              ip,
+		UUID:            m.UUID,
+		Location:        m.Location,
+		IsVisible:       m.Invisible != "1",
+		ChannelMapSet:   m.ChannelMapSet,
+		HTSatChanMapSet: m.HTSatChanMapSet,
+		SoftwareVersion: strings.TrimSpace(m.SoftwareVersion),
 	}
+	mem.BootSeq, _ = strconv.Atoi(strings.TrimSpace(m.BootSeq))
+	mem.WirelessMode, _ = strconv.Atoi(strings.TrimSpace(m.WirelessMode))
 	if groupCoordinatorUUID != "" {
 		mem.IsCoordinator = mem.UUID == groupCoordinatorUUID
 	}
@@ -187,6 +218,18 @@ func (t Topology) FindByIP(ip string) (Member, bool) {
 	return mem, ok
 }
 
+func (t Topology) FindByUUID(uuid string) (Member, bool) {
+	if mem, ok := t.byUUID[uuid]; ok {
+		return mem, true
+	}
+	for _, mem := range t.ByIP {
+		if mem.UUID == uuid {
+			return mem, true
+		}
+	}
+	return Member{}, false
+}
+
 func (t Topology) GroupForIP(ip string) (Group, bool) {
 	for _, g := range t.Groups {
 		for _, m := range g.Members {
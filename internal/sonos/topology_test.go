@@ -1,6 +1,10 @@
 package sonos
 
-import "testing"
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
 
 func TestParseZoneGroupStateXML(t *testing.T) {
 	payload := `
@@ -93,3 +97,54 @@ func TestTopology_FindByIP_AndCoordinatorUUIDForIP(t *testing.T) {
 		t.Fatalf("expected CoordinatorUUIDForIP to fail for unknown ip")
 	}
 }
+
+func TestParseZoneGroupStateXML_MemberDetails(t *testing.T) {
+	payload := `
+<ZoneGroupState>
+  <ZoneGroups>
+    <ZoneGroup Coordinator="RINCON_ARC1400" ID="RINCON_ARC1400:3">
+      <ZoneGroupMember ZoneName="Living Room" UUID="RINCON_ARC1400" Location="http://192.168.1.50:1400/xml/device_description.xml" SoftwareVersion="79.1-56030" BootSeq="42" WirelessMode="0" HTSatChanMapSet="RINCON_ARC1400:LF,RF;RINCON_SUB1400:SW;RINCON_SL1400:LR;RINCON_SR1400:RR">
+        <Satellite ZoneName="Living Room" UUID="RINCON_SUB1400" Location="http://192.168.1.51:1400/xml/device_description.xml" Invisible="1" SoftwareVersion="79.1-56030" BootSeq="17" WirelessMode="1" />
+        <Satellite ZoneName="Living Room" UUID="RINCON_SL1400" Location="http://192.168.1.52:1400/xml/device_description.xml" Invisible="1" />
+        <Satellite ZoneName="Living Room" UUID="RINCON_SR1400" Location="http://192.168.1.53:1400/xml/device_description.xml" Invisible="1" />
+      </ZoneGroupMember>
+    </ZoneGroup>
+  </ZoneGroups>
+</ZoneGroupState>`
+
+	top, err := parseZoneGroupStateXML(payload)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	arc := top.Groups[0].Coordinator
+	if arc.SoftwareVersion != "79.1-56030" || arc.BootSeq != 42 || arc.WirelessMode != 0 {
+		t.Fatalf("arc details: %+v", arc)
+	}
+	if len(arc.Satellites) != 3 || arc.Satellites[0].UUID != "RINCON_SUB1400" {
+		t.Fatalf("satellites: %+v", arc.Satellites)
+	}
+	if sub := arc.Satellites[0]; sub.BootSeq != 17 || sub.WirelessMode != 1 || sub.IsVisible {
+		t.Fatalf("sub details: %+v", sub)
+	}
+	// Satellites are still group members and addressable by IP.
+	if len(top.Groups[0].Members) != 4 {
+		t.Fatalf("members: %d", len(top.Groups[0].Members))
+	}
+	if mem, ok := top.FindByIP("192.168.1.53"); !ok || mem.UUID != "RINCON_SR1400" {
+		t.Fatalf("FindByIP: %v %+v", ok, mem)
+	}
+
+	// JSON keeps its shape: satellites appear once (as members) and an unset
+	// wireless mode is left out.
+	b, err := json.Marshal(top)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	s := string(b)
+	if strings.Contains(s, `"satellites"`) || strings.Contains(s, `"wirelessMode":0`) {
+		t.Fatalf("unexpected JSON: %s", s)
+	}
+	if strings.Count(s, `"uuid":"RINCON_SUB1400"`) != 1 {
+		t.Fatalf("sub serialized more than once: %s", s)
+	}
+}